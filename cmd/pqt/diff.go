@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"io"
+
+	"github.com/piotrkowalczuk/pqt/pqtsql"
+)
+
+func diff(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	version := fs.Float64("version", 9.5, "version of Postgres code will run against")
+	out := fs.String("o", "", "output file, standard output if empty")
+	if err := parseFlags(fs, args, stderr, "from", "to"); err != nil {
+		return err
+	}
+
+	from, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	to, err := load(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	w, closer, err := output(*out, stdout)
+	if err != nil {
+		return err
+	}
+	if err := (&pqtsql.Generator{Version: *version}).GenerateMigrationTo(from, to, w); err != nil {
+		closer()
+		return err
+	}
+	return closer()
+}
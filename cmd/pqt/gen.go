@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt/pqtgo/pqtgogen"
+	"github.com/piotrkowalczuk/pqt/pqtsql"
+)
+
+var components = map[string]pqtgogen.Component{
+	"insert":     pqtgogen.ComponentInsert,
+	"find":       pqtgogen.ComponentFind,
+	"update":     pqtgogen.ComponentUpdate,
+	"upsert":     pqtgogen.ComponentUpsert,
+	"count":      pqtgogen.ComponentCount,
+	"delete":     pqtgogen.ComponentDelete,
+	"helpers":    pqtgogen.ComponentHelpers,
+	"partitions": pqtgogen.ComponentPartitions,
+	"repository": pqtgogen.ComponentRepository,
+	"all":        pqtgogen.ComponentAll,
+}
+
+// parseComponents turns comma separated list of component names into bit mask.
+func parseComponents(s string) (pqtgogen.Component, error) {
+	var c pqtgogen.Component
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		comp, ok := components[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown component: %s", name)
+		}
+		c |= comp
+	}
+	return c, nil
+}
+
+func genGo(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("gen go", flag.ContinueOnError)
+	pkg := fs.String("pkg", "main", "name of the package code is generated into")
+	version := fs.Float64("version", 9.5, "version of Postgres code will run against")
+	comps := fs.String("components", "all", "comma separated list of components: insert, find, update, upsert, count, delete, helpers, partitions, repository, all (partitions is not a part of all)")
+	imports := fs.String("imports", "", "comma separated list of additional imports")
+	sqlConst := fs.String("sql-const", "", "if set, SQL schema is appended to the output as a constant of given name")
+	out := fs.String("o", "", "output file, standard output if empty")
+	if err := parseFlags(fs, args, stderr, "schema"); err != nil {
+		return err
+	}
+
+	c, err := parseComponents(*comps)
+	if err != nil {
+		return err
+	}
+	s, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	g := &pqtgogen.Generator{
+		Pkg:        *pkg,
+		Version:    *version,
+		Components: c,
+	}
+	for _, imp := range strings.Split(*imports, ",") {
+		if imp = strings.TrimSpace(imp); imp != "" {
+			g.Imports = append(g.Imports, imp)
+		}
+	}
+	code, err := g.Generate(s)
+	if err != nil {
+		return err
+	}
+
+	w, closer, err := output(*out, stdout)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(code); err != nil {
+		closer()
+		return err
+	}
+	if *sqlConst != "" {
+		sql, err := (&pqtsql.Generator{Version: *version}).Generate(s)
+		if err != nil {
+			closer()
+			return err
+		}
+		if strings.Contains(string(sql), "`") {
+			closer()
+			return fmt.Errorf("SQL schema contains backtick, it cannot be represented as raw string literal")
+		}
+		fmt.Fprintf(w, "\n// %s is a schema the code was generated for.\nconst %s = `\n%s`\n", *sqlConst, *sqlConst, sql)
+	}
+	return closer()
+}
+
+func genSQL(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("gen sql", flag.ContinueOnError)
+	version := fs.Float64("version", 9.5, "version of Postgres code will run against")
+	out := fs.String("o", "", "output file, standard output if empty")
+	if err := parseFlags(fs, args, stderr, "schema"); err != nil {
+		return err
+	}
+
+	s, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	w, closer, err := output(*out, stdout)
+	if err != nil {
+		return err
+	}
+	if err := (&pqtsql.Generator{Version: *version}).GenerateTo(s, w); err != nil {
+		closer()
+		return err
+	}
+	return closer()
+}
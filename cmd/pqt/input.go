@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtddl"
+	"github.com/piotrkowalczuk/pqt/pqtfile"
+)
+
+// load reads schema from the declarative file, DDL file or Go package.
+func load(src string) (*pqt.Schema, error) {
+	switch filepath.Ext(src) {
+	case ".json", ".yaml", ".yml":
+		return pqtfile.Load(src)
+	case ".sql":
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return pqtddl.Parse(f)
+	default:
+		return loadPackage(src)
+	}
+}
+
+var program = template.Must(template.New("program").Parse(`// Code generated by pqt. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	schema {{ printf "%q" . }}
+	"github.com/piotrkowalczuk/pqt/pqtfile"
+)
+
+func main() {
+	data, err := pqtfile.Marshal(schema.Schema(), pqtfile.FormatJSON)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(data)
+}
+`))
+
+// loadPackage builds and runs a program that calls Schema function of the given package and prints the result.
+// The program is placed in the current directory, so it is built within the module of the package.
+func loadPackage(pkg string) (*pqt.Schema, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.ImportPath}} {{.Name}}", pkg).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("package %s cannot be listed: %s", pkg, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, err
+	}
+	parts := strings.Fields(string(out))
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("package %s cannot be listed: unexpected output: %s", pkg, out)
+	}
+	if parts[1] == "main" {
+		return nil, fmt.Errorf("package %s is a command, schema needs to be exposed by importable package", pkg)
+	}
+
+	dir, err := os.MkdirTemp(".", "pqt")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	buf := bytes.NewBuffer(nil)
+	if err := program.Execute(buf, parts[0]); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), buf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", "."+string(filepath.Separator)+dir)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("schema of package %s cannot be obtained: %s", pkg, strings.TrimSpace(stderr.String()))
+	}
+
+	return pqtfile.Unmarshal(stdout.Bytes(), pqtfile.FormatJSON)
+}
@@ -0,0 +1,113 @@
+// Command pqt generates Go and SQL code out of pqt schema.
+//
+// Usage:
+//
+//	pqt gen go [flags] <schema>
+//	pqt gen sql [flags] <schema>
+//	pqt diff [flags] <from> <to>
+//	pqt validate <schema>
+//
+// Schema can be a declarative schema file (.json, .yaml, .yml), a PostgreSQL DDL file (.sql)
+// or a Go package that exposes function:
+//
+//	func Schema() *pqt.Schema
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `pqt generates Go and SQL code out of pqt schema.
+
+Usage:
+
+	pqt gen go [flags] <schema>
+	pqt gen sql [flags] <schema>
+	pqt diff [flags] <from> <to>
+	pqt validate <schema>
+
+Schema can be a declarative schema file (.json, .yaml, .yml), a PostgreSQL DDL file (.sql)
+or a Go package that exposes "func Schema() *pqt.Schema".
+
+Run "pqt <command> -h" to list flags of the command.
+`
+
+// errUsage is returned if command was called with wrong arguments. Usage is printed already.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != errUsage {
+			fmt.Fprintf(os.Stderr, "pqt: %s\n", err.Error())
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "gen":
+		if len(args) < 2 {
+			fmt.Fprint(stderr, usage)
+			return errUsage
+		}
+		switch args[1] {
+		case "go":
+			return genGo(args[2:], stdout, stderr)
+		case "sql":
+			return genSQL(args[2:], stdout, stderr)
+		}
+	case "diff":
+		return diff(args[1:], stdout, stderr)
+	case "validate":
+		return validate(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	fmt.Fprint(stderr, usage)
+	return errUsage
+}
+
+// parseFlags parses arguments of the command and checks number of positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, stderr io.Writer, positional ...string) error {
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: pqt %s [flags]", fs.Name())
+		for _, p := range positional {
+			fmt.Fprintf(stderr, " <%s>", p)
+		}
+		fmt.Fprint(stderr, "\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != len(positional) {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// output returns writer for given path. Standard output is used if path is empty.
+func output(path string, stdout io.Writer) (io.Writer, func() error, error) {
+	if path == "" {
+		return stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
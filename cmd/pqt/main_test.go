@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt/pqtgo/pqtgogen"
+)
+
+func TestRun_genSQL(t *testing.T) {
+	var fromFile, fromPackage bytes.Buffer
+	if err := run([]string{"gen", "sql", "testdata/schema.yaml"}, &fromFile, os.Stderr); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if err := run([]string{"gen", "sql", "./testdata/schema"}, &fromPackage, os.Stderr); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if fromFile.String() != fromPackage.String() {
+		t.Errorf("output for file and package should be the same, got:\n%s\nand:\n%s", fromFile.String(), fromPackage.String())
+	}
+	if !strings.Contains(fromFile.String(), `CREATE TABLE IF NOT EXISTS example."user" (`) {
+		t.Errorf("missing table definition:\n%s", fromFile.String())
+	}
+}
+
+func TestRun_genGo(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "schema.pqt.go")
+	args := []string{"gen", "go", "-pkg", "model", "-components", "find,count", "-sql-const", "SQL", "-o", out, "testdata/schema.yaml"}
+	if err := run(args, os.Stdout, os.Stderr); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	code, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for _, expected := range []string{
+		"package model",
+		"func (r *UserRepositoryBase) Find(",
+		"func (r *UserRepositoryBase) Count(",
+		"const SQL = `\n-- sql schema beginning",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("output is missing %q", expected)
+		}
+	}
+	if strings.Contains(string(code), "func (r *UserRepositoryBase) Insert(") {
+		t.Error("output should not contain insert method")
+	}
+}
+
+func TestRun_diff(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"diff", "testdata/legacy.sql", "testdata/schema.yaml"}, &buf, os.Stderr); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for _, expected := range []string{
+		`ALTER TABLE example."user" ALTER COLUMN username SET NOT NULL;`,
+		`ALTER TABLE example."user" ADD CONSTRAINT "example.user_username_key" UNIQUE (username);`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("output is missing %q:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestRun_validate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"validate", "testdata/schema.yaml"}, &buf, os.Stderr); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if buf.String() != "testdata/schema.yaml: ok\n" {
+		t.Errorf("wrong output: %s", buf.String())
+	}
+}
+
+func TestRun_validateInvalid(t *testing.T) {
+	var stderr bytes.Buffer
+	err := run([]string{"validate", "testdata/invalid.yaml"}, os.Stdout, &stderr)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != "schema testdata/invalid.yaml has 2 problem(s)" {
+		t.Errorf("wrong error: %s", err.Error())
+	}
+	if lines := strings.Count(stderr.String(), "\n"); lines != 2 {
+		t.Errorf("expected 2 problems to be printed, got %d:\n%s", lines, stderr.String())
+	}
+}
+
+func TestRun_usage(t *testing.T) {
+	cases := [][]string{
+		nil,
+		{"gen"},
+		{"gen", "rust"},
+		{"unknown"},
+		{"diff", "testdata/schema.yaml"},
+		{"gen", "go", "-unknown", "testdata/schema.yaml"},
+	}
+	for _, args := range cases {
+		var stderr bytes.Buffer
+		if err := run(args, os.Stdout, &stderr); err != errUsage {
+			t.Errorf("%v: expected usage error, got: %v", args, err)
+		}
+		if stderr.Len() == 0 {
+			t.Errorf("%v: usage should be printed", args)
+		}
+	}
+}
+
+func TestParseComponents(t *testing.T) {
+	got, err := parseComponents("find, insert,helpers")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if expected := pqtgogen.ComponentFind | pqtgogen.ComponentInsert | pqtgogen.ComponentHelpers; got != expected {
+		t.Errorf("wrong components, expected %b but got %b", expected, got)
+	}
+	if got, err := parseComponents("all,partitions"); err != nil || got != pqtgogen.ComponentAll|pqtgogen.ComponentPartitions {
+		t.Errorf("partitions should be accepted on top of all, got %b, %v", got, err)
+	}
+	if _, err := parseComponents("find,unknown"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,6 @@
+CREATE SCHEMA example;
+
+CREATE TABLE example.user (
+	id BIGSERIAL PRIMARY KEY,
+	username TEXT
+);
@@ -0,0 +1,13 @@
+// Package schema is used to test loading schema out of Go package.
+package schema
+
+import "github.com/piotrkowalczuk/pqt"
+
+// Schema ...
+func Schema() *pqt.Schema {
+	user := pqt.NewTable("user", pqt.WithTableIfNotExists()).
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("username", pqt.TypeText(), pqt.WithNotNull(), pqt.WithUnique()))
+
+	return pqt.NewSchema("example").AddTable(user)
+}
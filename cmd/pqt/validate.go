@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtgo/pqtgogen"
+	"github.com/piotrkowalczuk/pqt/pqtsql"
+)
+
+// validate checks if schema can be loaded, is valid and can be turned into both SQL and Go code.
+// Every problem found by pqt.Schema.Validate is printed in separate line.
+func validate(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	version := fs.Float64("version", 9.5, "version of Postgres code will run against")
+	if err := parseFlags(fs, args, stderr, "schema"); err != nil {
+		return err
+	}
+
+	s, err := load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if err := s.Validate(); err != nil {
+		if errs, ok := err.(pqt.ValidationErrors); ok {
+			for _, e := range errs {
+				fmt.Fprintf(stderr, "%s: %s\n", fs.Arg(0), e.Error())
+			}
+			return fmt.Errorf("schema %s has %d problem(s)", fs.Arg(0), len(errs))
+		}
+		return err
+	}
+	if _, err := (&pqtsql.Generator{Version: *version}).Generate(s); err != nil {
+		return fmt.Errorf("sql generation failure: %s", err.Error())
+	}
+	if _, err := (&pqtgogen.Generator{Version: *version, Components: pqtgogen.ComponentAll}).Generate(s); err != nil {
+		return fmt.Errorf("go generation failure: %s", err.Error())
+	}
+
+	fmt.Fprintf(stdout, "%s: ok\n", fs.Arg(0))
+	return nil
+}
@@ -2,6 +2,7 @@ package pqt
 
 import (
 	"bytes"
+	"sort"
 	"strings"
 )
 
@@ -10,8 +11,22 @@ const (
 	EventInsert Event = "INSERT"
 	// EventUpdate ...
 	EventUpdate Event = "UPDATE"
+	// EventDelete can be used only by triggers.
+	EventDelete Event = "DELETE"
+	// EventTruncate can be used only by triggers.
+	EventTruncate Event = "TRUNCATE"
 )
 
+const (
+	// IdentityAlways makes identity column reject explicit values, unless OVERRIDING SYSTEM VALUE is specified.
+	IdentityAlways Identity = "ALWAYS"
+	// IdentityByDefault makes identity column accept explicit values.
+	IdentityByDefault Identity = "BY DEFAULT"
+)
+
+// Identity describes how identity column treats values provided explicitly.
+type Identity string
+
 // Event ...
 type Event string
 
@@ -52,8 +67,9 @@ type Column struct {
 	OnDelete int32
 	// OnUpdate is a ON UPDATE clause that specifies the action to perform when a referenced column in the referenced table is being updated to a new value.
 	OnUpdate int32
-	// NoInherit if true means that column is not inherited by child tables.
-	NoInherit                    bool
+	// NoInherit if true means that check constraint of the column is not inherited by child tables.
+	NoInherit bool
+	// DeferrableInitiallyDeferred and DeferrableInitiallyImmediate describe when foreign key constraint is checked.
 	DeferrableInitiallyDeferred  bool
 	DeferrableInitiallyImmediate bool
 	// IsDynamic if true means that column is not stored in database, but is dynamically created using function.
@@ -62,6 +78,17 @@ type Column struct {
 	Func *Function
 	// Columns are columns that are used by dynamic column function.
 	Columns Columns
+	// Identity if set means that column value is generated from implicit sequence.
+	Identity Identity
+	// Generated is an expression that computes value of the stored generated column.
+	Generated string
+	// Comment describes the column, it is stored in the database and used as a documentation of generated code.
+	Comment string
+	// Position if set moves the column relative to other columns of the table, regardless of column order.
+	// Columns with lower position come first, columns without one have position zero.
+	Position int
+	// ordinal is a number of columns that were added to the table before this one.
+	ordinal int
 }
 
 // NewColumn initializes new instance of Column.
@@ -119,6 +146,7 @@ func (c *Column) Constraints() []*Constraint {
 			Check:          c.Check,
 			PrimaryColumns: Columns{c},
 			PrimaryTable:   c.Table,
+			NoInherit:      c.NoInherit,
 		})
 	}
 	if c.Reference != nil {
@@ -128,12 +156,31 @@ func (c *Column) Constraints() []*Constraint {
 			PrimaryTable:   c.Table,
 			Columns:        Columns{c.Reference},
 			Table:          c.Reference.Table,
+			OnDelete:       c.OnDelete,
+			OnUpdate:       c.OnUpdate,
+			Match:          c.Match,
+
+			DeferrableInitiallyDeferred:  c.DeferrableInitiallyDeferred,
+			DeferrableInitiallyImmediate: c.DeferrableInitiallyImmediate,
 		})
 	}
 
 	return cs
 }
 
+// QuotedName returns name of the column quoted according to quoting policy of the schema.
+func (c *Column) QuotedName() string {
+	if c.Table == nil {
+		return QuoteWhenNeeded.Quote(c.Name)
+	}
+	return c.Table.Schema.Quote(c.Name)
+}
+
+// IsReadOnly returns true if value of the column cannot be written, because it is computed by the database.
+func (c *Column) IsReadOnly() bool {
+	return c.IsDynamic || c.Identity != "" || c.Generated != ""
+}
+
 // DefaultOn ...
 func (c Column) DefaultOn(e ...Event) (string, bool) {
 	for k, v := range c.Default {
@@ -161,10 +208,26 @@ func (c Columns) Swap(i, j int) {
 }
 
 // Less implements sort.Interface interface.
+// Columns are ordered by position and then by name.
 func (c Columns) Less(i, j int) bool {
+	if c[i].Position != c[j].Position {
+		return c[i].Position < c[j].Position
+	}
 	return c[i].Name < c[j].Name
 }
 
+// Sort sorts columns by position and then in given order.
+// For ColumnOrderDeclared columns are expected to be given in order of declaration, which is kept.
+func (c Columns) Sort(order ColumnOrder) {
+	if order == ColumnOrderDeclared {
+		sort.SliceStable(c, func(i, j int) bool {
+			return c[i].Position < c[j].Position
+		})
+		return
+	}
+	sort.Stable(c)
+}
+
 // String implements Stringer interface.
 func (c Columns) String() string {
 	b := bytes.NewBuffer(nil)
@@ -180,6 +243,16 @@ func (c Columns) String() string {
 	return b.String()
 }
 
+// JoinQuotedColumns works like JoinColumns, but column names are quoted according to quoting policy of their schema.
+func JoinQuotedColumns(columns Columns, sep string) string {
+	tmp := make([]string, 0, len(columns))
+	for _, c := range columns {
+		tmp = append(tmp, c.QuotedName())
+	}
+
+	return strings.Join(tmp, sep)
+}
+
 // JoinColumns ...
 func JoinColumns(columns Columns, sep string) string {
 	tmp := make([]string, 0, len(columns))
@@ -241,6 +314,35 @@ func WithCheck(ch string) ColumnOption {
 	}
 }
 
+// WithNoInherit marks check constraint of the column as not inherited by child tables.
+func WithNoInherit() ColumnOption {
+	return func(c *Column) {
+		c.NoInherit = true
+	}
+}
+
+// WithComment pass the comment that describes the column.
+func WithComment(s string) ColumnOption {
+	return func(c *Column) {
+		c.Comment = s
+	}
+}
+
+// WithIdentity makes column an identity column, identity columns are implicitly not null.
+func WithIdentity(i Identity) ColumnOption {
+	return func(c *Column) {
+		c.Identity = i
+		c.NotNull = true
+	}
+}
+
+// WithGenerated makes column a stored generated column, computed using given expression.
+func WithGenerated(expr string) ColumnOption {
+	return func(c *Column) {
+		c.Generated = expr
+	}
+}
+
 // WithUnique ...
 func WithUnique() ColumnOption {
 	return func(c *Column) {
@@ -315,6 +417,37 @@ func WithOnUpdate(on int32) ColumnOption {
 	}
 }
 
+// WithReferenceMatch sets match type of foreign key created from the reference, MatchFull or MatchSimple.
+func WithReferenceMatch(match int32) ColumnOption {
+	return func(c *Column) {
+		c.Match = match
+	}
+}
+
+// WithReferenceDeferrable makes foreign key created from the reference DEFERRABLE INITIALLY IMMEDIATE.
+func WithReferenceDeferrable() ColumnOption {
+	return func(c *Column) {
+		c.DeferrableInitiallyImmediate = true
+		c.DeferrableInitiallyDeferred = false
+	}
+}
+
+// WithReferenceInitiallyDeferred makes foreign key created from the reference DEFERRABLE INITIALLY DEFERRED.
+func WithReferenceInitiallyDeferred() ColumnOption {
+	return func(c *Column) {
+		c.DeferrableInitiallyDeferred = true
+		c.DeferrableInitiallyImmediate = false
+	}
+}
+
+// WithPosition pass the position of the column within the table.
+// Negative position moves the column before columns without one, e.g. to keep the primary key first.
+func WithPosition(p int) ColumnOption {
+	return func(c *Column) {
+		c.Position = p
+	}
+}
+
 // WithColumnShortName ...
 func WithColumnShortName(s string) ColumnOption {
 	return func(c *Column) {
@@ -180,6 +180,64 @@ func TestWithOnUpdate(t *testing.T) {
 	}
 }
 
+func TestWithReferenceMatch(t *testing.T) {
+	parent := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	pqt.NewTable("parent").AddColumn(parent)
+	c := pqt.NewColumn("parent_id", pqt.TypeIntegerBig(),
+		pqt.WithReference(parent),
+		pqt.WithReferenceMatch(pqt.MatchFull),
+		pqt.WithReferenceInitiallyDeferred(),
+	)
+	child := pqt.NewTable("child").AddColumn(c)
+
+	fk := child.Constraints[0]
+	if fk.Type != pqt.ConstraintTypeForeignKey {
+		t.Fatalf("foreign key expected, got %s", fk.Type)
+	}
+	if fk.Match != pqt.MatchFull {
+		t.Errorf("wrong match type: %d", fk.Match)
+	}
+	if !fk.DeferrableInitiallyDeferred {
+		t.Errorf("foreign key should be deferrable initially deferred")
+	}
+	if c.Match != pqt.MatchDefault || c.DeferrableInitiallyDeferred {
+		t.Errorf("reference settings should be moved from column to the constraint")
+	}
+}
+
+func TestWithReferenceDeferrable(t *testing.T) {
+	c := pqt.NewColumn("parent_id", pqt.TypeIntegerBig(), pqt.WithReferenceInitiallyDeferred(), pqt.WithReferenceDeferrable())
+	if c.DeferrableInitiallyDeferred || !c.DeferrableInitiallyImmediate {
+		t.Errorf("column reference should be deferrable initially immediate")
+	}
+}
+
+func TestWithIdentity(t *testing.T) {
+	c := pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityByDefault))
+	if c.Identity != pqt.IdentityByDefault {
+		t.Errorf("wrong identity: %s", c.Identity)
+	}
+	if !c.NotNull {
+		t.Error("identity column expected to be not null")
+	}
+	if !c.IsReadOnly() {
+		t.Error("identity column expected to be read only")
+	}
+}
+
+func TestWithGenerated(t *testing.T) {
+	c := pqt.NewColumn("full_name", pqt.TypeText(), pqt.WithGenerated("first_name || ' ' || last_name"))
+	if c.Generated != "first_name || ' ' || last_name" {
+		t.Errorf("wrong generation expression: %s", c.Generated)
+	}
+	if !c.IsReadOnly() {
+		t.Error("generated column expected to be read only")
+	}
+	if pqt.NewColumn("name", pqt.TypeText()).IsReadOnly() {
+		t.Error("regular column expected not to be read only")
+	}
+}
+
 func TestWithColumnShortName(t *testing.T) {
 	given := "short-name"
 	c := pqt.NewColumn("short_name", pqt.TypeBool(), pqt.WithColumnShortName(given))
@@ -188,6 +246,31 @@ func TestWithColumnShortName(t *testing.T) {
 	}
 }
 
+func TestWithPosition(t *testing.T) {
+	c := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPosition(-1))
+	if c.Position != -1 {
+		t.Errorf("wrong position: %d", c.Position)
+	}
+}
+
+func TestColumns_Sort(t *testing.T) {
+	given := pqt.Columns{
+		&pqt.Column{Name: "c"},
+		&pqt.Column{Name: "b", Position: 1},
+		&pqt.Column{Name: "a"},
+		&pqt.Column{Name: "d", Position: -1},
+	}
+
+	given.Sort(pqt.ColumnOrderDeclared)
+	if exp := "d,c,a,b"; given.String() != exp {
+		t.Errorf("wrong declared order, expected %s but got %s", exp, given.String())
+	}
+	given.Sort(pqt.ColumnOrderAlphabetical)
+	if exp := "d,a,c,b"; given.String() != exp {
+		t.Errorf("wrong alphabetical order, expected %s but got %s", exp, given.String())
+	}
+}
+
 func TestColumns_String(t *testing.T) {
 	given := pqt.Columns{
 		&pqt.Column{Name: "1"},
@@ -3,8 +3,6 @@ package pqt
 import (
 	"crypto/md5"
 	"encoding/base64"
-	"fmt"
-	"strings"
 )
 
 const (
@@ -28,6 +26,16 @@ const (
 
 type ConstraintType string
 
+const (
+	// MatchDefault leaves match type of foreign key up to the database, which is MATCH SIMPLE.
+	MatchDefault int32 = iota
+	// MatchSimple allows any of the foreign key columns to be null.
+	// If any of them are null, the row is not required to have a match in the referenced table.
+	MatchSimple
+	// MatchFull does not allow one column of a multicolumn foreign key to be null unless all foreign key columns are null.
+	MatchFull
+)
+
 // ConstraintOption ...
 type ConstraintOption func(*Constraint)
 
@@ -41,43 +49,213 @@ type Constraint struct {
 	Match, OnDelete, OnUpdate                                            int32
 	NoInherit, DeferrableInitiallyDeferred, DeferrableInitiallyImmediate bool
 	MethodSuffix                                                         string
+	// Method is an index method used by index or exclusion constraint, e.g. gin or gist.
+	Method string
+	// Exclude is a collection of elements compared by exclusion constraint.
+	Exclude []*ExclusionElement
+	// Elements if not empty describe columns and expressions of an index, PrimaryColumns holds only columns.
+	Elements []*IndexElement
+	// Include is a list of non-key columns of an index.
+	Include Columns
+	// Concurrently if true means that index is built and dropped without locking out writes.
+	Concurrently bool
+	// Comment describes the constraint, it is stored in the database.
+	Comment string
+	// ExplicitName if not empty is used as the name of the constraint instead of the generated one.
+	ExplicitName string
 }
 
-// Name ...
-func (c *Constraint) Name() string {
-	var schema string
+const (
+	// NullsDefault leaves the position of null values up to the sort order.
+	NullsDefault NullsOrder = iota
+	// NullsFirst places null values before non-null values.
+	NullsFirst
+	// NullsLast places null values after non-null values.
+	NullsLast
+)
 
-	switch {
-	case c.PrimaryTable == nil:
-		return "<missing table>"
-	case c.PrimaryTable.Schema == nil || c.PrimaryTable.Schema.Name == "":
-		schema = "public"
-	default:
-		schema = c.PrimaryTable.Schema.Name
+// NullsOrder describes position of null values within an index.
+type NullsOrder int
+
+// IndexElement is a column or an expression that is a part of an index.
+type IndexElement struct {
+	Column *Column
+	// Expression is used if Column is not set.
+	Expression string
+	// OpClass is an operator class, e.g. jsonb_path_ops.
+	OpClass    string
+	Descending bool
+	Nulls      NullsOrder
+}
+
+// IndexElementOption configures how IndexElement handles things.
+type IndexElementOption func(*IndexElement)
+
+// IndexColumn returns index element that is made of a column.
+func IndexColumn(c *Column, opts ...IndexElementOption) *IndexElement {
+	e := &IndexElement{Column: c}
+	for _, o := range opts {
+		o(e)
 	}
+	return e
+}
 
-	if len(c.PrimaryColumns) == 0 {
-		return fmt.Sprintf("%s.%s_%s", schema, c.PrimaryTable.ShortName, c.Type)
+// IndexExpression returns index element that is made of an expression, e.g. lower(email).
+func IndexExpression(expression string, opts ...IndexElementOption) *IndexElement {
+	e := &IndexElement{Expression: expression}
+	for _, o := range opts {
+		o(e)
 	}
-	tmp := make([]string, 0, len(c.PrimaryColumns))
-	for _, col := range c.PrimaryColumns {
-		if col.ShortName != "" {
-			tmp = append(tmp, col.ShortName)
-			continue
-		}
-		tmp = append(tmp, col.Name)
+	return e
+}
+
+// WithOpClass sets operator class of the index element.
+func WithOpClass(opClass string) IndexElementOption {
+	return func(e *IndexElement) {
+		e.OpClass = opClass
+	}
+}
+
+// WithDescending makes the index element sorted in descending order.
+func WithDescending() IndexElementOption {
+	return func(e *IndexElement) {
+		e.Descending = true
+	}
+}
+
+// WithNulls sets position of null values of the index element.
+func WithNulls(nulls NullsOrder) IndexElementOption {
+	return func(e *IndexElement) {
+		e.Nulls = nulls
+	}
+}
+
+// WithIndexMethod sets index method, e.g. gin, gist, brin or hash.
+func WithIndexMethod(method string) ConstraintOption {
+	return func(c *Constraint) {
+		c.Method = method
+	}
+}
+
+// WithWhere turns index into partial index.
+func WithWhere(where string) ConstraintOption {
+	return func(c *Constraint) {
+		c.Where = where
+	}
+}
+
+// WithInclude adds non-key columns to the index.
+func WithInclude(columns ...*Column) ConstraintOption {
+	return func(c *Constraint) {
+		c.Include = append(c.Include, columns...)
+	}
+}
+
+// WithConcurrently makes index to be created and dropped concurrently.
+func WithConcurrently() ConstraintOption {
+	return func(c *Constraint) {
+		c.Concurrently = true
+	}
+}
+
+// WithConstraintComment pass the comment that describes the constraint.
+func WithConstraintComment(s string) ConstraintOption {
+	return func(c *Constraint) {
+		c.Comment = s
+	}
+}
+
+// WithConstraintName sets explicit name of the constraint, naming strategy is not used then.
+func WithConstraintName(name string) ConstraintOption {
+	return func(c *Constraint) {
+		c.ExplicitName = name
+	}
+}
+
+// WithMatch sets match type of foreign key, MatchFull or MatchSimple.
+func WithMatch(match int32) ConstraintOption {
+	return func(c *Constraint) {
+		c.Match = match
+	}
+}
+
+// WithDeferrable makes the constraint DEFERRABLE INITIALLY IMMEDIATE,
+// it is checked after each statement unless deferred using SET CONSTRAINTS.
+func WithDeferrable() ConstraintOption {
+	return func(c *Constraint) {
+		c.DeferrableInitiallyImmediate = true
+		c.DeferrableInitiallyDeferred = false
 	}
+}
 
-	if len(c.Where) > 0 {
-		tmp = append(tmp, c.whereClauseHash())
+// WithInitiallyDeferred makes the constraint DEFERRABLE INITIALLY DEFERRED,
+// it is checked only at the end of the transaction.
+func WithInitiallyDeferred() ConstraintOption {
+	return func(c *Constraint) {
+		c.DeferrableInitiallyDeferred = true
+		c.DeferrableInitiallyImmediate = false
 	}
+}
 
-	return fmt.Sprintf("%s.%s_%s_%s", schema, c.PrimaryTable.ShortName, strings.Join(tmp, "_"), c.Type)
+// WithUniqueIndex turns index into unique index.
+// Method suffix is used to name generated methods if index is partial.
+func WithUniqueIndex(methodSuffix string) ConstraintOption {
+	return func(c *Constraint) {
+		c.Type = ConstraintTypeUniqueIndex
+		c.MethodSuffix = methodSuffix
+	}
 }
 
-// WhereClauseHash returns at least 8-character hash of a hash clause
-func (c *Constraint) whereClauseHash() string {
-	sum := md5.Sum([]byte(c.Where))
+// ExclusionElement is a column or an expression that exclusion constraint compares using the operator.
+type ExclusionElement struct {
+	Column *Column
+	// Expression is used if Column is not set.
+	Expression string
+	// Operator has to be commutative, e.g. = or &&.
+	Operator string
+}
+
+// ExcludeColumn returns exclusion element that compares column using given operator.
+func ExcludeColumn(c *Column, operator string) *ExclusionElement {
+	return &ExclusionElement{
+		Column:   c,
+		Operator: operator,
+	}
+}
+
+// ExcludeExpression returns exclusion element that compares expression using given operator.
+func ExcludeExpression(expression, operator string) *ExclusionElement {
+	return &ExclusionElement{
+		Expression: expression,
+		Operator:   operator,
+	}
+}
+
+// Name returns name of the constraint.
+// Explicit name takes precedence over the one produced by naming strategy of the schema.
+func (c *Constraint) Name() string {
+	switch {
+	case c.ExplicitName != "":
+		return c.ExplicitName
+	case c.PrimaryTable == nil:
+		return "<missing table>"
+	default:
+		return namingStrategy(c.PrimaryTable.Schema).ConstraintName(c)
+	}
+}
+
+// QuotedName returns name of the constraint quoted according to quoting policy of the schema.
+// Name itself stays unquoted, it is what Postgres reports in errors.
+func (c *Constraint) QuotedName() string {
+	if c.PrimaryTable == nil {
+		return QuoteWhenNeeded.Quote(c.Name())
+	}
+	return c.PrimaryTable.Schema.Quote(c.Name())
+}
+
+// shortHash returns at least 8-character hash of a where clause or an expression.
+func shortHash(s string) string {
+	sum := md5.Sum([]byte(s))
 	encoded := base64.StdEncoding.EncodeToString(sum[:])
 	if len(encoded) > 8 {
 		encoded = encoded[:8]
@@ -94,6 +272,16 @@ func Unique(table *Table, columns ...*Column) *Constraint {
 	}
 }
 
+// UniqueOn creates unique constraint made of given columns that can be adjusted using options, e.g. WithDeferrable.
+func UniqueOn(table *Table, columns Columns, opts ...ConstraintOption) *Constraint {
+	c := Unique(table, columns...)
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
 // PrimaryKey constraint is simply a combination of a unique constraint and a not-null constraint.
 func PrimaryKey(table *Table, columns ...*Column) *Constraint {
 	return &Constraint{
@@ -113,18 +301,25 @@ func Check(table *Table, check string, columns ...*Column) *Constraint {
 	}
 }
 
-//
-//// Exclusion constraint ensure that if any two rows are compared on the specified columns
-//// or expressions using the specified operators,
-//// at least one of these operator comparisons will return false or null.
-//func Exclusion(table *Table, exclude Exclude, columns ...*Column) *Constraint {
-//	return &Constraint{
-//		Type:    ConstraintTypeExclusion,
-//		Table:   table,
-//		Exclude: exclude,
-//		Columns: columns,
-//	}
-//}
+// Exclusion constraint ensure that if any two rows are compared on the specified columns
+// or expressions using the specified operators,
+// at least one of these operator comparisons will return false or null.
+// Optional predicate can be set using Where field.
+func Exclusion(table *Table, method string, elements ...*ExclusionElement) *Constraint {
+	var columns Columns
+	for _, e := range elements {
+		if e.Column != nil {
+			columns = append(columns, e.Column)
+		}
+	}
+	return &Constraint{
+		Type:           ConstraintTypeExclusion,
+		PrimaryTable:   table,
+		PrimaryColumns: columns,
+		Method:         method,
+		Exclude:        elements,
+	}
+}
 
 // Reference ...
 type Reference struct {
@@ -135,26 +330,18 @@ type Reference struct {
 // must match the values appearing in some row of another table.
 // We say this maintains the referential integrity between two related tables.
 func ForeignKey(primaryColumns, referenceColumns Columns, opts ...ConstraintOption) *Constraint {
-	if len(referenceColumns) == 0 {
-		panic("foreign key expects at least one reference column")
-	}
-	for _, c := range primaryColumns {
-		if c.Table != primaryColumns[0].Table {
-			panic("column tables inconsistency")
-		}
-	}
-	for _, r := range referenceColumns {
-		if r.Table != referenceColumns[0].Table {
-			panic("reference column tables inconsistency")
-		}
-	}
 	fk := &Constraint{
 		Type:           ConstraintTypeForeignKey,
-		PrimaryTable:   primaryColumns[0].Table,
 		PrimaryColumns: primaryColumns,
-		Table:          referenceColumns[0].Table,
 		Columns:        referenceColumns,
 	}
+	// Inconsistencies are reported by Schema.Validate.
+	if len(primaryColumns) > 0 {
+		fk.PrimaryTable = primaryColumns[0].Table
+	}
+	if len(referenceColumns) > 0 {
+		fk.Table = referenceColumns[0].Table
+	}
 
 	for _, o := range opts {
 		o(fk)
@@ -172,6 +359,25 @@ func Index(table *Table, columns ...*Column) *Constraint {
 	}
 }
 
+// IndexOn creates index made of given columns and expressions.
+func IndexOn(table *Table, elements []*IndexElement, opts ...ConstraintOption) *Constraint {
+	c := &Constraint{
+		Type:         ConstraintTypeIndex,
+		PrimaryTable: table,
+		Elements:     elements,
+	}
+	for _, e := range elements {
+		if e.Column != nil {
+			c.PrimaryColumns = append(c.PrimaryColumns, e.Column)
+		}
+	}
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
 // UniqueIndex ...
 func UniqueIndex(table *Table, methodSuffix, where string, columns ...*Column) *Constraint {
 	return &Constraint{
@@ -188,34 +394,40 @@ func (c *Constraint) String() string {
 	return c.Name()
 }
 
-// IsForeignKey returns true if string has suffix "_fkey".
+// IsForeignKey returns true if constraint of given name is a foreign key, generated names have suffix "_fkey".
+// Names of constraints of a schema are interpreted by Schema.IsForeignKey.
 func IsForeignKey(c string) bool {
-	return strings.HasSuffix(c, string(ConstraintTypeForeignKey))
+	return (*Schema)(nil).IsForeignKey(c)
 }
 
-// IsUnique returns true if string has suffix "_key".
+// IsUnique returns true if constraint of given name is a unique constraint, generated names have suffix "_key".
+// Names of constraints of a schema are interpreted by Schema.IsUnique.
 func IsUnique(c string) bool {
-	return strings.HasSuffix(c, string(ConstraintTypeUnique))
+	return (*Schema)(nil).IsUnique(c)
 }
 
-// IsPrimaryKey returns true if string has suffix "_pkey".
+// IsPrimaryKey returns true if constraint of given name is a primary key, generated names have suffix "_pkey".
+// Names of constraints of a schema are interpreted by Schema.IsPrimaryKey.
 func IsPrimaryKey(c string) bool {
-	return strings.HasSuffix(c, string(ConstraintTypePrimaryKey))
+	return (*Schema)(nil).IsPrimaryKey(c)
 }
 
-// IsCheck returns true if string has suffix "_check".
+// IsCheck returns true if constraint of given name is a check constraint, generated names have suffix "_check".
+// Names of constraints of a schema are interpreted by Schema.IsCheck.
 func IsCheck(c string) bool {
-	return strings.HasSuffix(c, string(ConstraintTypeCheck))
+	return (*Schema)(nil).IsCheck(c)
 }
 
-//// IsExclusion returns true if string has suffix "_excl".
-//func IsExclusion(c string) bool {
-//	return strings.HasSuffix(c, string(ConstraintTypeExclusion))
-//}
+// IsExclusion returns true if constraint of given name is an exclusion constraint, generated names have suffix "_excl".
+// Names of constraints of a schema are interpreted by Schema.IsExclusion.
+func IsExclusion(c string) bool {
+	return (*Schema)(nil).IsExclusion(c)
+}
 
-// IsIndex returns true if string has suffix "_idx".
+// IsIndex returns true if constraint of given name is an index, generated names have suffix "_idx".
+// Names of constraints of a schema are interpreted by Schema.IsIndex.
 func IsIndex(c string) bool {
-	return strings.HasSuffix(c, string(ConstraintTypeIndex))
+	return (*Schema)(nil).IsIndex(c)
 }
 
 type Constraints []*Constraint
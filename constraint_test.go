@@ -21,6 +21,7 @@ func TestConstraint_Name(t *testing.T) {
 		}(), id),
 		"<missing table>": pqt.Check(nil, "a > b", id),
 		"public.news_key": pqt.Unique(pqt.NewTable("news")),
+		"user_pk":         pqt.UniqueOn(pqt.NewTable("user"), pqt.Columns{id}, pqt.WithConstraintName("user_pk")),
 	}
 
 	for expected, given := range success {
@@ -63,6 +64,42 @@ func TestForeignKey(t *testing.T) {
 	}
 }
 
+func TestForeignKey_options(t *testing.T) {
+	parent := pqt.NewColumn("id", pqt.TypeSerialBig())
+	pqt.NewTable("parent").AddColumn(parent)
+	child := pqt.NewColumn("parent_id", pqt.TypeIntegerBig())
+	pqt.NewTable("child").AddColumn(child)
+
+	fk := pqt.ForeignKey(pqt.Columns{child}, pqt.Columns{parent}, pqt.WithMatch(pqt.MatchFull), pqt.WithInitiallyDeferred())
+	if fk.Match != pqt.MatchFull {
+		t.Errorf("wrong match type: %d", fk.Match)
+	}
+	if !fk.DeferrableInitiallyDeferred || fk.DeferrableInitiallyImmediate {
+		t.Errorf("foreign key should be deferrable initially deferred")
+	}
+
+	pqt.WithDeferrable()(fk)
+	if fk.DeferrableInitiallyDeferred || !fk.DeferrableInitiallyImmediate {
+		t.Errorf("foreign key should be deferrable initially immediate")
+	}
+}
+
+func TestUniqueOn(t *testing.T) {
+	c := pqt.NewColumn("position", pqt.TypeInteger())
+	tbl := pqt.NewTable("item").AddColumn(c)
+
+	u := pqt.UniqueOn(tbl, pqt.Columns{c}, pqt.WithDeferrable())
+	if u.Type != pqt.ConstraintTypeUnique {
+		t.Errorf("wrong type, expected %s but got %s", pqt.ConstraintTypeUnique, u.Type)
+	}
+	if len(u.PrimaryColumns) != 1 || u.PrimaryTable != tbl {
+		t.Errorf("wrong columns or table")
+	}
+	if !u.DeferrableInitiallyImmediate {
+		t.Errorf("unique constraint should be deferrable")
+	}
+}
+
 func TestConstraints_CountOf(t *testing.T) {
 	idx := pqt.NewColumn("index", pqt.TypeIntegerBig())
 	uidx := pqt.NewColumn("unique_index", pqt.TypeIntegerBig())
@@ -104,3 +141,59 @@ func TestConstraints_CountOf(t *testing.T) {
 		t.Errorf("expected %d but got %d", 4, got)
 	}
 }
+
+func TestExclusion(t *testing.T) {
+	tbl := pqt.NewTable("reservation")
+	room := pqt.NewColumn("room", pqt.TypeInteger())
+	tbl.AddColumn(room)
+
+	cstr := pqt.Exclusion(tbl, "gist", pqt.ExcludeColumn(room, "="), pqt.ExcludeExpression("tstzrange(starts_at, ends_at)", "&&"))
+	if cstr.Type != pqt.ConstraintTypeExclusion {
+		t.Errorf("wrong type, expected %s but got %s", pqt.ConstraintTypeExclusion, cstr.Type)
+	}
+	if cstr.Method != "gist" {
+		t.Errorf("wrong method, expected %s but got %s", "gist", cstr.Method)
+	}
+	if len(cstr.Exclude) != 2 {
+		t.Errorf("wrong number of elements, expected %d but got %d", 2, len(cstr.Exclude))
+	}
+	if len(cstr.PrimaryColumns) != 1 || cstr.PrimaryColumns[0] != room {
+		t.Errorf("wrong columns, expected only %s", room.Name)
+	}
+	if !pqt.IsExclusion(cstr.Name()) {
+		t.Errorf("expected %s to be recognized as exclusion constraint", cstr.Name())
+	}
+}
+
+func TestIndexOn(t *testing.T) {
+	tbl := pqt.NewTable("user")
+	email := pqt.NewColumn("email", pqt.TypeText())
+	name := pqt.NewColumn("name", pqt.TypeText())
+	tbl.AddColumn(email).AddColumn(name)
+
+	cstr := pqt.IndexOn(tbl, []*pqt.IndexElement{
+		pqt.IndexExpression("lower(email)", pqt.WithOpClass("text_pattern_ops")),
+		pqt.IndexColumn(name, pqt.WithDescending(), pqt.WithNulls(pqt.NullsFirst)),
+	}, pqt.WithIndexMethod("btree"), pqt.WithInclude(email), pqt.WithWhere("name IS NOT NULL"), pqt.WithConcurrently(), pqt.WithUniqueIndex("Named"))
+	if cstr.Type != pqt.ConstraintTypeUniqueIndex {
+		t.Errorf("wrong type, expected %s but got %s", pqt.ConstraintTypeUniqueIndex, cstr.Type)
+	}
+	if len(cstr.PrimaryColumns) != 1 || cstr.PrimaryColumns[0] != name {
+		t.Errorf("wrong columns, expected only %s", name.Name)
+	}
+	if len(cstr.Include) != 1 || cstr.Method != "btree" || cstr.Where == "" || !cstr.Concurrently || cstr.MethodSuffix != "Named" {
+		t.Errorf("options are not applied: %#v", cstr)
+	}
+	if e := cstr.Elements[1]; !e.Descending || e.Nulls != pqt.NullsFirst {
+		t.Errorf("element options are not applied: %#v", e)
+	}
+
+	plain := pqt.IndexOn(tbl, []*pqt.IndexElement{pqt.IndexColumn(name)})
+	expression := pqt.IndexOn(tbl, []*pqt.IndexElement{pqt.IndexColumn(name), pqt.IndexExpression("lower(email)")})
+	if plain.Name() == expression.Name() {
+		t.Errorf("expressions should be a part of the name, got %s", plain.Name())
+	}
+	if plain.Name() != pqt.Index(tbl, name).Name() {
+		t.Errorf("index made of columns only should be named like plain index, got %s", plain.Name())
+	}
+}
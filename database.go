@@ -0,0 +1,76 @@
+package pqt
+
+// DefaultSchema is a schema objects of a schema without name are created in.
+const DefaultSchema = "public"
+
+// Database describes collection of schemas that can reference each other and extensions they depend on.
+type Database struct {
+	// Schemas is a collection of schemas that database contains.
+	Schemas []*Schema
+	// Extensions is a collection of extensions that has to be installed before schemas are created.
+	Extensions []*Extension
+}
+
+// NewDatabase initializes new instance of Database for given options.
+func NewDatabase(opts ...DatabaseOption) *Database {
+	d := &Database{}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// AddSchema adds schema to database.
+func (d *Database) AddSchema(s *Schema) *Database {
+	s.Database = d
+	d.Schemas = append(d.Schemas, s)
+	return d
+}
+
+// AddExtension adds extension to database.
+func (d *Database) AddExtension(e *Extension) *Database {
+	d.Extensions = append(d.Extensions, e)
+	return d
+}
+
+// DatabaseOption configures how we set up a database.
+type DatabaseOption func(*Database)
+
+// WithExtension is database option that adds extension of given name.
+func WithExtension(name string, opts ...ExtensionOption) DatabaseOption {
+	return func(d *Database) {
+		d.AddExtension(NewExtension(name, opts...))
+	}
+}
+
+// Extension describes PostgreSQL extension, e.g. pgcrypto.
+type Extension struct {
+	Name string
+	// Schema if not empty is a schema extension objects are installed into.
+	Schema string
+}
+
+// NewExtension allocates new extension using given name and options.
+func NewExtension(name string, opts ...ExtensionOption) *Extension {
+	e := &Extension{
+		Name: name,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// ExtensionOption configures how we set up an extension.
+type ExtensionOption func(*Extension)
+
+// WithExtensionSchema is extension option that sets schema extension objects are installed into.
+func WithExtensionSchema(schema string) ExtensionOption {
+	return func(e *Extension) {
+		e.Schema = schema
+	}
+}
@@ -0,0 +1,92 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestNewDatabase(t *testing.T) {
+	s := pqt.NewSchema("auth")
+	d := pqt.NewDatabase(pqt.WithExtension("uuid-ossp", pqt.WithExtensionSchema("auth"))).AddSchema(s)
+
+	if s.Database != d {
+		t.Error("schema should reference the database")
+	}
+	if len(d.Extensions) != 1 {
+		t.Fatalf("wrong number of extensions: %d", len(d.Extensions))
+	}
+	if d.Extensions[0].Name != "uuid-ossp" || d.Extensions[0].Schema != "auth" {
+		t.Errorf("wrong extension: %v", d.Extensions[0])
+	}
+}
+
+func TestDatabase_Validate(t *testing.T) {
+	cases := map[string]struct {
+		database func() *pqt.Database
+		expected []string
+	}{
+		"cross-schema": {
+			database: func() *pqt.Database {
+				status := pqt.TypeEnumerated("status", "active")
+				userID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+				user := pqt.NewTable("user").AddColumn(userID)
+				invoice := pqt.NewTable("invoice").
+					AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(userID))).
+					AddColumn(pqt.NewColumn("status", status))
+				return pqt.NewDatabase().
+					AddSchema(pqt.NewSchema("auth").AddType(status).AddTable(user)).
+					AddSchema(pqt.NewSchema("billing").AddTable(invoice))
+			},
+		},
+		"duplicates": {
+			database: func() *pqt.Database {
+				return pqt.NewDatabase(pqt.WithExtension("pgcrypto"), pqt.WithExtension("pgcrypto"), pqt.WithExtension("")).
+					AddSchema(pqt.NewSchema("")).
+					AddSchema(pqt.NewSchema("public"))
+			},
+			expected: []string{
+				"public: duplicate schema name",
+				"pgcrypto: duplicate extension",
+				"extensions[2]: extension name is missing",
+			},
+		},
+		"outside-database": {
+			database: func() *pqt.Database {
+				groupID := pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())
+				pqt.NewSchema("other").AddTable(pqt.NewTable("group").AddColumn(groupID))
+				user := pqt.NewTable("user").
+					AddColumn(pqt.NewColumn("group_id", pqt.TypeInteger(), pqt.WithReference(groupID)))
+				return pqt.NewDatabase().AddSchema(pqt.NewSchema("auth").AddTable(user))
+			},
+			expected: []string{
+				"auth.user_group_id_fkey: referenced table other.group is not a part of the schema",
+				"auth.user: related table other.group is not a part of the schema",
+			},
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			err := c.database().Validate()
+			if len(c.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			errs, ok := err.(pqt.ValidationErrors)
+			if !ok {
+				t.Fatalf("expected validation errors, got: %v", err)
+			}
+			if len(errs) != len(c.expected) {
+				t.Fatalf("wrong number of problems, expected %d but got %d:\n%s", len(c.expected), len(errs), errs.Error())
+			}
+			for i, exp := range c.expected {
+				if errs[i].Error() != exp {
+					t.Errorf("wrong problem #%d, expected:\n%s\nbut got:\n%s", i, exp, errs[i].Error())
+				}
+			}
+		})
+	}
+}
@@ -5976,7 +5976,7 @@ func (r *CommentRepositoryBase) InsertQuery(e *CommentEntity, read bool) (string
 			if len(r.Columns) > 0 {
 				buf.WriteString(strings.Join(r.Columns, ", "))
 			} else {
-				buf.WriteString("content, created_at, id, multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at")
+				buf.WriteString("content, created_at, id, example.multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at")
 			}
 		}
 	}
@@ -6112,7 +6112,7 @@ func _CommentCriteriaWhereClause(comp *Composer, c *CommentCriteria, id int) err
 		if comp.Dirty {
 			comp.WriteString(" AND ")
 		}
-		if _, err := comp.WriteString("multiply"); err != nil {
+		if _, err := comp.WriteString("example.multiply"); err != nil {
 			return err
 		}
 		if _, err := comp.WriteString("("); err != nil {
@@ -6231,7 +6231,7 @@ func (r *CommentRepositoryBase) FindQuery(fe *CommentFindExpr) (string, []interf
 	comp := NewComposer(8)
 	buf := bytes.NewBufferString("SELECT ")
 	if len(fe.Columns) == 0 {
-		buf.WriteString("t0.content, t0.created_at, t0.id, multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at")
+		buf.WriteString("t0.content, t0.created_at, t0.id, example.multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at")
 	} else {
 		buf.WriteString(strings.Join(fe.Columns, ", "))
 	}
@@ -6706,7 +6706,7 @@ func (r *CommentRepositoryBase) UpsertQuery(e *CommentEntity, p *CommentPatch, i
 		if len(r.Columns) > 0 {
 			buf.WriteString(strings.Join(r.Columns, ", "))
 		} else {
-			buf.WriteString("content, created_at, id, multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at")
+			buf.WriteString("content, created_at, id, example.multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at")
 		}
 	}
 	return buf.String(), upsert.Args(), nil
@@ -10404,6 +10404,57 @@ func (n *NullByteaArray) Scan(value interface{}) error {
 	return n.ByteaArray.Scan(value)
 }
 
+// TimeArray represents a one-dimensional array of the PostgreSQL timestamp, timestamptz and date types.
+type TimeArray []time.Time
+
+// Scan satisfy sql.Scanner interface.
+func (a *TimeArray) Scan(src interface{}) error {
+	var tmp pq.StringArray
+	if err := tmp.Scan(src); err != nil {
+		return err
+	}
+	if tmp == nil {
+		*a = nil
+		return nil
+	}
+	res := make(TimeArray, 0, len(tmp))
+	for _, s := range tmp {
+		v, err := pq.ParseTimestamp(nil, s)
+		if err != nil {
+			return err
+		}
+		res = append(res, v)
+	}
+	*a = res
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (a TimeArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	tmp := make(pq.StringArray, 0, len(a))
+	for _, v := range a {
+		tmp = append(tmp, v.Format(time.RFC3339Nano))
+	}
+	return tmp.Value()
+}
+
+type NullTimeArray struct {
+	TimeArray
+	Valid bool
+}
+
+func (n *NullTimeArray) Scan(value interface{}) error {
+	if value == nil {
+		n.TimeArray, n.Valid = nil, false
+		return nil
+	}
+	n.Valid = true
+	return n.TimeArray.Scan(value)
+}
+
 const (
 	jsonArraySeparator     = ","
 	jsonArrayBeginningChar = "["
@@ -10732,7 +10783,7 @@ const SQL = `
 
 CREATE SCHEMA IF NOT EXISTS example; 
 
-CREATE OR REPLACE FUNCTION multiply(x BIGINT, y BIGINT) RETURNS BIGINT
+CREATE OR REPLACE FUNCTION example.multiply(x BIGINT, y BIGINT) RETURNS BIGINT
 	AS 'SELECT x * y'
 	LANGUAGE SQL
 	VOLATILE;
@@ -23,7 +23,7 @@ var testCommentFindData = map[string]struct {
 				Content: sql.NullString{String: "content - minimum", Valid: true},
 			},
 		},
-		query: "SELECT t0.content, t0.created_at, t0.id, multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at FROM example.comment AS t0 WHERE t0.content=$1",
+		query: "SELECT t0.content, t0.created_at, t0.id, example.multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at FROM example.comment AS t0 WHERE t0.content=$1",
 	},
 	"logical-operator": {
 		expr: model.CommentFindExpr{
@@ -48,7 +48,7 @@ var testCommentFindData = map[string]struct {
 				),
 			),
 		},
-		query: "SELECT t0.content, t0.created_at, t0.id, multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at FROM example.comment AS t0 WHERE (t0.content=$1) OR ((t0.content=$2) AND ((t0.news_id=$3) OR (t0.content=$4 AND t0.news_id=$5)))",
+		query: "SELECT t0.content, t0.created_at, t0.id, example.multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at FROM example.comment AS t0 WHERE (t0.content=$1) OR ((t0.content=$2) AND ((t0.news_id=$3) OR (t0.content=$4 AND t0.news_id=$5)))",
 	},
 	"minimum-join-news-by-id": {
 		expr: model.CommentFindExpr{
@@ -59,7 +59,7 @@ var testCommentFindData = map[string]struct {
 				Kind: model.JoinInner,
 			},
 		},
-		query: "SELECT t0.content, t0.created_at, t0.id, multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at FROM example.comment AS t0 INNER JOIN example.news AS t1 ON t0.news_title=t1.title WHERE t0.content=$1",
+		query: "SELECT t0.content, t0.created_at, t0.id, example.multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at FROM example.comment AS t0 INNER JOIN example.news AS t1 ON t0.news_title=t1.title WHERE t0.content=$1",
 	},
 	"full": {
 		expr: model.CommentFindExpr{
@@ -112,7 +112,7 @@ var testCommentFindData = map[string]struct {
 				},
 			},
 		},
-		query: "SELECT t0.content, t0.created_at, t0.id, multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at, " + join(model.TableNewsColumns, 2) + " FROM example.comment AS t0 LEFT JOIN example.news AS t2 ON t0.news_id=t2.id AND t2.title=$1 WHERE t0.content=$2 AND t0.created_at=$3 AND multiply(t0.id, t0.id)=$4 AND t0.updated_at=$5 AND t2.content=$6 AND t2.continue=$7 AND t2.created_at=$8 AND t2.lead=$9 AND t2.meta_data=$10 AND t2.score=$11 AND t2.title=$12 AND t2.updated_at=$13 AND t2.views_distribution=$14",
+		query: "SELECT t0.content, t0.created_at, t0.id, example.multiply(t0.id, t0.id) AS id_multiply, t0.news_id, t0.news_title, now() AS right_now, t0.updated_at, " + join(model.TableNewsColumns, 2) + " FROM example.comment AS t0 LEFT JOIN example.news AS t2 ON t0.news_id=t2.id AND t2.title=$1 WHERE t0.content=$2 AND t0.created_at=$3 AND example.multiply(t0.id, t0.id)=$4 AND t0.updated_at=$5 AND t2.content=$6 AND t2.continue=$7 AND t2.created_at=$8 AND t2.lead=$9 AND t2.meta_data=$10 AND t2.score=$11 AND t2.title=$12 AND t2.updated_at=$13 AND t2.views_distribution=$14",
 	},
 }
 
@@ -260,7 +260,7 @@ var testCommentInsertData = map[string]struct {
 		entity: model.CommentEntity{
 			Content: "content - minimum",
 		},
-		query: "INSERT INTO example.comment (content, news_id, news_title) VALUES ($1, $2, $3) RETURNING content, created_at, id, multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at",
+		query: "INSERT INTO example.comment (content, news_id, news_title) VALUES ($1, $2, $3) RETURNING content, created_at, id, example.multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at",
 	},
 	"full": {
 		entity: model.CommentEntity{
@@ -271,7 +271,7 @@ var testCommentInsertData = map[string]struct {
 				Time:  time.Now(),
 			},
 		},
-		query: "INSERT INTO example.comment (content, created_at, news_id, news_title, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING content, created_at, id, multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at",
+		query: "INSERT INTO example.comment (content, created_at, news_id, news_title, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING content, created_at, id, example.multiply(id, id) AS id_multiply, news_id, news_title, now() AS right_now, updated_at",
 	},
 }
 
@@ -21,17 +21,37 @@ const (
 	FunctionBehaviourStable
 )
 
+const (
+	// FunctionLanguageSQL is a language of functions written in plain SQL.
+	FunctionLanguageSQL = "SQL"
+	// FunctionLanguagePLpgSQL is a language of functions written in PL/pgSQL.
+	FunctionLanguagePLpgSQL = "plpgsql"
+)
+
 // FunctionBehaviour is a function behaviour, it can be volatile, immutable or stable.
 type FunctionBehaviour int
 
 // Function describes database function.
 type Function struct {
-	Name      string
-	BuiltIn   bool
-	Type      Type
-	Body      string
+	Name    string
+	BuiltIn bool
+	// Type is a return type of the function. TypeTrigger should be used for trigger functions.
+	Type Type
+	// SetOf if true means that function returns set of values of the Type.
+	SetOf bool
+	// ReturnsTable if not empty means that function returns a table with given columns, Type is ignored then.
+	ReturnsTable []*FunctionArg
+	Body         string
+	// Language is a language body is written in, SQL if empty.
+	Language  string
 	Behaviour FunctionBehaviour
-	Args      []*FunctionArg
+	// SecurityDefiner if true means that function is executed with privileges of the user that owns it.
+	SecurityDefiner bool
+	Args            []*FunctionArg
+	// Comment describes the function, it is stored in the database.
+	Comment string
+	// Grants is a collection of privileges given to roles on the function.
+	Grants []*Grant
 }
 
 // FunctionArg is a function argument, it is used to describe function signature.
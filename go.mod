@@ -6,4 +6,5 @@ require (
 	github.com/aryann/difflib v0.0.0-20210328193216-ff5ff6dc229b
 	github.com/huandu/xstrings v1.4.0
 	github.com/lib/pq v1.10.9
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,60 @@
+package pqt
+
+const (
+	// PrivilegeAll grants all privileges available for the object.
+	PrivilegeAll Privilege = "ALL PRIVILEGES"
+	// PrivilegeSelect allows to read table or column.
+	PrivilegeSelect Privilege = "SELECT"
+	// PrivilegeInsert allows to insert rows into table or values into column.
+	PrivilegeInsert Privilege = "INSERT"
+	// PrivilegeUpdate allows to update table or column.
+	PrivilegeUpdate Privilege = "UPDATE"
+	// PrivilegeDelete allows to delete rows from table.
+	PrivilegeDelete Privilege = "DELETE"
+	// PrivilegeTruncate allows to truncate table.
+	PrivilegeTruncate Privilege = "TRUNCATE"
+	// PrivilegeReferences allows to create foreign key referencing table or column.
+	PrivilegeReferences Privilege = "REFERENCES"
+	// PrivilegeTrigger allows to create trigger on table.
+	PrivilegeTrigger Privilege = "TRIGGER"
+	// PrivilegeUsage allows to access objects in schema.
+	PrivilegeUsage Privilege = "USAGE"
+	// PrivilegeCreate allows to create objects in schema.
+	PrivilegeCreate Privilege = "CREATE"
+	// PrivilegeExecute allows to call function.
+	PrivilegeExecute Privilege = "EXECUTE"
+)
+
+// Privilege is a kind of access to database object that can be granted to a role.
+type Privilege string
+
+// Grant describes privileges given to a role on a schema, table or function.
+type Grant struct {
+	// Role is a role that receives privileges, PUBLIC means all roles.
+	Role       string
+	Privileges []Privilege
+	// Columns if not empty limits table privileges to given columns.
+	Columns Columns
+	// GrantOption if true allows role to grant the privileges to others.
+	GrantOption bool
+}
+
+// NewGrant allocates new grant of given privileges to the role.
+func NewGrant(role string, privileges ...Privilege) *Grant {
+	return &Grant{
+		Role:       role,
+		Privileges: privileges,
+	}
+}
+
+// OnColumns limits privileges to given columns of the table.
+func (g *Grant) OnColumns(columns ...*Column) *Grant {
+	g.Columns = columns
+	return g
+}
+
+// WithGrantOption allows role to grant the privileges to others.
+func (g *Grant) WithGrantOption() *Grant {
+	g.GrantOption = true
+	return g
+}
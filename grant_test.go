@@ -0,0 +1,25 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestNewGrant(t *testing.T) {
+	email := pqt.NewColumn("email", pqt.TypeText())
+	g := pqt.NewGrant("app", pqt.PrivilegeSelect, pqt.PrivilegeUpdate).OnColumns(email).WithGrantOption()
+
+	if g.Role != "app" {
+		t.Errorf("wrong role: %s", g.Role)
+	}
+	if len(g.Privileges) != 2 {
+		t.Errorf("wrong number of privileges: %d", len(g.Privileges))
+	}
+	if len(g.Columns) != 1 || g.Columns[0] != email {
+		t.Errorf("wrong columns: %s", g.Columns.String())
+	}
+	if !g.GrantOption {
+		t.Error("grant should have grant option")
+	}
+}
@@ -10,13 +10,21 @@ import (
 )
 
 func (g *Generator) Entity(t *pqt.Table) {
+	if t.Comment != "" {
+		g.Print(docComment(pqtfmt.Public(tableName(t))+"Entity", t.Comment))
+	} else {
+		g.Printf(`
+// %sEntity ...`, pqtfmt.Public(tableName(t)))
+	}
 	g.Printf(`
-// %sEntity ...`, pqtfmt.Public(t.Name))
-	g.Printf(`
-type %sEntity struct{`, pqtfmt.Public(t.Name))
+type %sEntity struct{`, pqtfmt.Public(tableName(t)))
 	for prop := range g.entityPropertiesGenerator(t) {
-		g.Printf(`
+		if prop.Comment != "" {
+			g.Print(docComment(pqtfmt.Public(prop.Name), prop.Comment))
+		} else {
+			g.Printf(`
 // %s ...`, pqtfmt.Public(prop.Name))
+		}
 		if prop.ReadOnly {
 			g.Printf(`
 // %s is read only`, pqtfmt.Public(prop.Name))
@@ -35,16 +43,36 @@ type %sEntity struct{`, pqtfmt.Public(t.Name))
 	g.Print(`}`)
 }
 
+// PrimaryKey generates struct that holds values of multi column primary key.
+// Nothing is generated for tables with single column key, the value is passed directly.
+func (g *Generator) PrimaryKey(t *pqt.Table) {
+	pk, ok := primaryKey(t)
+	if !ok || len(pk) == 1 {
+		return
+	}
+
+	name := pqtfmt.Public(tableName(t), "primaryKey")
+	g.Printf(`
+// %s represents primary key of %s table.
+type %s struct{`, name, t.QualifiedName(), name)
+	for _, c := range pk {
+		g.Printf(`
+%s %s`, pqtfmt.Public(c.Name), g.columnType(c, pqtgo.ModeMandatory))
+	}
+	g.Print(`
+}`)
+}
+
 func (g *Generator) EntityProp(t *pqt.Table) {
 	g.Printf(`
-		func (e *%sEntity) %s(cn string) (interface{}, bool) {`, pqtfmt.Public(t.Name), pqtfmt.Public("prop"))
+		func (e *%sEntity) %s(cn string) (interface{}, bool) {`, pqtfmt.Public(tableName(t)), pqtfmt.Public("prop"))
 	g.Println(`
 		switch cn {`)
 
 ColumnsLoop:
 	for _, c := range t.Columns {
 		g.Printf(`
-			case %s:`, pqtfmt.Public("table", t.Name, "column", c.Name))
+			case %s:`, pqtfmt.Public("table", tableName(t), "column", c.Name))
 		for _, plugin := range g.Plugins {
 			if txt := plugin.ScanClause(c); txt != "" {
 				tmpl, err := template.New("root").Parse(fmt.Sprintf(`
@@ -75,8 +103,13 @@ ColumnsLoop:
 				g.Printf(`if e.%s == nil { e.%s = []bool{} }`, pn, pn)
 			case "pq.ByteaArray":
 				g.Printf(`if e.%s == nil { e.%s = [][]byte{} }`, pn, pn)
+			case "TimeArray":
+				g.Printf(`if e.%s == nil { e.%s = []time.Time{} }`, pn, pn)
 			}
 
+			g.Printf(`
+				return &e.%s, true`, pqtfmt.Public(c.Name))
+		case g.isType(c, pqtgo.ModeDefault, "Hstore"):
 			g.Printf(`
 				return &e.%s, true`, pqtfmt.Public(c.Name))
 		case g.canBeNil(c, pqtgo.ModeDefault):
@@ -101,7 +134,7 @@ ColumnsLoop:
 
 func (g *Generator) EntityProps(t *pqt.Table) {
 	g.Printf(`
-		func (e *%sEntity) %s(cns ...string) ([]interface{}, error) {`, pqtfmt.Public(t.Name), pqtfmt.Public("props"))
+		func (e *%sEntity) %s(cns ...string) ([]interface{}, error) {`, pqtfmt.Public(tableName(t)), pqtfmt.Public("props"))
 	g.Printf(`
 		if len(cns) == 0 {
 			cns = %s
@@ -115,7 +148,7 @@ func (g *Generator) EntityProps(t *pqt.Table) {
 			}
 		}
 		return res, nil`,
-		pqtfmt.Public("table", t.Name, "columns"),
+		pqtfmt.Public("table", tableName(t), "columns"),
 		pqtfmt.Public("prop"),
 	)
 	g.Print(`
@@ -52,6 +52,87 @@ func TestGenerator_Entity(t *testing.T) {
 			table: table(pqt.NewColumn("a", pqt.TypeIntegerBig(), pqt.WithNotNull())),
 			exp:   expected("A", "int64"),
 		},
+		"column-interval": {
+			table: table(pqt.NewColumn("a", pqt.TypeInterval())),
+			exp:   expected("A", "sql.NullString"),
+		},
+		"column-time-not-null": {
+			table: table(pqt.NewColumn("a", pqt.TypeTimeTZ(), pqt.WithNotNull())),
+			exp:   expected("A", "time.Time"),
+		},
+		"column-hstore": {
+			table: table(pqt.NewColumn("a", pqt.TypeHstore())),
+			exp:   expected("A", "Hstore"),
+		},
+		"column-point": {
+			table: table(pqt.NewColumn("a", pqt.TypePoint())),
+			exp:   expected("A", "NullPoint"),
+		},
+		"column-int4range-not-null": {
+			table: table(pqt.NewColumn("a", pqt.TypeInt4Range(), pqt.WithNotNull())),
+			exp:   expected("A", "Int64Range"),
+		},
+		"column-numrange": {
+			table: table(pqt.NewColumn("a", pqt.TypeNumRange())),
+			exp:   expected("A", "NullFloat64Range"),
+		},
+		"column-tstzrange": {
+			table: table(pqt.NewColumn("a", pqt.TypeTSTZRange())),
+			exp:   expected("A", "NullTimeRange"),
+		},
+		"column-bit": {
+			table: table(pqt.NewColumn("a", pqt.TypeBit(8), pqt.WithNotNull())),
+			exp:   expected("A", "string"),
+		},
+		"column-domain": {
+			table: table(pqt.NewColumn("a", pqt.TypeDomain("email", pqt.TypeCIText()))),
+			exp:   expected("A", "sql.NullString"),
+		},
+		"column-domain-not-null": {
+			table: table(pqt.NewColumn("a", pqt.TypeDomain("amount", pqt.TypeNumeric(10, 2), pqt.WithDomainNotNull()))),
+			exp:   expected("A", "float64"),
+		},
+		"column-domain-enumerated": {
+			table: table(pqt.NewColumn("a", pqt.TypeDomain("feeling", pqt.TypeEnumerated("mood", "happy")))),
+			exp:   expected("A", "NullMood"),
+		},
+		"column-uuid-array": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeUUID()))),
+			exp:   expected("A", "NullStringArray"),
+		},
+		"column-numeric-array-not-null": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeNumeric(10, 2)), pqt.WithNotNull())),
+			exp:   expected("A", "pq.Float64Array"),
+		},
+		"column-smallint-array-not-null": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeIntegerSmall()), pqt.WithNotNull())),
+			exp:   expected("A", "pq.Int64Array"),
+		},
+		"column-timestamptz-array": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeTimestampTZ()))),
+			exp:   expected("A", "NullTimeArray"),
+		},
+		"column-enumerated-array-not-null": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeEnumerated("mood", "happy")), pqt.WithNotNull())),
+			exp:   expected("A", "pq.StringArray"),
+		},
+		"column-multidimensional-array": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeInteger(), 0, 0))),
+			exp:   expected("A", "sql.NullString"),
+		},
+		"comment": {
+			table: pqt.NewTable("example", pqt.WithTableComment("is an example.\nIt spans multiple lines.")).
+				AddColumn(pqt.NewColumn("name", pqt.TypeText(), pqt.WithNotNull(), pqt.WithComment("Name is unique"))).
+				AddColumn(pqt.NewColumn("age", pqt.TypeInteger(), pqt.WithComment("in years"))),
+			exp: `
+// ExampleEntity is an example.
+// It spans multiple lines.
+type ExampleEntity struct{
+// Age in years
+Age *int32
+// Name is unique
+Name string}`,
+		},
 		"dynamic": {
 			table: func() *pqt.Table {
 				age := pqt.NewColumn("age", pqt.TypeInteger())
@@ -71,6 +152,20 @@ Age *int32
 // Dynamic is read only
 Dynamic int32}`,
 		},
+		"generated": {
+			table: pqt.NewTable("example").
+				AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityAlways))).
+				AddColumn(pqt.NewColumn("slug", pqt.TypeText(), pqt.WithGenerated("lower(id::text)"))),
+			exp: `
+// ExampleEntity ...
+type ExampleEntity struct{
+// ID ...
+// ID is read only
+ID int64
+// Slug ...
+// Slug is read only
+Slug sql.NullString}`,
+		},
 	}
 
 	for hint, c := range cases {
@@ -153,6 +248,45 @@ func (e *T1Entity) Prop(cn string) (interface{}, bool) {
 }`)
 }
 
+func TestGenerator_EntityProp_types(t *testing.T) {
+	t1 := pqt.NewTable("t1").
+		AddColumn(pqt.NewColumn("attributes", pqt.TypeHstore())).
+		AddColumn(pqt.NewColumn("during", pqt.TypeTSTZRange())).
+		AddColumn(pqt.NewColumn("logins", pqt.TypeArray(pqt.TypeTimestampTZ()), pqt.WithNotNull()))
+
+	g := &gogen.Generator{}
+	g.Reset()
+	g.Entity(t1)
+	g.EntityProp(t1)
+	testutil.AssertOutput(t, g.Printer, `
+// T1Entity ...
+type T1Entity struct {
+	// Attributes ...
+	Attributes Hstore
+	// During ...
+	During NullTimeRange
+	// Logins ...
+	Logins TimeArray
+}
+
+func (e *T1Entity) Prop(cn string) (interface{}, bool) {
+	switch cn {
+
+	case TableT1ColumnAttributes:
+		return &e.Attributes, true
+	case TableT1ColumnDuring:
+		return &e.During, true
+	case TableT1ColumnLogins:
+		if e.Logins == nil {
+			e.Logins = []time.Time{}
+		}
+		return &e.Logins, true
+	default:
+		return nil, false
+	}
+}`)
+}
+
 func TestGenerator_EntityProps(t *testing.T) {
 	t1 := pqt.NewTable("t1")
 	t2 := pqt.NewTable("t2").
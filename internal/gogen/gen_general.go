@@ -2,6 +2,7 @@ package gogen
 
 import (
 	"fmt"
+	"strings"
 	"text/template"
 
 	"github.com/piotrkowalczuk/pqt"
@@ -36,12 +37,19 @@ func (g *Generator) Imports(s *pqt.Schema, fixed ...string) {
 		}
 		return slice
 	}
+	columns := make([]*pqt.Column, 0)
 	for _, t := range s.Tables {
-		for _, c := range t.Columns {
-			if ct, ok := c.Type.(pqtgo.CustomType); ok {
-				imports = appendIfNotEmpty(imports, ct.TypeOf(pqtgo.ModeMandatory).PkgPath())
-				imports = appendIfNotEmpty(imports, ct.TypeOf(pqtgo.ModeOptional).PkgPath())
-				imports = appendIfNotEmpty(imports, ct.TypeOf(pqtgo.ModeCriteria).PkgPath())
+		columns = append(columns, t.Columns...)
+	}
+	for _, v := range s.Views {
+		columns = append(columns, v.Columns...)
+	}
+	for _, c := range columns {
+		if ct, ok := c.Type.(pqtgo.CustomType); ok {
+			for _, m := range []int32{pqtgo.ModeMandatory, pqtgo.ModeOptional, pqtgo.ModeCriteria} {
+				if gt := ct.GoTypeOf(m); gt != nil {
+					imports = appendIfNotEmpty(imports, gt.PkgPath)
+				}
 			}
 		}
 	}
@@ -56,14 +64,30 @@ func (g *Generator) Imports(s *pqt.Schema, fixed ...string) {
 }
 
 func (g *Generator) Criteria(t *pqt.Table) {
-	tableName := pqtfmt.Public(t.Name)
+	tableName := pqtfmt.Public(tableName(t))
 
+	if t.Comment != "" {
+		g.Print(docComment(tableName+"Criteria", t.Comment))
+	}
 	g.Printf(`
 type %sCriteria struct {`, tableName)
 	for _, c := range t.Columns {
 		if t := g.columnType(c, pqtgo.ModeCriteria); t != "<nil>" {
+			if c.Comment != "" {
+				g.Print(docComment(pqtfmt.Public(c.Name), c.Comment))
+			}
 			g.Printf(`
 %s %s`, pqtfmt.Public(c.Name), t)
+			if elem, ok := g.arrayElem(c); ok {
+				g.Printf(`
+%s %s
+%s %s
+%s %s`,
+					pqtfmt.Public(c.Name, "contains"), t,
+					pqtfmt.Public(c.Name, "overlap"), t,
+					pqtfmt.Public(c.Name, "any"), elem,
+				)
+			}
 		}
 	}
 	g.Printf(`
@@ -79,7 +103,7 @@ var RetryTransaction = errors.New("retry transaction")`)
 }
 
 func (g *Generator) Operand(t *pqt.Table) {
-	tableName := pqtfmt.Public(t.Name)
+	tableName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 func %sOperand(operator string, operands ...*%sCriteria) *%sCriteria {
@@ -116,25 +140,45 @@ func %sAnd(operands ...*%sCriteria) *%sCriteria {
 func (g *Generator) Columns(t *pqt.Table) {
 	g.Printf(`
 const (
-%s = "%s"`, pqtfmt.Public("table", t.Name), t.FullName())
+%s = %q`, pqtfmt.Public("table", tableName(t)), t.FullName())
 
 	for _, c := range t.Columns {
 		g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", t.Name, "column", c.Name), c.Name)
+%s = %q`, pqtfmt.Public("table", tableName(t), "column", c.Name), c.Name)
 	}
 
 	g.Printf(`
 )
 
-var %s = []string{`, pqtfmt.Public("table", t.Name, "columns"))
+var %s = []string{`, pqtfmt.Public("table", tableName(t), "columns"))
 
 	for _, c := range t.Columns {
 		g.Printf(`
-%s,`, pqtfmt.Public("table", t.Name, "column", c.Name))
+%s,`, pqtfmt.Public("table", tableName(t), "column", c.Name))
 	}
 	g.Print(`
 }`)
 
+	if !quotedColumns(t) {
+		return
+	}
+	g.Printf(`
+
+// %s returns name of the column as it has to be written into a query.
+func %s(cn string) string {
+	switch cn {`, quoteColumnFunc(t), quoteColumnFunc(t))
+	for _, c := range t.Columns {
+		if c.QuotedName() == c.Name {
+			continue
+		}
+		g.Printf(`
+	case %s:
+		return %q`, pqtfmt.Public("table", tableName(t), "column", c.Name), c.QuotedName())
+	}
+	g.Print(`
+	}
+	return cn
+}`)
 }
 
 func (g *Generator) Constraints(t *pqt.Table) {
@@ -145,22 +189,22 @@ const (`)
 		switch c.Type {
 		case pqt.ConstraintTypeCheck:
 			g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", c.PrimaryTable.Name, "constraint", name, "Check"), c.String())
+%s = "%s"`, pqtfmt.Public("table", tableName(c.PrimaryTable), "constraint", name, "Check"), c.String())
 		case pqt.ConstraintTypePrimaryKey:
 			g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", c.PrimaryTable.Name, "constraintPrimaryKey"), c.String())
+%s = "%s"`, pqtfmt.Public("table", tableName(c.PrimaryTable), "constraintPrimaryKey"), c.String())
 		case pqt.ConstraintTypeForeignKey:
 			g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", c.PrimaryTable.Name, "constraint", name, "ForeignKey"), c.String())
+%s = "%s"`, pqtfmt.Public("table", tableName(c.PrimaryTable), "constraint", name, "ForeignKey"), c.String())
 		case pqt.ConstraintTypeExclusion:
 			g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", c.PrimaryTable.Name, "constraint", name, "Exclusion"), c.String())
+%s = "%s"`, pqtfmt.Public("table", tableName(c.PrimaryTable), "constraint", name, "Exclusion"), c.String())
 		case pqt.ConstraintTypeUnique:
 			g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", c.PrimaryTable.Name, "constraint", name, "Unique"), c.String())
+%s = "%s"`, pqtfmt.Public("table", tableName(c.PrimaryTable), "constraint", name, "Unique"), c.String())
 		case pqt.ConstraintTypeIndex:
 			g.Printf(`
-%s = "%s"`, pqtfmt.Public("table", c.PrimaryTable.Name, "constraint", name, "Index"), c.String())
+%s = "%s"`, pqtfmt.Public("table", tableName(c.PrimaryTable), "constraint", name, "Index"), c.String())
 		}
 	}
 	g.Printf(`
@@ -169,18 +213,22 @@ const (`)
 
 func (g *Generator) FindExpr(t *pqt.Table) {
 	g.Printf(`
-type %sFindExpr struct {`, pqtfmt.Public(t.Name))
+type %sFindExpr struct {`, pqtfmt.Public(tableName(t)))
 	g.Printf(`
-%s *%sCriteria`, pqtfmt.Public("where"), pqtfmt.Public(t.Name))
+%s *%sCriteria`, pqtfmt.Public("where"), pqtfmt.Public(tableName(t)))
 	g.Printf(`
 %s, %s int64`, pqtfmt.Public("offset"), pqtfmt.Public("limit"))
 	g.Printf(`
 %s []string`, pqtfmt.Public("columns"))
 	g.Printf(`
 %s []RowOrder`, pqtfmt.Public("orderBy"))
+	if inherited(t) {
+		g.Printf(`
+%s bool`, pqtfmt.Public("only"))
+	}
 	for _, r := range joinableRelationships(t) {
 		g.Printf(`
-%s *%sJoin`, pqtfmt.Public("join", or(r.InversedName, r.InversedTable.Name)), pqtfmt.Public(r.InversedTable.Name))
+%s *%sJoin`, pqtfmt.Public("join", or(r.InversedName, r.InversedTable.Name)), pqtfmt.Public(tableName(r.InversedTable)))
 	}
 	g.Print(`
 }`)
@@ -188,12 +236,12 @@ type %sFindExpr struct {`, pqtfmt.Public(t.Name))
 
 func (g *Generator) CountExpr(t *pqt.Table) {
 	g.Printf(`
-type %sCountExpr struct {`, pqtfmt.Public(t.Name))
+type %sCountExpr struct {`, pqtfmt.Public(tableName(t)))
 	g.Printf(`
-%s *%sCriteria`, pqtfmt.Public("where"), pqtfmt.Public(t.Name))
+%s *%sCriteria`, pqtfmt.Public("where"), pqtfmt.Public(tableName(t)))
 	for _, r := range joinableRelationships(t) {
 		g.Printf(`
-%s *%sJoin`, pqtfmt.Public("join", or(r.InversedName, r.InversedTable.Name)), pqtfmt.Public(r.InversedTable.Name))
+%s *%sJoin`, pqtfmt.Public("join", or(r.InversedName, r.InversedTable.Name)), pqtfmt.Public(tableName(r.InversedTable)))
 	}
 	g.Print(`
 }`)
@@ -201,32 +249,38 @@ type %sCountExpr struct {`, pqtfmt.Public(t.Name))
 
 func (g *Generator) Join(t *pqt.Table) {
 	g.Printf(`
-type %sJoin struct {`, pqtfmt.Public(t.Name))
+type %sJoin struct {`, pqtfmt.Public(tableName(t)))
 	g.Printf(`
-%s, %s *%sCriteria`, pqtfmt.Public("on"), pqtfmt.Public("where"), pqtfmt.Public(t.Name))
+%s, %s *%sCriteria`, pqtfmt.Public("on"), pqtfmt.Public("where"), pqtfmt.Public(tableName(t)))
 	g.Printf(`
 %s bool`, pqtfmt.Public("fetch"))
 	g.Printf(`
 %s JoinType`, pqtfmt.Public("kind"))
 	for _, r := range joinableRelationships(t) {
 		g.Printf(`
-Join%s *%sJoin`, pqtfmt.Public(or(r.InversedName, r.InversedTable.Name)), pqtfmt.Public(r.InversedTable.Name))
+Join%s *%sJoin`, pqtfmt.Public(or(r.InversedName, r.InversedTable.Name)), pqtfmt.Public(tableName(r.InversedTable)))
 	}
 	g.Print(`
 }`)
 }
 
 func (g *Generator) Patch(t *pqt.Table) {
+	if t.Comment != "" {
+		g.Print(docComment(pqtfmt.Public(tableName(t))+"Patch", t.Comment))
+	}
 	g.Printf(`
-type %sPatch struct {`, pqtfmt.Public(t.Name))
+type %sPatch struct {`, pqtfmt.Public(tableName(t)))
 
 ArgumentsLoop:
 	for _, c := range t.Columns {
-		if c.PrimaryKey {
+		if keyColumn(c) || computed(c) {
 			continue ArgumentsLoop
 		}
 
 		if t := g.columnType(c, pqtgo.ModeOptional); t != "<nil>" {
+			if c.Comment != "" {
+				g.Print(docComment(pqtfmt.Public(c.Name), c.Comment))
+			}
 			g.Printf(`
 %s %s`,
 				pqtfmt.Public(c.Name),
@@ -239,7 +293,7 @@ ArgumentsLoop:
 }
 
 func (g *Generator) Iterator(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 	g.Printf(`
 // %sIterator is not thread safe.
 type %sIterator struct {
@@ -248,7 +302,7 @@ type %sIterator struct {
 	expr *%sFindExpr
 }`, entityName,
 		entityName,
-		pqtfmt.Public(t.Name))
+		pqtfmt.Public(tableName(t)))
 
 	g.Printf(`
 func (i *%sIterator) Next() bool {
@@ -296,9 +350,9 @@ func (i *%sIterator) %s() (*%sEntity, error) {
 		entityName,
 		entityName,
 		entityName,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		entityName,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		entityName,
 		entityName,
 		pqtfmt.Public("props"))
@@ -318,7 +372,7 @@ func (i *%sIterator) %s() (*%sEntity, error) {
 }
 
 func (g *Generator) WhereClause(t *pqt.Table) {
-	name := pqtfmt.Public(t.Name)
+	name := pqtfmt.Public(tableName(t))
 	fnName := fmt.Sprintf("%sCriteriaWhereClause", name)
 	g.Printf(`
 		func %s(comp *Composer, c *%sCriteria, id int) (error) {`, fnName, name)
@@ -421,16 +475,13 @@ ColumnsLoop:
 				panic(fmt.Sprintf("number of function arguments is greater then number of available columns: %s.%s", c.Table.Name, c.Name))
 			}
 			g.Printf(`
-				if _, err := comp.WriteString("%s"); err != nil {
+				if _, err := comp.WriteString(%q); err != nil {
 					return err
 				}
 				if _, err := comp.WriteString("("); err != nil {
 					return err
-				}`, functionName(c.Func))
-			for i, arg := range c.Func.Args {
-				if arg.Type != c.Columns[i].Type {
-					fmt.Printf("wrong function (%s) argument type, expected %v but got %v\n", functionName(c.Func), arg.Type, c.Columns[i].Type)
-				}
+				}`, functionName(c))
+			for i := range c.Func.Args {
 				if i != 0 {
 					g.Print(`
 					if _, err := comp.WriteString(", "); err != nil {
@@ -444,7 +495,7 @@ ColumnsLoop:
 					if _, err := comp.WriteString(%s); err != nil {
 						return err
 					}`,
-					pqtfmt.Public("table", c.Columns[i].Table.Name, "column", c.Columns[i].Name),
+					columnIdentifier(c.Columns[i]),
 				)
 			}
 			g.Print(`
@@ -459,7 +510,7 @@ ColumnsLoop:
 				if _, err := comp.WriteString(%s); err != nil {
 					return err
 				}`,
-				pqtfmt.Public("table", t.Name, "column", c.Name),
+				columnIdentifier(c),
 			)
 		}
 
@@ -475,12 +526,77 @@ ColumnsLoop:
 			pqtfmt.Public(c.Name),
 		)
 		closeBrace(g, braces)
+		g.arrayCriteriaWhereClause(t, c)
 	}
 	g.Print(`
 	return nil`)
 	closeBrace(g, 1)
 }
 
+// arrayCriteriaWhereClause generates conditions for the array operators supported by criteria of given column.
+func (g *Generator) arrayCriteriaWhereClause(t *pqt.Table, c *pqt.Column) {
+	elem, ok := g.arrayElem(c)
+	if !ok {
+		return
+	}
+	column := columnIdentifier(c)
+	for _, op := range []struct{ suffix, operator string }{{"contains", "@>"}, {"overlap", "&&"}} {
+		name := pqtfmt.Public(c.Name, op.suffix)
+		g.Printf(`
+			if c.%s.Valid {
+				if comp.Dirty {
+					comp.WriteString(" AND ")
+				}
+				if err := comp.WriteAlias(id); err != nil {
+					return err
+				}
+				if _, err := comp.WriteString(%s); err != nil {
+					return err
+				}
+				if _, err := comp.WriteString(" %s "); err != nil {
+					return err
+				}
+				if err := comp.WritePlaceholder(); err != nil {
+					return err
+				}
+				comp.Add(c.%s)
+				comp.Dirty=true
+			}`, name, column, op.operator, name)
+	}
+
+	name := pqtfmt.Public(c.Name, "any")
+	switch {
+	case strings.HasPrefix(elem, "*"), strings.HasPrefix(elem, "[]"), elem == "interface{}", elem == "Hstore":
+		g.Printf(`
+			if c.%s != nil {`, name)
+	default:
+		g.Printf(`
+			if c.%s.Valid {`, name)
+	}
+	g.Printf(`
+			if comp.Dirty {
+				comp.WriteString(" AND ")
+			}
+			if err := comp.WritePlaceholder(); err != nil {
+				return err
+			}
+			comp.Add(c.%s)
+			if _, err := comp.WriteString(" = ANY("); err != nil {
+				return err
+			}
+			if err := comp.WriteAlias(id); err != nil {
+				return err
+			}
+			if _, err := comp.WriteString(%s); err != nil {
+				return err
+			}
+			if _, err := comp.WriteString(")"); err != nil {
+				return err
+			}
+			comp.Dirty=true
+		}`, name, column)
+}
+
 func (g *Generator) JoinClause() {
 	g.Print(`
 	func joinClause(comp *Composer, jt JoinType, on string) (ok bool, err error) {
@@ -517,8 +633,8 @@ func (g *Generator) JoinClause() {
 }
 
 func (g *Generator) ScanRows(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	funcName := pqtfmt.Public("scan", t.Name, "rows")
+	entityName := pqtfmt.Public(tableName(t))
+	funcName := pqtfmt.Public("scan", tableName(t), "rows")
 	g.Printf(`
 		// %s helps to scan rows straight to the slice of entities.
 		func %s(rows Rows) (entities []*%sEntity, err error) {`, funcName, funcName, entityName)
@@ -566,7 +682,7 @@ func (g *Generator) Interfaces() {
 	}`)
 }
 
-func (g *Generator) Statics() {
+func (g *Generator) Statics(s *pqt.Schema) {
 	code := `
 const (
 	JoinInner = iota
@@ -692,6 +808,57 @@ func (n *NullByteaArray) Scan(value interface{}) error {
 	return n.ByteaArray.Scan(value)
 }
 
+// TimeArray represents a one-dimensional array of the PostgreSQL timestamp, timestamptz and date types.
+type TimeArray []time.Time
+
+// Scan satisfy sql.Scanner interface.
+func (a *TimeArray) Scan(src interface{}) error {
+	var tmp pq.StringArray
+	if err := tmp.Scan(src); err != nil {
+		return err
+	}
+	if tmp == nil {
+		*a = nil
+		return nil
+	}
+	res := make(TimeArray, 0, len(tmp))
+	for _, s := range tmp {
+		v, err := pq.ParseTimestamp(nil, s)
+		if err != nil {
+			return err
+		}
+		res = append(res, v)
+	}
+	*a = res
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (a TimeArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	tmp := make(pq.StringArray, 0, len(a))
+	for _, v := range a {
+		tmp = append(tmp, v.Format(time.RFC3339Nano))
+	}
+	return tmp.Value()
+}
+
+type NullTimeArray struct {
+	TimeArray
+	Valid bool
+}
+
+func (n *NullTimeArray) Scan(value interface{}) error {
+	if value == nil {
+		n.TimeArray, n.Valid = nil, false
+		return nil
+	}
+	n.Valid = true
+	return n.TimeArray.Scan(value)
+}
+
 
 const (
 	jsonArraySeparator     = ","
@@ -890,7 +1057,6 @@ func (a JSONArrayFloat64) Value() (driver.Value, error) {
 	return buffer.Bytes(), nil
 }
 
-
 var (
 	// Space is a shorthand composition option that holds space.
 	Space = &CompositionOpts{
@@ -1016,6 +1182,78 @@ func (c *Composer) Args() []interface{} {
 }`
 
 	g.Print(code)
+	g.typeStatics(s)
+}
+
+// typeStatics generates Go counterparts of types that are not supported by the pq library, but only those that schema makes use of.
+func (g *Generator) typeStatics(s *pqt.Schema) {
+	used := g.usedTypes(s)
+	uses := func(names ...string) bool {
+		for _, name := range names {
+			if used[name] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var statics []string
+	if uses("Hstore") {
+		statics = append(statics, staticHstore)
+	}
+	if uses("Point", "NullPoint") {
+		statics = append(statics, staticPoint)
+	}
+	if uses("Int64Range", "NullInt64Range", "Float64Range", "NullFloat64Range", "TimeRange", "NullTimeRange", "DateRange", "NullDateRange") {
+		statics = append(statics, staticRangeBounds)
+	}
+	if uses("Int64Range", "NullInt64Range") {
+		statics = append(statics, staticInt64Range)
+	}
+	if uses("Float64Range", "NullFloat64Range") {
+		statics = append(statics, staticFloat64Range)
+	}
+	if uses("TimeRange", "NullTimeRange", "DateRange", "NullDateRange") {
+		statics = append(statics, staticTimeRange)
+	}
+	if uses("DateRange", "NullDateRange") {
+		statics = append(statics, staticDateRange)
+	}
+	if len(statics) > 0 {
+		statics = append(statics, staticSourceString)
+	}
+	for _, code := range statics {
+		g.NewLine()
+		g.Print(code)
+	}
+}
+
+// usedTypes returns set of Go types that columns and composite types of the schema are represented by.
+func (g *Generator) usedTypes(s *pqt.Schema) map[string]bool {
+	used := make(map[string]bool)
+	add := func(name string) {
+		used[strings.TrimLeft(name, "*[]")] = true
+	}
+	var columns pqt.Columns
+	for _, t := range s.Tables {
+		columns = append(columns, t.Columns...)
+	}
+	for _, v := range s.Views {
+		columns = append(columns, v.Columns...)
+	}
+	for _, c := range columns {
+		for _, m := range []int32{pqtgo.ModeDefault, pqtgo.ModeMandatory, pqtgo.ModeOptional, pqtgo.ModeCriteria} {
+			add(g.columnType(c, m))
+		}
+	}
+	for _, t := range s.Types {
+		if ct, ok := t.(pqt.CompositeType); ok {
+			for _, a := range ct.Attributes {
+				add(attributeType(a))
+			}
+		}
+	}
+	return used
 }
 
 func (g *Generator) PluginsStatics(s *pqt.Schema) {
@@ -1026,3 +1264,485 @@ func (g *Generator) PluginsStatics(s *pqt.Schema) {
 		}
 	}
 }
+
+// Go counterparts of types that are not supported by the pq library, they are generated only if schema makes use of them.
+const staticHstore = `
+// Hstore is a set of key/value pairs stored within a single value, nil map represents NULL.
+type Hstore map[string]sql.NullString
+
+// Scan satisfy sql.Scanner interface.
+func (h *Hstore) Scan(src interface{}) error {
+	if src == nil {
+		*h = nil
+		return nil
+	}
+	s, err := sourceString(src)
+	if err != nil {
+		return err
+	}
+
+	res := make(Hstore)
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		key, tail, ok := hstoreQuoted(rest)
+		if !ok {
+			return fmt.Errorf("expected to get source argument in format '\"key\"=>\"value\", ...', but got %s", s)
+		}
+		tail = strings.TrimSpace(tail)
+		if !strings.HasPrefix(tail, "=>") {
+			return fmt.Errorf("expected to get source argument in format '\"key\"=>\"value\", ...', but got %s", s)
+		}
+		tail = strings.TrimSpace(tail[2:])
+
+		var value sql.NullString
+		if strings.HasPrefix(tail, "NULL") {
+			tail = tail[4:]
+		} else {
+			if value.String, tail, ok = hstoreQuoted(tail); !ok {
+				return fmt.Errorf("expected to get source argument in format '\"key\"=>\"value\", ...', but got %s", s)
+			}
+			value.Valid = true
+		}
+		res[key] = value
+		rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tail), ","))
+	}
+	*h = res
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (h Hstore) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	pairs := make([]string, 0, len(h))
+	for k, v := range h {
+		if !v.Valid {
+			pairs = append(pairs, hstoreQuote(k)+"=>NULL")
+			continue
+		}
+		pairs = append(pairs, hstoreQuote(k)+"=>"+hstoreQuote(v.String))
+	}
+	return strings.Join(pairs, ", "), nil
+}
+
+// hstoreQuoted reads double quoted string from the beginning of s and returns it unescaped, together with the remainder.
+func hstoreQuoted(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, "\"") {
+		return "", "", false
+	}
+	var buf bytes.Buffer
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i < len(s) {
+				buf.WriteByte(s[i])
+			}
+		case '"':
+			return buf.String(), s[i+1:], true
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return "", "", false
+}
+
+func hstoreQuote(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s) + "\""
+}`
+
+const staticPoint = `
+// Point represents point on a plane.
+type Point struct {
+	X, Y float64
+}
+
+// Scan satisfy sql.Scanner interface.
+func (p *Point) Scan(src interface{}) error {
+	s, err := sourceString(src)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Sscanf(s, "(%g,%g)", &p.X, &p.Y); err != nil {
+		return fmt.Errorf("expected to get source argument in format '(x,y)', but got %s", s)
+	}
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (p Point) Value() (driver.Value, error) {
+	return "(" + strconv.FormatFloat(p.X, 'f', -1, 64) + "," + strconv.FormatFloat(p.Y, 'f', -1, 64) + ")", nil
+}
+
+type NullPoint struct {
+	Point
+	Valid bool
+}
+
+func (n *NullPoint) Scan(value interface{}) error {
+	if value == nil {
+		n.Point, n.Valid = Point{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Point.Scan(value)
+}
+
+func (n NullPoint) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Point.Value()
+}`
+
+const staticRangeBounds = `
+// rangeBounds is a textual representation of a range, nil bound means that range is unbounded on that side.
+type rangeBounds struct {
+	lower, upper                   *string
+	lowerInclusive, upperInclusive bool
+	empty                          bool
+}
+
+func scanRange(src interface{}) (*rangeBounds, error) {
+	s, err := sourceString(src)
+	if err != nil {
+		return nil, err
+	}
+	if s == "empty" {
+		return &rangeBounds{empty: true}, nil
+	}
+	l := len(s)
+	if l < 3 || !strings.ContainsAny(s[:1], "[(") || !strings.ContainsAny(s[l-1:], "])") {
+		return nil, fmt.Errorf("expected to get source argument in format '[lower,upper)', but got %s", s)
+	}
+
+	var (
+		bounds  []*string
+		buf     bytes.Buffer
+		quoted  bool
+		present bool
+	)
+	bound := func() {
+		if !present {
+			bounds = append(bounds, nil)
+		} else {
+			b := buf.String()
+			bounds = append(bounds, &b)
+		}
+		buf.Reset()
+		present = false
+	}
+	for i := 1; i < l-1; i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < l-1:
+			i++
+			buf.WriteByte(s[i])
+			present = true
+		case c == '"':
+			quoted = !quoted
+			present = true
+		case c == ',' && !quoted:
+			bound()
+		default:
+			buf.WriteByte(c)
+			present = true
+		}
+	}
+	bound()
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("expected to get source argument in format '[lower,upper)', but got %s", s)
+	}
+
+	return &rangeBounds{
+		lower:          bounds[0],
+		upper:          bounds[1],
+		lowerInclusive: s[0] == '[',
+		upperInclusive: s[l-1] == ']',
+	}, nil
+}
+
+// String implements fmt Stringer interface.
+func (b rangeBounds) String() string {
+	if b.empty {
+		return "empty"
+	}
+	var buf bytes.Buffer
+	if b.lowerInclusive {
+		buf.WriteString("[")
+	} else {
+		buf.WriteString("(")
+	}
+	if b.lower != nil {
+		buf.WriteString(rangeQuote(*b.lower))
+	}
+	buf.WriteString(",")
+	if b.upper != nil {
+		buf.WriteString(rangeQuote(*b.upper))
+	}
+	if b.upperInclusive {
+		buf.WriteString("]")
+	} else {
+		buf.WriteString(")")
+	}
+	return buf.String()
+}
+
+// rangeQuote double quotes the bound, so it can contain any character, including comma and parentheses.
+func rangeQuote(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s) + "\""
+}`
+
+const staticInt64Range = `
+// Int64Range represents int4range and int8range, nil bound means that range is unbounded on that side.
+type Int64Range struct {
+	Lower, Upper                   *int64
+	LowerInclusive, UpperInclusive bool
+	Empty                          bool
+}
+
+// Scan satisfy sql.Scanner interface.
+func (r *Int64Range) Scan(src interface{}) error {
+	b, err := scanRange(src)
+	if err != nil {
+		return err
+	}
+	*r = Int64Range{LowerInclusive: b.lowerInclusive, UpperInclusive: b.upperInclusive, Empty: b.empty}
+	if b.lower != nil {
+		v, err := strconv.ParseInt(*b.lower, 10, 64)
+		if err != nil {
+			return err
+		}
+		r.Lower = &v
+	}
+	if b.upper != nil {
+		v, err := strconv.ParseInt(*b.upper, 10, 64)
+		if err != nil {
+			return err
+		}
+		r.Upper = &v
+	}
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (r Int64Range) Value() (driver.Value, error) {
+	b := rangeBounds{lowerInclusive: r.LowerInclusive, upperInclusive: r.UpperInclusive, empty: r.Empty}
+	if r.Lower != nil {
+		v := strconv.FormatInt(*r.Lower, 10)
+		b.lower = &v
+	}
+	if r.Upper != nil {
+		v := strconv.FormatInt(*r.Upper, 10)
+		b.upper = &v
+	}
+	return b.String(), nil
+}
+
+type NullInt64Range struct {
+	Int64Range
+	Valid bool
+}
+
+func (n *NullInt64Range) Scan(value interface{}) error {
+	if value == nil {
+		n.Int64Range, n.Valid = Int64Range{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Int64Range.Scan(value)
+}
+
+func (n NullInt64Range) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Int64Range.Value()
+}`
+
+const staticFloat64Range = `
+// Float64Range represents numrange, nil bound means that range is unbounded on that side.
+type Float64Range struct {
+	Lower, Upper                   *float64
+	LowerInclusive, UpperInclusive bool
+	Empty                          bool
+}
+
+// Scan satisfy sql.Scanner interface.
+func (r *Float64Range) Scan(src interface{}) error {
+	b, err := scanRange(src)
+	if err != nil {
+		return err
+	}
+	*r = Float64Range{LowerInclusive: b.lowerInclusive, UpperInclusive: b.upperInclusive, Empty: b.empty}
+	if b.lower != nil {
+		v, err := strconv.ParseFloat(*b.lower, 64)
+		if err != nil {
+			return err
+		}
+		r.Lower = &v
+	}
+	if b.upper != nil {
+		v, err := strconv.ParseFloat(*b.upper, 64)
+		if err != nil {
+			return err
+		}
+		r.Upper = &v
+	}
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (r Float64Range) Value() (driver.Value, error) {
+	b := rangeBounds{lowerInclusive: r.LowerInclusive, upperInclusive: r.UpperInclusive, empty: r.Empty}
+	if r.Lower != nil {
+		v := strconv.FormatFloat(*r.Lower, 'f', -1, 64)
+		b.lower = &v
+	}
+	if r.Upper != nil {
+		v := strconv.FormatFloat(*r.Upper, 'f', -1, 64)
+		b.upper = &v
+	}
+	return b.String(), nil
+}
+
+type NullFloat64Range struct {
+	Float64Range
+	Valid bool
+}
+
+func (n *NullFloat64Range) Scan(value interface{}) error {
+	if value == nil {
+		n.Float64Range, n.Valid = Float64Range{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Float64Range.Scan(value)
+}
+
+func (n NullFloat64Range) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Float64Range.Value()
+}`
+
+const staticTimeRange = `
+// TimeRange represents tsrange and tstzrange, nil bound means that range is unbounded on that side.
+type TimeRange struct {
+	Lower, Upper                   *time.Time
+	LowerInclusive, UpperInclusive bool
+	Empty                          bool
+}
+
+// Scan satisfy sql.Scanner interface.
+func (r *TimeRange) Scan(src interface{}) error {
+	b, err := scanRange(src)
+	if err != nil {
+		return err
+	}
+	*r = TimeRange{LowerInclusive: b.lowerInclusive, UpperInclusive: b.upperInclusive, Empty: b.empty}
+	if b.lower != nil {
+		v, err := pq.ParseTimestamp(nil, *b.lower)
+		if err != nil {
+			return err
+		}
+		r.Lower = &v
+	}
+	if b.upper != nil {
+		v, err := pq.ParseTimestamp(nil, *b.upper)
+		if err != nil {
+			return err
+		}
+		r.Upper = &v
+	}
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (r TimeRange) Value() (driver.Value, error) {
+	return r.value(time.RFC3339Nano), nil
+}
+
+// value returns textual representation of the range, bounds are formatted using given layout.
+func (r TimeRange) value(layout string) string {
+	b := rangeBounds{lowerInclusive: r.LowerInclusive, upperInclusive: r.UpperInclusive, empty: r.Empty}
+	if r.Lower != nil {
+		v := r.Lower.Format(layout)
+		b.lower = &v
+	}
+	if r.Upper != nil {
+		v := r.Upper.Format(layout)
+		b.upper = &v
+	}
+	return b.String()
+}
+
+type NullTimeRange struct {
+	TimeRange
+	Valid bool
+}
+
+func (n *NullTimeRange) Scan(value interface{}) error {
+	if value == nil {
+		n.TimeRange, n.Valid = TimeRange{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.TimeRange.Scan(value)
+}
+
+func (n NullTimeRange) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.TimeRange.Value()
+}`
+
+const staticDateRange = `
+// DateRange represents daterange, nil bound means that range is unbounded on that side.
+type DateRange TimeRange
+
+// Scan satisfy sql.Scanner interface.
+func (r *DateRange) Scan(src interface{}) error {
+	return (*TimeRange)(r).Scan(src)
+}
+
+// Value satisfy driver.Valuer interface.
+func (r DateRange) Value() (driver.Value, error) {
+	return TimeRange(r).value("2006-01-02"), nil
+}
+
+type NullDateRange struct {
+	DateRange
+	Valid bool
+}
+
+func (n *NullDateRange) Scan(value interface{}) error {
+	if value == nil {
+		n.DateRange, n.Valid = DateRange{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.DateRange.Scan(value)
+}
+
+func (n NullDateRange) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.DateRange.Value()
+}`
+
+const staticSourceString = `
+// sourceString returns textual representation of the value read from the database.
+func sourceString(src interface{}) (string, error) {
+	switch t := src.(type) {
+	case []byte:
+		return string(t), nil
+	case string:
+		return t, nil
+	default:
+		return "", fmt.Errorf("expected slice of bytes or string as a source argument in Scan, not %T", src)
+	}
+}`
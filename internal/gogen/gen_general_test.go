@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go/format"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -131,6 +132,23 @@ child, sibling, parent *ExampleCriteria
 			}(),
 			exp: expected(testColumn{"Age", "*int32"}, testColumn{"Dynamic", "*int32"}),
 		},
+		"column-integer-array": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeInteger()), pqt.WithNotNull())),
+			exp: expected(
+				testColumn{"A", "NullInt64Array"},
+				testColumn{"AContains", "NullInt64Array"},
+				testColumn{"AOverlap", "NullInt64Array"},
+				testColumn{"AAny", "*int32"},
+			),
+		},
+		"column-multidimensional-array": {
+			table: table(pqt.NewColumn("a", pqt.TypeArray(pqt.TypeInteger(), 0, 0))),
+			exp:   expected(testColumn{"A", "sql.NullString"}),
+		},
+		"generated": {
+			table: table(pqt.NewColumn("slug", pqt.TypeText(), pqt.WithGenerated("lower(name)"))),
+			exp:   expected(testColumn{"Slug", "sql.NullString"}),
+		},
 	}
 
 	for hint, c := range cases {
@@ -142,6 +160,29 @@ child, sibling, parent *ExampleCriteria
 	}
 }
 
+func TestGenerator_Criteria_comment(t *testing.T) {
+	t1 := pqt.NewTable("t1", pqt.WithTableComment("is a test table")).
+		AddColumn(pqt.NewColumn("name", pqt.TypeText(), pqt.WithComment("is a name")))
+
+	g := &gogen.Generator{}
+	g.Criteria(t1)
+	g.Patch(t1)
+	testutil.AssertOutput(t, g.Printer, `
+// T1Criteria is a test table
+type T1Criteria struct {
+	// Name is a name
+	Name                   sql.NullString
+	operator               string
+	child, sibling, parent *T1Criteria
+}
+
+// T1Patch is a test table
+type T1Patch struct {
+	// Name is a name
+	Name sql.NullString
+}`)
+}
+
 func TestGenerator_Operand(t *testing.T) {
 	g := &gogen.Generator{}
 	g.Operand(pqt.NewTable("example"))
@@ -190,6 +231,28 @@ type T2RepositoryBase struct {
 }`)
 }
 
+func TestGenerator_Repository_comment(t *testing.T) {
+	t1 := pqt.NewTable("t1", pqt.WithTableComment("is a test table"))
+
+	g := &gogen.Generator{}
+	g.Repository(t1)
+	g.RepositoryTx(t1)
+	testutil.AssertOutput(t, g.Printer, `
+// T1RepositoryBase is a test table
+type T1RepositoryBase struct {
+	Table   string
+	Columns []string
+	DB      *sql.DB
+	Log     LogFunc
+}
+
+// T1RepositoryBaseTx is a test table
+type T1RepositoryBaseTx struct {
+	base *T1RepositoryBase
+	tx   *sql.Tx
+}`)
+}
+
 func TestGenerator_Columns(t *testing.T) {
 	t1 := pqt.NewTable("t1")
 	t2 := pqt.NewTable("t2").
@@ -215,6 +278,57 @@ var TableT2Columns = []string{
 }`)
 }
 
+func TestGenerator_Columns_quoted(t *testing.T) {
+	user := pqt.NewTable("user").
+		AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("order", pqt.TypeInteger(), pqt.WithNotNull())).
+		AddColumn(pqt.NewColumn("FirstName", pqt.TypeText(), pqt.WithNotNull()))
+	pqt.NewSchema("example").AddTable(user)
+
+	g := &gogen.Generator{}
+	g.Columns(user)
+	g.NewLine()
+	g.EntityProp(user)
+	testutil.AssertOutput(t, g.Printer, `
+const (
+	TableUser                = "example.\"user\""
+	TableUserColumnFirstName = "FirstName"
+	TableUserColumnID        = "id"
+	TableUserColumnOrder     = "order"
+)
+
+var TableUserColumns = []string{
+	TableUserColumnFirstName,
+	TableUserColumnID,
+	TableUserColumnOrder,
+}
+
+// quoteUserColumn returns name of the column as it has to be written into a query.
+func quoteUserColumn(cn string) string {
+	switch cn {
+	case TableUserColumnFirstName:
+		return "\"FirstName\""
+	case TableUserColumnOrder:
+		return "\"order\""
+	}
+	return cn
+}
+
+func (e *UserEntity) Prop(cn string) (interface{}, bool) {
+	switch cn {
+
+	case TableUserColumnFirstName:
+		return &e.FirstName, true
+	case TableUserColumnID:
+		return &e.ID, true
+	case TableUserColumnOrder:
+		return &e.Order, true
+	default:
+		return nil, false
+	}
+}`)
+}
+
 func TestGenerator_Constraints(t *testing.T) {
 	name := pqt.NewColumn("name", pqt.TypeText(), pqt.WithNotNull(), pqt.WithIndex())
 	description := pqt.NewColumn("description", pqt.TypeText(), pqt.WithColumnShortName("desc"))
@@ -228,7 +342,8 @@ func TestGenerator_Constraints(t *testing.T) {
 		AddColumn(name).
 		AddColumn(description).
 		AddUnique(name, description).
-		AddCheck("name <> 'LOL'", name)
+		AddCheck("name <> 'LOL'", name).
+		AddExclusion("gist", "", pqt.ExcludeColumn(name, "="), pqt.ExcludeExpression("length(description)", "<>"))
 
 	pqt.NewSchema("constraints_test").
 		AddTable(t1).
@@ -243,6 +358,35 @@ const (
 	TableT2ConstraintNameIndex             = "constraints_test.t2_name_idx"
 	TableT2ConstraintNameDescriptionUnique = "constraints_test.t2_name_desc_key"
 	TableT2ConstraintNameCheck             = "constraints_test.t2_name_check"
+	TableT2ConstraintNameExclusion         = "constraints_test.t2_name_excl"
+)`)
+}
+
+type typeFirstNamingStrategy struct{}
+
+func (typeFirstNamingStrategy) ConstraintName(c *pqt.Constraint) string {
+	return string(c.Type) + "_" + c.PrimaryTable.Name + "_" + pqt.JoinColumns(c.PrimaryColumns, "_")
+}
+
+func (typeFirstNamingStrategy) ConstraintType(name string) pqt.ConstraintType {
+	return pqt.ConstraintType(name[:strings.Index(name, "_")])
+}
+
+func TestGenerator_Constraints_namingStrategy(t *testing.T) {
+	name := pqt.NewColumn("name", pqt.TypeText(), pqt.WithNotNull())
+	t1 := pqt.NewTable("t1").
+		AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithPrimaryKey())).
+		AddColumn(name)
+	t1.AddConstraint(pqt.UniqueOn(t1, pqt.Columns{name}, pqt.WithConstraintName("t1_name_uq")))
+
+	pqt.NewSchema("constraints_test", pqt.WithNamingStrategy(typeFirstNamingStrategy{})).AddTable(t1)
+
+	g := &gogen.Generator{}
+	g.Constraints(t1)
+	testutil.AssertOutput(t, g.Printer, `
+const (
+	TableT1ConstraintPrimaryKey = "pkey_t1_id"
+	TableT1ConstraintNameUnique = "t1_name_uq"
 )`)
 }
 
@@ -262,6 +406,23 @@ type T2FindExpr struct {
 }`)
 }
 
+func TestGenerator_FindExpr_inherited(t *testing.T) {
+	t1 := pqt.NewTable("t1")
+	t2 := pqt.NewTable("t2", pqt.WithInherits(t1))
+	pqt.NewSchema("inherits_test").AddTable(t1).AddTable(t2)
+
+	g := &gogen.Generator{}
+	g.FindExpr(t1)
+	testutil.AssertOutput(t, g.Printer, `
+type T1FindExpr struct {
+	Where         *T1Criteria
+	Offset, Limit int64
+	Columns       []string
+	OrderBy       []RowOrder
+	Only          bool
+}`)
+}
+
 func TestGenerator_CountExpr(t *testing.T) {
 	t1 := pqt.NewTable("t1")
 	t2 := pqt.NewTable("t2").
@@ -358,6 +519,14 @@ type ExamplePatch struct {`)
 			}(),
 			exp: expected(testColumn{"Age", "*int32"}, testColumn{"Dynamic", "*int32"}),
 		},
+		"generated": {
+			table: table(
+				pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityByDefault)),
+				pqt.NewColumn("name", pqt.TypeText()),
+				pqt.NewColumn("slug", pqt.TypeText(), pqt.WithGenerated("lower(name)")),
+			),
+			exp: expected(testColumn{"Name", "sql.NullString"}),
+		},
 	}
 
 	for hint, c := range cases {
@@ -530,6 +699,96 @@ func _T1CriteriaWhereClause(comp *Composer, c *T1Criteria, id int) error {
 		comp.Dirty = true
 	}
 	return nil
+}`),
+		},
+		{
+			hint: "array",
+			col:  pqt.NewColumn("xyz", pqt.TypeArray(pqt.TypeUUID())),
+			exp: exp(`NullStringArray
+	XyzContains NullStringArray
+	XyzOverlap NullStringArray
+	XyzAny sql.NullString`, `
+func _T1CriteriaWhereClause(comp *Composer, c *T1Criteria, id int) error {
+	if c.Xyz.Valid {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
+		}
+		if err := comp.WriteAlias(id); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(TableT1ColumnXyz); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString("="); err != nil {
+			return err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.Xyz)
+		comp.Dirty = true
+	}
+	if c.XyzContains.Valid {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
+		}
+		if err := comp.WriteAlias(id); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(TableT1ColumnXyz); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(" @> "); err != nil {
+			return err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.XyzContains)
+		comp.Dirty = true
+	}
+	if c.XyzOverlap.Valid {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
+		}
+		if err := comp.WriteAlias(id); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(TableT1ColumnXyz); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(" && "); err != nil {
+			return err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.XyzOverlap)
+		comp.Dirty = true
+	}
+	if c.XyzAny.Valid {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.XyzAny)
+		if _, err := comp.WriteString(" = ANY("); err != nil {
+			return err
+		}
+		if err := comp.WriteAlias(id); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(TableT1ColumnXyz); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(")"); err != nil {
+			return err
+		}
+		comp.Dirty = true
+	}
+	return nil
 }`),
 		},
 		{
@@ -748,7 +1007,6 @@ func TestGenerator_withNoArgument(t *testing.T) {
 		"Errors",
 		"Funcs",
 		"Interfaces",
-		"Statics",
 		"RunInTransaction",
 		"JoinClause",
 	}
@@ -767,6 +1025,55 @@ func TestGenerator_withNoArgument(t *testing.T) {
 	}
 }
 
+func TestGenerator_Statics(t *testing.T) {
+	cases := map[string]struct {
+		schema            *pqt.Schema
+		expected, missing []string
+	}{
+		"none": {
+			schema:  pqt.NewSchema("example").AddTable(pqt.NewTable("user").AddColumn(pqt.NewColumn("id", pqt.TypeSerial()))),
+			missing: []string{"type Hstore", "type Point", "type rangeBounds", "func sourceString"},
+		},
+		"hstore": {
+			schema:   pqt.NewSchema("example").AddTable(pqt.NewTable("user").AddColumn(pqt.NewColumn("attributes", pqt.TypeHstore()))),
+			expected: []string{"type Hstore", "func sourceString"},
+			missing:  []string{"type Point", "type rangeBounds"},
+		},
+		"date-range": {
+			schema:   pqt.NewSchema("example").AddTable(pqt.NewTable("user").AddColumn(pqt.NewColumn("period", pqt.TypeDateRange(), pqt.WithNotNull()))),
+			expected: []string{"type rangeBounds", "type TimeRange", "type DateRange", "type NullDateRange", "func sourceString"},
+			missing:  []string{"type Hstore", "type Int64Range", "type Float64Range"},
+		},
+		"composite": {
+			schema: pqt.NewSchema("example").AddType(pqt.TypeComposite("location",
+				&pqt.Attribute{Name: "position", Type: pqt.TypePoint()},
+			)),
+			expected: []string{"type Point", "type NullPoint"},
+			missing:  []string{"type rangeBounds"},
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			g := &gogen.Generator{}
+			g.Statics(c.schema)
+			assertSourceFormatting(t, g, true)
+
+			got := g.String()
+			for _, exp := range c.expected {
+				if !strings.Contains(got, exp) {
+					t.Errorf("%s expected to be generated", exp)
+				}
+			}
+			for _, miss := range c.missing {
+				if strings.Contains(got, miss) {
+					t.Errorf("%s is not expected to be generated", miss)
+				}
+			}
+		})
+	}
+}
+
 func TestGenerator_withTableName(t *testing.T) {
 	cases := []string{
 		"Operand",
@@ -882,6 +1189,15 @@ func TestGenerator_withUniqueConstraint(t *testing.T) {
 				reflect.ValueOf(t2),
 			})
 			assertSourceFormatting(t, g, true)
+			g.Reset()
+
+			c4 := pqt.NewColumn("email", pqt.TypeText())
+			t3 := pqt.NewTable("t3").AddColumn(c4)
+			t3.AddConstraint(pqt.IndexOn(t3, []*pqt.IndexElement{pqt.IndexExpression("lower(email)")}, pqt.WithUniqueIndex("")))
+			_ = reflect.ValueOf(g).MethodByName(c).Call([]reflect.Value{
+				reflect.ValueOf(t3),
+			})
+			assertSourceFormatting(t, g, false)
 		})
 	}
 }
@@ -50,7 +50,26 @@ func (g *Generator) isArray(c *pqt.Column, m int32) bool {
 		return true
 	}
 
-	return g.isType(c, m, "pq.StringArray", "pq.Int64Array", "pq.BoolArray", "pq.Float64Array", "pq.ByteaArray", "pq.GenericArray")
+	return g.isType(c, m, "pq.StringArray", "pq.Int64Array", "pq.BoolArray", "pq.Float64Array", "pq.ByteaArray", "pq.GenericArray", "TimeArray")
+}
+
+// arrayElem returns criteria type of the array element if criteria of given column support array operators.
+// Columns handled by plugins and multidimensional arrays are compared using equality only.
+func (g *Generator) arrayElem(c *pqt.Column) (string, bool) {
+	at, ok := underlyingType(c.Type).(pqt.ArrayType)
+	if !ok || at.Elem == nil || at.Dimensions() > 1 || c.IsDynamic {
+		return "", false
+	}
+	for _, plugin := range g.Plugins {
+		if plugin.PropertyType(c, pqtgo.ModeCriteria) != "" || plugin.WhereClause(c) != "" {
+			return "", false
+		}
+	}
+	elem := pqtfmt.Type(at.Elem, pqtgo.ModeCriteria)
+	if elem == "" {
+		return "", false
+	}
+	return elem, true
 }
 
 func (g *Generator) columnType(c *pqt.Column, m int32) string {
@@ -67,6 +86,39 @@ func (g *Generator) columnType(c *pqt.Column, m int32) string {
 	return res
 }
 
+// primaryKeyType returns type that primary key is passed as to generated methods.
+// Multi column key is passed as a struct generated by PrimaryKey.
+func (g *Generator) primaryKeyType(t *pqt.Table, pk pqt.Columns) string {
+	if len(pk) == 1 {
+		return g.columnType(pk[0], pqtgo.ModeMandatory)
+	}
+	return pqtfmt.Public(tableName(t), "primaryKey")
+}
+
+// primaryKeyCondition generates code that writes condition matching the primary key into the composer.
+func (g *Generator) primaryKeyCondition(t *pqt.Table, pk pqt.Columns, composer string) {
+	for i, c := range pk {
+		arg := "pk"
+		if len(pk) > 1 {
+			arg = "pk." + pqtfmt.Public(c.Name)
+		}
+		if i > 0 {
+			g.Printf(`
+		%s.WriteString(" AND ")`, composer)
+		}
+		g.Printf(`
+		%s.WriteString(%s)
+		%s.WriteString("=")
+		%s.WritePlaceholder()
+		%s.Add(%s)`,
+			composer, columnIdentifier(c),
+			composer,
+			composer,
+			composer, arg,
+		)
+	}
+}
+
 func (g *Generator) isType(c *pqt.Column, m int32, types ...string) bool {
 	for _, t := range types {
 		if g.columnType(c, m) == t {
@@ -77,21 +129,14 @@ func (g *Generator) isType(c *pqt.Column, m int32, types ...string) bool {
 }
 
 func (g *Generator) isNullable(c *pqt.Column, m int32) bool {
+	if et, ok := underlyingType(c.Type).(pqt.EnumeratedType); ok {
+		return g.isType(c, m, "Null"+pqtfmt.EnumeratedType(et))
+	}
 	if mt, ok := c.Type.(pqt.MappableType); ok {
 		for _, mapto := range mt.Mapping {
 			if ct, ok := mapto.(pqtgo.CustomType); ok {
-				tof := ct.TypeOf(columnMode(c, m))
-				if tof == nil {
-					continue
-				}
-				if tof.Kind() != reflect.Struct {
-					continue
-				}
-
-				if field, ok := tof.FieldByName("Valid"); ok {
-					if field.Type.Kind() == reflect.Bool {
-						return true
-					}
+				if gt := ct.GoTypeOf(columnMode(c, m)); gt != nil && gt.Nullable {
+					return true
 				}
 			}
 		}
@@ -111,6 +156,12 @@ func (g *Generator) isNullable(c *pqt.Column, m int32) bool {
 		"NullByteaArray",
 		"NullStringArray",
 		"NullBoolArray",
+		"NullPoint",
+		"NullInt64Range",
+		"NullFloat64Range",
+		"NullTimeRange",
+		"NullDateRange",
+		"NullTimeArray",
 	)
 }
 
@@ -119,12 +170,9 @@ func (g *Generator) canBeNil(c *pqt.Column, m int32) bool {
 		for _, mapto := range tp.Mapping {
 			if ct, ok := mapto.(pqtgo.CustomType); ok {
 				switch m {
-				case pqtgo.ModeMandatory:
-					return ct.TypeOf(pqtgo.ModeMandatory).Kind() == reflect.Ptr || ct.TypeOf(pqtgo.ModeMandatory).Kind() == reflect.Map
-				case pqtgo.ModeOptional:
-					return ct.TypeOf(pqtgo.ModeOptional).Kind() == reflect.Ptr || ct.TypeOf(pqtgo.ModeOptional).Kind() == reflect.Map
-				case pqtgo.ModeCriteria:
-					return ct.TypeOf(pqtgo.ModeCriteria).Kind() == reflect.Ptr || ct.TypeOf(pqtgo.ModeCriteria).Kind() == reflect.Map
+				case pqtgo.ModeMandatory, pqtgo.ModeOptional, pqtgo.ModeCriteria:
+					gt := ct.GoTypeOf(m)
+					return gt != nil && (gt.Kind == reflect.Ptr || gt.Kind == reflect.Map)
 				default:
 					return false
 				}
@@ -146,6 +194,7 @@ func (g *Generator) canBeNil(c *pqt.Column, m int32) bool {
 		"pq.BoolArray",
 		"pq.Int64Array",
 		"pq.Float64Array",
+		"Hstore",
 	) {
 		return true
 	}
@@ -158,26 +207,23 @@ func (g *Generator) selectList(t *pqt.Table, nb int) {
 			g.Print(", ")
 		}
 		if c.IsDynamic {
-			g.Printf("%s(", c.Func.Name)
-			for i, arg := range c.Func.Args {
-				if arg.Type != c.Columns[i].Type {
-					fmt.Printf("wrong function (%s) argument type, expected %v but got %v\n", c.Func.Name, arg.Type, c.Columns[i].Type)
-				}
+			g.Printf("%s(", escaped(functionName(c)))
+			for i := range c.Func.Args {
 				if i != 0 {
 					g.Print(", ")
 				}
 				if nb > -1 {
-					g.Printf("t%d.%s", nb, c.Columns[i].Name)
+					g.Printf("t%d.%s", nb, escaped(c.Columns[i].QuotedName()))
 				} else {
-					g.Printf("%s", c.Columns[i].Name)
+					g.Printf("%s", escaped(c.Columns[i].QuotedName()))
 				}
 			}
-			g.Printf(") AS %s", c.Name)
+			g.Printf(") AS %s", escaped(c.QuotedName()))
 		} else {
 			if nb > -1 {
-				g.Printf("t%d.%s", nb, c.Name)
+				g.Printf("t%d.%s", nb, escaped(c.QuotedName()))
 			} else {
-				g.Printf("%s", c.Name)
+				g.Printf("%s", escaped(c.QuotedName()))
 			}
 		}
 	}
@@ -207,6 +253,11 @@ func (g *Generator) generateRepositoryInsertClause(c *pqt.Column, sel string) {
 					if !e.%s.IsZero() {`, pqtfmt.Public(c.Name))
 			braces++
 		}
+		if et, ok := underlyingType(c.Type).(pqt.EnumeratedType); ok && g.isType(c, pqtgo.ModeDefault, pqtfmt.EnumeratedType(et)) {
+			g.Printf(`
+					if e.%s != "" {`, pqtfmt.Public(c.Name))
+			braces++
+		}
 		g.Printf(strings.Replace(`
 			if columns.Len() > 0 {
 				if _, err := columns.WriteString(", "); err != nil {
@@ -226,7 +277,7 @@ func (g *Generator) generateRepositoryInsertClause(c *pqt.Column, sel string) {
 			}
 			{{SELECTOR}}.Add(e.%s)
 			{{SELECTOR}}.Dirty=true`, "{{SELECTOR}}", sel, -1),
-			pqtfmt.Public("table", c.Table.Name, "column", c.Name),
+			columnIdentifier(c),
 			pqtfmt.Public(c.Name),
 		)
 
@@ -236,7 +287,7 @@ func (g *Generator) generateRepositoryInsertClause(c *pqt.Column, sel string) {
 }
 
 func (g *Generator) generateRepositorySetClause(c *pqt.Column, sel string) {
-	if c.PrimaryKey {
+	if keyColumn(c) || computed(c) {
 		return
 	}
 	for _, plugin := range g.Plugins {
@@ -247,7 +298,7 @@ func (g *Generator) generateRepositorySetClause(c *pqt.Column, sel string) {
 			}
 			if err = tmpl.Execute(g, map[string]interface{}{
 				"selector": fmt.Sprintf("p.%s", pqtfmt.Public(c.Name)),
-				"column":   pqtfmt.Public("table", c.Table.Name, "column", c.Name),
+				"column":   columnIdentifier(c),
 				"composer": sel,
 			}); err != nil {
 				panic(err)
@@ -291,7 +342,7 @@ func (g *Generator) generateRepositorySetClause(c *pqt.Column, sel string) {
 		{{SELECTOR}}.Add(p.%s)
 		{{SELECTOR}}.Dirty=true
 		`, "{{SELECTOR}}", sel, -1),
-		pqtfmt.Public("table", c.Table.Name, "column", c.Name),
+		columnIdentifier(c),
 		pqtfmt.Public(c.Name),
 	)
 
@@ -311,7 +362,7 @@ func (g *Generator) generateRepositorySetClause(c *pqt.Column, sel string) {
 						return "", nil, err
 					}
 				{{SELECTOR}}.Dirty=true`, "{{SELECTOR}}", sel, -1),
-				pqtfmt.Public("table", c.Table.Name, "column", c.Name),
+				columnIdentifier(c),
 				d,
 			)
 		}
@@ -344,7 +395,7 @@ func (g *Generator) scanJoinableRelationships(t *pqt.Table, sel string) {
 			joinPropertyName,
 			pqtfmt.Public("fetch"),
 			nestedEntityPropertyName,
-			pqtfmt.Public(r.InversedTable.Name),
+			pqtfmt.Public(tableName(r.InversedTable)),
 			nestedEntityPropertyName,
 			pqtfmt.Public("props"),
 		)
@@ -359,29 +410,29 @@ func (g *Generator) entityPropertiesGenerator(t *pqt.Table) chan structField {
 	go func(out chan structField) {
 		for _, c := range t.Columns {
 			if t := g.columnType(c, pqtgo.ModeDefault); t != "<nil>" {
-				out <- structField{Name: pqtfmt.Public(c.Name), Type: t, ReadOnly: c.IsDynamic}
+				out <- structField{Name: pqtfmt.Public(c.Name), Type: t, ReadOnly: c.IsReadOnly(), Comment: c.Comment}
 			}
 		}
 
 		for _, r := range t.OwnedRelationships {
 			switch r.Type {
 			case pqt.RelationshipTypeOneToMany:
-				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(r.InversedTable.Name))}
+				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(tableName(r.InversedTable)))}
 			case pqt.RelationshipTypeOneToOne:
-				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(r.InversedTable.Name))}
+				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(tableName(r.InversedTable)))}
 			case pqt.RelationshipTypeManyToOne:
-				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(r.InversedTable.Name))}
+				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(tableName(r.InversedTable)))}
 			}
 		}
 
 		for _, r := range t.InversedRelationships {
 			switch r.Type {
 			case pqt.RelationshipTypeOneToMany:
-				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(r.OwnerTable.Name))}
+				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(tableName(r.OwnerTable)))}
 			case pqt.RelationshipTypeOneToOne:
-				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(r.OwnerTable.Name))}
+				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name)), Type: fmt.Sprintf("*%sEntity", pqtfmt.Public(tableName(r.OwnerTable)))}
 			case pqt.RelationshipTypeManyToOne:
-				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(r.OwnerTable.Name))}
+				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(tableName(r.OwnerTable)))}
 			}
 		}
 
@@ -391,10 +442,10 @@ func (g *Generator) entityPropertiesGenerator(t *pqt.Table) chan structField {
 			}
 
 			switch {
-			case r.OwnerTable == t:
-				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(r.InversedTable.Name))}
-			case r.InversedTable == t:
-				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(r.OwnerTable.Name))}
+			case r.OwnerTable.QualifiedName() == t.QualifiedName():
+				out <- structField{Name: pqtfmt.Public(or(r.InversedName, r.InversedTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(tableName(r.InversedTable)))}
+			case r.InversedTable.QualifiedName() == t.QualifiedName():
+				out <- structField{Name: pqtfmt.Public(or(r.OwnerName, r.OwnerTable.Name+"s")), Type: fmt.Sprintf("[]*%sEntity", pqtfmt.Public(tableName(r.OwnerTable)))}
 			}
 		}
 
@@ -6,6 +6,9 @@ import (
 )
 
 func (g *Generator) Repository(t *pqt.Table) {
+	if t.Comment != "" {
+		g.Print(docComment(pqtfmt.Public(tableName(t))+"RepositoryBase", t.Comment))
+	}
 	g.Printf(`
 type %sRepositoryBase struct {
 	%s string
@@ -13,7 +16,7 @@ type %sRepositoryBase struct {
 	%s *sql.DB
 	%s LogFunc
 }`,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		pqtfmt.Public("table"),
 		pqtfmt.Public("columns"),
 		pqtfmt.Public("db"),
@@ -24,9 +27,9 @@ type %sRepositoryBase struct {
 func (g *Generator) RepositoryMethodTx(t *pqt.Table) {
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(tx *sql.Tx) (*%sRepositoryBaseTx, error) {`,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		pqtfmt.Public("tx"),
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 	)
 	g.Printf(`
 	return &%sRepositoryBaseTx{
@@ -34,16 +37,16 @@ func (g *Generator) RepositoryMethodTx(t *pqt.Table) {
 		tx: tx,
 	}, nil
 }`,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 	)
 }
 
 func (g *Generator) RepositoryMethodBeginTx(t *pqt.Table) {
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context) (*%sRepositoryBaseTx, error) {`,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		pqtfmt.Public("beginTx"),
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 	)
 	g.Printf(`
 	tx, err := r.%s.BeginTx(ctx, nil)
@@ -68,8 +71,8 @@ func (r %sRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx *%sR
 		return fn(rtx)
 	}, attempts)
 }`,
-		pqtfmt.Public(t.Name),
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
+		pqtfmt.Public(tableName(t)),
 		pqtfmt.Public("db"),
 	)
 }
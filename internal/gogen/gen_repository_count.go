@@ -6,7 +6,7 @@ import (
 )
 
 func (g *Generator) RepositoryMethodCount(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, exp *%sCountExpr) (int64, error) {
@@ -15,7 +15,7 @@ func (g *Generator) RepositoryMethodCount(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodCount(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBaseTx) %s(ctx context.Context, exp *%sCountExpr) (int64, error) {
@@ -24,7 +24,7 @@ func (g *Generator) RepositoryTxMethodCount(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodPrivateCount(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, exp *%sCountExpr) (int64, error) {`, entityName, pqtfmt.Private("count"), entityName)
@@ -3,12 +3,11 @@ package gogen
 import (
 	"github.com/piotrkowalczuk/pqt"
 	"github.com/piotrkowalczuk/pqt/pqtfmt"
-	"github.com/piotrkowalczuk/pqt/pqtgo"
 )
 
 func (g *Generator) RepositoryMethodDeleteOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -18,15 +17,15 @@ func (g *Generator) RepositoryMethodDeleteOneByPrimaryKey(t *pqt.Table) {
 			return r.%s(ctx, nil, pk)
 		}`,
 		entityName,
-		pqtfmt.Public("deleteOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
-		pqtfmt.Private("deleteOneBy", pk.Name),
+		pqtfmt.Public("deleteOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
+		pqtfmt.Private("deleteOneBy", primaryKeyName(pk)),
 	)
 }
 
 func (g *Generator) RepositoryTxMethodDeleteOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -36,15 +35,15 @@ func (g *Generator) RepositoryTxMethodDeleteOneByPrimaryKey(t *pqt.Table) {
 			return r.base.%s(ctx, r.tx, pk)
 		}`,
 		entityName,
-		pqtfmt.Public("deleteOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
-		pqtfmt.Private("deleteOneBy", pk.Name),
+		pqtfmt.Public("deleteOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
+		pqtfmt.Private("deleteOneBy", primaryKeyName(pk)),
 	)
 }
 
 func (g *Generator) RepositoryMethodPrivateDeleteOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -52,21 +51,17 @@ func (g *Generator) RepositoryMethodPrivateDeleteOneByPrimaryKey(t *pqt.Table) {
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, pk %s) (int64, error) {`,
 		entityName,
-		pqtfmt.Private("DeleteOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
+		pqtfmt.Private("DeleteOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
 	)
 	g.Printf(`
 		find := NewComposer(%d)
 		find.WriteString("DELETE FROM ")
 		find.WriteString(%s)
-		find.WriteString(" WHERE ")
-		find.WriteString(%s)
-		find.WriteString("=")
-		find.WritePlaceholder()
-		find.Add(pk)`, len(t.Columns),
-		pqtfmt.Public("table", t.Name),
-		pqtfmt.Public("table", t.Name, "column", pk.Name),
+		find.WriteString(" WHERE ")`, len(t.Columns),
+		pqtfmt.Public("table", tableName(t)),
 	)
+	g.primaryKeyCondition(t, pk, "find")
 
 	g.Printf(`
 		var (
@@ -50,3 +50,51 @@ func (r *T1RepositoryBase) deleteOneByID(ctx context.Context, tx *sql.Tx, pk int
 	return res.RowsAffected()
 }`)
 }
+
+func TestGenerator_RepositoryMethodPrivateDeleteOneByPrimaryKey_composite(t *testing.T) {
+	userID := pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithNotNull())
+	roleID := pqt.NewColumn("role_id", pqt.TypeIntegerBig(), pqt.WithNotNull())
+	t1 := pqt.NewTable("t1").AddColumn(userID).AddColumn(roleID)
+	t1.AddConstraint(pqt.PrimaryKey(t1, userID, roleID))
+
+	g := &gogen.Generator{}
+	g.Reset()
+	g.PrimaryKey(t1)
+	g.RepositoryMethodPrivateDeleteOneByPrimaryKey(t1)
+	testutil.AssertOutput(t, g.Printer, `
+// T1PrimaryKey represents primary key of t1 table.
+type T1PrimaryKey struct {
+	UserID int64
+	RoleID int64
+}
+
+func (r *T1RepositoryBase) deleteOneByPrimaryKey(ctx context.Context, tx *sql.Tx, pk T1PrimaryKey) (int64, error) {
+	find := NewComposer(2)
+	find.WriteString("DELETE FROM ")
+	find.WriteString(TableT1)
+	find.WriteString(" WHERE ")
+	find.WriteString(TableT1ColumnUserID)
+	find.WriteString("=")
+	find.WritePlaceholder()
+	find.Add(pk.UserID)
+	find.WriteString(" AND ")
+	find.WriteString(TableT1ColumnRoleID)
+	find.WriteString("=")
+	find.WritePlaceholder()
+	find.Add(pk.RoleID)
+	var (
+		err error
+		res sql.Result
+	)
+	if tx == nil {
+		res, err = r.DB.ExecContext(ctx, find.String(), find.Args()...)
+	} else {
+		res, err = tx.ExecContext(ctx, find.String(), find.Args()...)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}`)
+}
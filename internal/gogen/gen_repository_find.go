@@ -9,7 +9,7 @@ import (
 )
 
 func (g *Generator) RepositoryMethodFindIter(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, fe *%sFindExpr) (*%sIterator, error) {
@@ -24,7 +24,7 @@ func (g *Generator) RepositoryMethodFindIter(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodFindIter(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBaseTx) %s(ctx context.Context, fe *%sFindExpr) (*%sIterator, error) {
@@ -39,7 +39,7 @@ func (g *Generator) RepositoryTxMethodFindIter(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodPrivateFindIter(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, fe *%sFindExpr) (*%sIterator, error) {`,
@@ -86,11 +86,11 @@ func (g *Generator) RepositoryMethodPrivateFindIter(t *pqt.Table) {
 				expr: fe,
 				cols: fe.Columns,
 		}, nil
-	}`, pqtfmt.Public(t.Name))
+	}`, pqtfmt.Public(tableName(t)))
 }
 
 func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %sQuery(fe *%sFindExpr) (string, []interface{}, error) {`, entityName, pqtfmt.Public("find"), entityName)
@@ -102,8 +102,8 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 	g.selectList(t, 0)
 	g.Printf(`")
 		} else {
-			buf.WriteString(strings.Join(fe.%s, ", "))
-		}`, pqtfmt.Public("columns"))
+			%s
+		}`, writeColumns(t, "buf", "fe."+pqtfmt.Public("columns")))
 	// Generate select clause for joinable tables if needed.
 	for nb, r := range joinableRelationships(t) {
 		joinPropertyName := pqtfmt.Public("join", or(r.InversedName, r.InversedTable.Name))
@@ -121,8 +121,15 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 		g.Print(`")`)
 		closeBrace(g, 1)
 	}
+	g.Print(`
+		buf.WriteString(" FROM ")`)
+	if inherited(t) {
+		g.Printf(`
+		if fe.%s {
+			buf.WriteString("ONLY ")
+		}`, pqtfmt.Public("only"))
+	}
 	g.Printf(`
-		buf.WriteString(" FROM ")
 		buf.WriteString(r.%s)
 		buf.WriteString(" AS t0")`, pqtfmt.Public("table"))
 	// Generate JOIN clause for joinable tables if needed.
@@ -144,7 +151,7 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 			joinClause(comp, fe.%s.%s, "%s AS t%d ON `,
 			joinPropertyName,
 			pqtfmt.Public("kind"),
-			r.InversedTable.FullName(),
+			escaped(r.InversedTable.FullName()),
 			nb+1,
 		)
 
@@ -152,7 +159,7 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 			if i > 0 {
 				g.Print(` AND `)
 			}
-			g.Printf(`t%d.%s=t%d.%s`, 0, oc[i].Name, nb+1, ic[i].Name)
+			g.Printf(`t%d.%s=t%d.%s`, 0, escaped(oc[i].QuotedName()), nb+1, escaped(ic[i].QuotedName()))
 		}
 		g.Print(`")`)
 
@@ -165,7 +172,7 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 		}`,
 			joinPropertyName,
 			pqtfmt.Public("on"),
-			pqtfmt.Public(r.InversedTable.Name),
+			pqtfmt.Public(tableName(r.InversedTable)),
 			joinPropertyName,
 			pqtfmt.Public("on"),
 			nb+1,
@@ -185,7 +192,7 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 		}
 	}`,
 		pqtfmt.Public("where"),
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		pqtfmt.Public("where"),
 	)
 
@@ -201,7 +208,7 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 			joinPropertyName,
 			joinPropertyName,
 			pqtfmt.Public("where"),
-			pqtfmt.Public(r.InversedTable.Name),
+			pqtfmt.Public(tableName(r.InversedTable)),
 			joinPropertyName,
 			pqtfmt.Public("where"),
 			nb+1,
@@ -217,6 +224,10 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 		}
 	`)
 
+	orderName := "order.Name"
+	if quotedColumns(t) {
+		orderName = quoteColumnFunc(t) + "(order.Name)"
+	}
 	g.Printf(`
 	if len(fe.%s) > 0 {
 		i:=0
@@ -231,7 +242,7 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 							return "", nil, err
 						}
 					}
-					if _, err := comp.WriteString(order.Name); err != nil {
+					if _, err := comp.WriteString(%s); err != nil {
 						return "", nil, err
 					}
 					if order.Descending {
@@ -272,7 +283,8 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 `,
 		pqtfmt.Public("orderBy"),
 		pqtfmt.Public("orderBy"),
-		pqtfmt.Public("table", t.Name, "columns"),
+		pqtfmt.Public("table", tableName(t), "columns"),
+		orderName,
 		pqtfmt.Public("offset"),
 		pqtfmt.Public("offset"),
 		pqtfmt.Public("limit"),
@@ -287,8 +299,8 @@ func (g *Generator) RepositoryMethodFindQuery(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodFindOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -298,16 +310,16 @@ func (g *Generator) RepositoryMethodFindOneByPrimaryKey(t *pqt.Table) {
 			return r.%s(ctx, nil, pk)
 		}`,
 		entityName,
-		pqtfmt.Public("findOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
+		pqtfmt.Public("findOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
 		entityName,
-		pqtfmt.Private("findOneBy", pk.Name),
+		pqtfmt.Private("findOneBy", primaryKeyName(pk)),
 	)
 }
 
 func (g *Generator) RepositoryTxMethodFindOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -317,16 +329,16 @@ func (g *Generator) RepositoryTxMethodFindOneByPrimaryKey(t *pqt.Table) {
 			return r.base.%s(ctx, r.tx, pk)
 		}`,
 		entityName,
-		pqtfmt.Public("findOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
+		pqtfmt.Public("findOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
 		entityName,
-		pqtfmt.Private("findOneBy", pk.Name),
+		pqtfmt.Private("findOneBy", primaryKeyName(pk)),
 	)
 }
 
 func (g *Generator) RepositoryMethodPrivateFindOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -334,8 +346,8 @@ func (g *Generator) RepositoryMethodPrivateFindOneByPrimaryKey(t *pqt.Table) {
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, pk %s) (*%sEntity, error) {`,
 		entityName,
-		pqtfmt.Private("findOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
+		pqtfmt.Private("findOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
 		entityName,
 	)
 	g.Printf(`
@@ -347,22 +359,20 @@ func (g *Generator) RepositoryMethodPrivateFindOneByPrimaryKey(t *pqt.Table) {
 	g.selectList(t, -1)
 	g.Printf(`")
 		} else {
-			find.WriteString(strings.Join(r.%s, ", "))
-		}`, pqtfmt.Public("columns"))
+			%s
+		}`, writeColumns(t, "find", "r."+pqtfmt.Public("columns")))
 
 	g.Printf(`
 		find.WriteString(" FROM ")
 		find.WriteString(%s)
-		find.WriteString(" WHERE ")
-		find.WriteString(%s)
-		find.WriteString("=")
-		find.WritePlaceholder()
-		find.Add(pk)
+		find.WriteString(" WHERE ")`,
+		pqtfmt.Public("table", tableName(t)),
+	)
+	g.primaryKeyCondition(t, pk, "find")
+	g.Printf(`
 		var (
 			ent %sEntity
 		)`,
-		pqtfmt.Public("table", t.Name),
-		pqtfmt.Public("table", t.Name, "column", pk.Name),
 		entityName,
 	)
 
@@ -402,14 +412,14 @@ func (g *Generator) RepositoryMethodPrivateFindOneByPrimaryKey(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodFindOneByPrimaryKeyAndUpdate(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
 
 	g.Printf(`
-		func (r *%sRepositoryBase) %s(ctx context.Context, pk %s, p *%sPatch) (before, after *%sEntity, err error) {`, entityName, pqtfmt.Public("findOneBy", pk.Name, "AndUpdate"), g.columnType(pk, pqtgo.ModeMandatory), entityName, entityName)
+		func (r *%sRepositoryBase) %s(ctx context.Context, pk %s, p *%sPatch) (before, after *%sEntity, err error) {`, entityName, pqtfmt.Public("findOneBy", primaryKeyName(pk), "AndUpdate"), g.primaryKeyType(t, pk), entityName, entityName)
 
 	g.Printf(`
 		find := NewComposer(%d)
@@ -420,26 +430,23 @@ func (g *Generator) RepositoryMethodFindOneByPrimaryKeyAndUpdate(t *pqt.Table) {
 	g.selectList(t, -1)
 	g.Printf(`")
 		} else {
-			find.WriteString(strings.Join(r.%s, ", "))
-		}`, pqtfmt.Public("columns"))
+			%s
+		}`, writeColumns(t, "find", "r."+pqtfmt.Public("columns")))
 
 	g.Printf(`
 		find.WriteString(" FROM ")
 		find.WriteString(%s)
-		find.WriteString(" WHERE ")
-		find.WriteString(%s)
-		find.WriteString("=")
-		find.WritePlaceholder()
-		find.Add(pk)
-		find.WriteString(" FOR UPDATE")`,
-		pqtfmt.Public("table", t.Name),
-		pqtfmt.Public("table", t.Name, "column", pk.Name),
+		find.WriteString(" WHERE ")`,
+		pqtfmt.Public("table", tableName(t)),
 	)
+	g.primaryKeyCondition(t, pk, "find")
+	g.Print(`
+		find.WriteString(" FOR UPDATE")`)
 	g.Printf(`
 		query, args, err := r.%sQuery(pk, p)
 		if err != nil {
 			return
-		}`, pqtfmt.Public("updateOneBy", pk.Name))
+		}`, pqtfmt.Public("updateOneBy", primaryKeyName(pk)))
 
 	g.Printf(`
 		var (
@@ -505,7 +512,7 @@ func (g *Generator) RepositoryMethodFindOneByPrimaryKeyAndUpdate(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodFind(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, fe *%sFindExpr) ([]*%sEntity, error) {
@@ -520,7 +527,7 @@ func (g *Generator) RepositoryMethodFind(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodFind(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBaseTx) %s(ctx context.Context, fe *%sFindExpr) ([]*%sEntity, error) {
@@ -535,7 +542,7 @@ func (g *Generator) RepositoryTxMethodFind(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodPrivateFind(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, fe *%sFindExpr) ([]*%sEntity, error) {`,
@@ -589,7 +596,7 @@ func (g *Generator) RepositoryMethodPrivateFind(t *pqt.Table) {
 				return nil, err
 			}`,
 		entityName,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 		pqtfmt.Public("props"),
 	)
 	if hasJoinableRelationships(t) {
@@ -622,7 +629,7 @@ func (g *Generator) RepositoryMethodPrivateFind(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodFindOneByUniqueConstraint(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	for _, u := range uniqueConstraints(t) {
 		method := []string{pqtfmt.Public("findOneBy")}
@@ -660,7 +667,7 @@ func (g *Generator) RepositoryMethodFindOneByUniqueConstraint(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodFindOneByUniqueConstraint(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	for _, u := range uniqueConstraints(t) {
 		method := []string{pqtfmt.Public("findOneBy")}
@@ -698,7 +705,7 @@ func (g *Generator) RepositoryTxMethodFindOneByUniqueConstraint(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodPrivateFindOneByUniqueConstraint(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	for i, u := range uniqueConstraints(t) {
 		if i > 0 {
@@ -737,8 +744,8 @@ func (g *Generator) RepositoryMethodPrivateFindOneByUniqueConstraint(t *pqt.Tabl
 		g.selectList(t, -1)
 		g.Printf(`")
 		} else {
-			find.WriteString(strings.Join(r.%s, ", "))
-		}`, pqtfmt.Public("columns"))
+			%s
+		}`, writeColumns(t, "find", "r."+pqtfmt.Public("columns")))
 
 		partialClause := ""
 		if len(u.Where) > 0 {
@@ -749,7 +756,7 @@ func (g *Generator) RepositoryMethodPrivateFindOneByUniqueConstraint(t *pqt.Tabl
 			find.WriteString(" FROM ")
 			find.WriteString(%s)
 			find.WriteString(" WHERE %s")`,
-			pqtfmt.Public("table", t.Name),
+			pqtfmt.Public("table", tableName(t)),
 			partialClause,
 		)
 		for i, c := range u.PrimaryColumns {
@@ -761,7 +768,7 @@ func (g *Generator) RepositoryMethodPrivateFindOneByUniqueConstraint(t *pqt.Tabl
 		find.WriteString("=")
 		find.WritePlaceholder()
 		find.Add(%s)
-		`, pqtfmt.Public("table", t.Name, "column", c.Name), pqtfmt.Private(columnForeignName(c)))
+		`, columnIdentifier(c), pqtfmt.Private(columnForeignName(c)))
 		}
 
 		g.Printf(`
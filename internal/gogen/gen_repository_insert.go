@@ -6,7 +6,7 @@ import (
 )
 
 func (g *Generator) RepositoryMethodInsert(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, e *%sEntity) (*%sEntity, error) {`, entityName, pqtfmt.Public("insert"), entityName, entityName)
@@ -18,7 +18,7 @@ func (g *Generator) RepositoryMethodInsert(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodInsert(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBaseTx) %s(ctx context.Context, e *%sEntity) (*%sEntity, error) {`, entityName, pqtfmt.Public("insert"), entityName, entityName)
@@ -30,7 +30,7 @@ func (g *Generator) RepositoryTxMethodInsert(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodPrivateInsert(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, e *%sEntity) (*%sEntity, error) {`, entityName, pqtfmt.Private("insert"), entityName, entityName)
@@ -78,7 +78,7 @@ func (g *Generator) RepositoryMethodPrivateInsert(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodInsertQuery(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %sQuery(e *%sEntity, read bool) (string, []interface{}, error) {`, entityName, pqtfmt.Public("insert"), entityName)
@@ -90,7 +90,7 @@ func (g *Generator) RepositoryMethodInsertQuery(t *pqt.Table) {
 	`, len(t.Columns), pqtfmt.Public("table"))
 
 	for _, c := range t.Columns {
-		if c.IsDynamic {
+		if c.IsReadOnly() {
 			continue
 		}
 		g.generateRepositoryInsertClause(c, "insert")
@@ -106,10 +106,10 @@ func (g *Generator) RepositoryMethodInsertQuery(t *pqt.Table) {
 			if read {
 				buf.WriteString("RETURNING ")
 				if len(r.%s) > 0 {
-					buf.WriteString(strings.Join(r.%s, ", "))
+					%s
 				} else {`,
 		pqtfmt.Public("columns"),
-		pqtfmt.Public("columns"),
+		writeColumns(t, "buf", "r."+pqtfmt.Public("columns")),
 	)
 	g.Print(`
 		buf.WriteString("`)
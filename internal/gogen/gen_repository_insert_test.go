@@ -69,6 +69,8 @@ func TestGenerator_RepositoryInsertQuery(t *testing.T) {
 		}, name)).
 		AddColumn(pqt.NewColumn("age", pqt.TypeInteger())).
 		AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())).
+		AddColumn(pqt.NewColumn("number", pqt.TypeInteger(), pqt.WithIdentity(pqt.IdentityAlways))).
+		AddColumn(pqt.NewColumn("title", pqt.TypeText(), pqt.WithGenerated("upper(name)"))).
 		AddColumn(name).
 		AddColumn(description).
 		AddUnique(name, description).
@@ -91,7 +93,7 @@ type T2RepositoryBase struct {
 }
 
 func (r *T2RepositoryBase) InsertQuery(e *T2Entity, read bool) (string, []interface{}, error) {
-	insert := NewComposer(7)
+	insert := NewComposer(9)
 	columns := bytes.NewBuffer(nil)
 	buf := bytes.NewBufferString("INSERT INTO ")
 	buf.WriteString(r.Table)
@@ -210,7 +212,76 @@ func (r *T2RepositoryBase) InsertQuery(e *T2Entity, read bool) (string, []interf
 			if len(r.Columns) > 0 {
 				buf.WriteString(strings.Join(r.Columns, ", "))
 			} else {
-				buf.WriteString("age, created_at, description, id, name, slugify() AS slug, t1_id")
+				buf.WriteString("age, created_at, description, id, name, number, constraints_test.slugify() AS slug, t1_id, title")
+			}
+		}
+	}
+	return buf.String(), insert.Args(), nil
+}`)
+}
+
+func TestGenerator_RepositoryInsertQuery_quoted(t *testing.T) {
+	t1 := pqt.NewTable("t1").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("createdAt", pqt.TypeTimestampTZ()))
+	pqt.NewSchema("quoted_test").AddTable(t1)
+
+	g := &gogen.Generator{}
+	g.Repository(t1) // Is here so output can be properly formatted
+	g.RepositoryMethodInsertQuery(t1)
+
+	testutil.AssertOutput(t, g.Printer, `
+type T1RepositoryBase struct {
+	Table   string
+	Columns []string
+	DB      *sql.DB
+	Log     LogFunc
+}
+
+func (r *T1RepositoryBase) InsertQuery(e *T1Entity, read bool) (string, []interface{}, error) {
+	insert := NewComposer(2)
+	columns := bytes.NewBuffer(nil)
+	buf := bytes.NewBufferString("INSERT INTO ")
+	buf.WriteString(r.Table)
+
+	if e.CreatedAt.Valid {
+		if columns.Len() > 0 {
+			if _, err := columns.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if _, err := columns.WriteString("\"createdAt\""); err != nil {
+			return "", nil, err
+		}
+		if insert.Dirty {
+			if _, err := insert.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := insert.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		insert.Add(e.CreatedAt)
+		insert.Dirty = true
+	}
+
+	if columns.Len() > 0 {
+		buf.WriteString(" (")
+		buf.ReadFrom(columns)
+		buf.WriteString(") VALUES (")
+		buf.ReadFrom(insert)
+		buf.WriteString(") ")
+		if read {
+			buf.WriteString("RETURNING ")
+			if len(r.Columns) > 0 {
+				for i, cn := range r.Columns {
+					if i != 0 {
+						buf.WriteString(", ")
+					}
+					buf.WriteString(quoteT1Column(cn))
+				}
+			} else {
+				buf.WriteString("\"createdAt\", id")
 			}
 		}
 	}
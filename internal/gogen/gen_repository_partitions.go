@@ -0,0 +1,124 @@
+package gogen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtfmt"
+)
+
+func (g *Generator) RepositoryMethodCreatePartitions(t *pqt.Table) {
+	if !timePartitioned(t) {
+		return
+	}
+	entityName := pqtfmt.Public(tableName(t))
+
+	g.Printf(`
+		// %s creates n consecutive partitions starting at since, each of them covers period given as years, months and days.
+		// Partition name is the table name followed by the date its range starts at. Existing partitions are left untouched.
+		func (r *%sRepositoryBase) %s(ctx context.Context, since time.Time, n, years, months, days int) error {
+			return r.%s(ctx, nil, since, n, years, months, days)
+		}`, pqtfmt.Public("createPartitions"), entityName, pqtfmt.Public("createPartitions"), pqtfmt.Private("createPartitions"))
+}
+
+func (g *Generator) RepositoryTxMethodCreatePartitions(t *pqt.Table) {
+	if !timePartitioned(t) {
+		return
+	}
+	entityName := pqtfmt.Public(tableName(t))
+
+	g.Printf(`
+		func (r *%sRepositoryBaseTx) %s(ctx context.Context, since time.Time, n, years, months, days int) error {
+			return r.base.%s(ctx, r.tx, since, n, years, months, days)
+		}`, entityName, pqtfmt.Public("createPartitions"), pqtfmt.Private("createPartitions"))
+}
+
+func (g *Generator) RepositoryMethodPrivateCreatePartitions(t *pqt.Table) {
+	if !timePartitioned(t) {
+		return
+	}
+	entityName := pqtfmt.Public(tableName(t))
+
+	g.Printf(`
+		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, since time.Time, n, years, months, days int) error {
+			if years == 0 && months == 0 && days == 0 {
+				return errors.New("partition period is empty")
+			}
+			const layout = "2006-01-02 15:04:05.999999999Z07:00"
+
+			from := since
+			for i := 0; i < n; i++ {
+				to := from.AddDate(years, months, days)
+				query := "CREATE TABLE IF NOT EXISTS " + %s +
+					" PARTITION OF " + r.%s +
+					" FOR VALUES FROM ('" + from.Format(layout) + "') TO ('" + to.Format(layout) + "')"
+
+				var err error
+				if tx == nil {
+					_, err = r.%s.ExecContext(ctx, query)
+				} else {
+					_, err = tx.ExecContext(ctx, query)
+				}
+				if r.%s != nil {
+					if tx == nil {
+						r.%s(err, %s, "create partitions", query)
+					} else {
+						r.%s(err, %s, "create partitions tx", query)
+					}
+				}
+				if err != nil {
+					return err
+				}
+				from = to
+			}
+			return nil
+		}`,
+		entityName,
+		pqtfmt.Private("createPartitions"),
+		partitionName(t),
+		pqtfmt.Public("table"),
+		pqtfmt.Public("db"),
+		pqtfmt.Public("log"),
+		pqtfmt.Public("log"),
+		pqtfmt.Public("table", tableName(t)),
+		pqtfmt.Public("log"),
+		pqtfmt.Public("table", tableName(t)),
+	)
+}
+
+// partitionName returns expression that evaluates to the name of the partition that starts at from.
+// Partitions live in the schema of the table and are named after it followed by the date,
+// the name is built from the unquoted parts and then quoted as a whole.
+func partitionName(t *pqt.Table) string {
+	const placeholder = "20060102"
+
+	name := t.Schema.Quote(t.Name + "_" + placeholder)
+	i := strings.LastIndex(name, placeholder)
+	prefix, suffix := name[:i], name[i+len(placeholder):]
+	if t.Schema != nil && t.Schema.Name != "" {
+		prefix = t.Schema.QuotedName() + "." + prefix
+	}
+	expr := fmt.Sprintf("%q + from.Format(%q)", prefix, placeholder)
+	if suffix != "" {
+		expr += fmt.Sprintf(" + %q", suffix)
+	}
+	return expr
+}
+
+// timePartitioned returns true if table is partitioned by range of a single date or timestamp column.
+func timePartitioned(t *pqt.Table) bool {
+	if t.PartitionBy != pqt.PartitionByRange || len(t.PartitionKey) != 1 {
+		return false
+	}
+	typ := t.PartitionKey[0].Type
+	if mt, ok := typ.(pqt.MappableType); ok {
+		typ = mt.From
+	}
+	switch typ {
+	case pqt.TypeTimestamp(), pqt.TypeTimestampTZ(), pqt.TypeDate():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package gogen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/internal/gogen"
+	"github.com/piotrkowalczuk/pqt/internal/testutil"
+)
+
+func TestGenerator_RepositoryMethodPrivateCreatePartitions(t *testing.T) {
+	createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+	t1 := pqt.NewTable("t1", pqt.WithPartitionBy(pqt.PartitionByRange, createdAt)).
+		AddColumn(createdAt)
+
+	g := &gogen.Generator{}
+	g.Reset()
+	g.RepositoryTx(t1)
+	g.NewLine()
+	g.RepositoryMethodPrivateCreatePartitions(t1)
+	g.NewLine()
+	g.RepositoryMethodCreatePartitions(t1)
+	g.NewLine()
+	g.RepositoryTxMethodCreatePartitions(t1)
+	testutil.AssertOutput(t, g.Printer, `
+type T1RepositoryBaseTx struct {
+	base *T1RepositoryBase
+	tx   *sql.Tx
+}
+
+func (r *T1RepositoryBase) createPartitions(ctx context.Context, tx *sql.Tx, since time.Time, n, years, months, days int) error {
+	if years == 0 && months == 0 && days == 0 {
+		return errors.New("partition period is empty")
+	}
+	const layout = "2006-01-02 15:04:05.999999999Z07:00"
+
+	from := since
+	for i := 0; i < n; i++ {
+		to := from.AddDate(years, months, days)
+		query := "CREATE TABLE IF NOT EXISTS " + "t1_" + from.Format("20060102") +
+			" PARTITION OF " + r.Table +
+			" FOR VALUES FROM ('" + from.Format(layout) + "') TO ('" + to.Format(layout) + "')"
+
+		var err error
+		if tx == nil {
+			_, err = r.DB.ExecContext(ctx, query)
+		} else {
+			_, err = tx.ExecContext(ctx, query)
+		}
+		if r.Log != nil {
+			if tx == nil {
+				r.Log(err, TableT1, "create partitions", query)
+			} else {
+				r.Log(err, TableT1, "create partitions tx", query)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		from = to
+	}
+	return nil
+}
+
+// CreatePartitions creates n consecutive partitions starting at since, each of them covers period given as years, months and days.
+// Partition name is the table name followed by the date its range starts at. Existing partitions are left untouched.
+func (r *T1RepositoryBase) CreatePartitions(ctx context.Context, since time.Time, n, years, months, days int) error {
+	return r.createPartitions(ctx, nil, since, n, years, months, days)
+}
+
+func (r *T1RepositoryBaseTx) CreatePartitions(ctx context.Context, since time.Time, n, years, months, days int) error {
+	return r.base.createPartitions(ctx, r.tx, since, n, years, months, days)
+}`)
+}
+
+func TestGenerator_RepositoryMethodPrivateCreatePartitions_quoted(t *testing.T) {
+	cases := map[string]struct {
+		schema   *pqt.Schema
+		table    string
+		expected string
+	}{
+		"keyword": {
+			schema:   pqt.NewSchema("example"),
+			table:    "user",
+			expected: `"CREATE TABLE IF NOT EXISTS " + "example.user_" + from.Format("20060102") +`,
+		},
+		"upper-case": {
+			schema:   pqt.NewSchema("example"),
+			table:    "Event",
+			expected: `"CREATE TABLE IF NOT EXISTS " + "example.\"Event_" + from.Format("20060102") + "\"" +`,
+		},
+		"quote-always": {
+			schema:   pqt.NewSchema("Example", pqt.WithQuotingPolicy(pqt.QuoteAlways)),
+			table:    "event",
+			expected: `"CREATE TABLE IF NOT EXISTS " + "\"Example\".\"event_" + from.Format("20060102") + "\"" +`,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+			t1 := pqt.NewTable(c.table, pqt.WithPartitionBy(pqt.PartitionByRange, createdAt)).
+				AddColumn(createdAt)
+			c.schema.AddTable(t1)
+
+			g := &gogen.Generator{}
+			g.Reset()
+			g.RepositoryMethodPrivateCreatePartitions(t1)
+			if !strings.Contains(g.String(), c.expected) {
+				t.Errorf("partition name not found, expected:\n%s\nin:\n%s", c.expected, g.String())
+			}
+		})
+	}
+}
+
+func TestGenerator_RepositoryMethodCreatePartitions_notTimePartitioned(t *testing.T) {
+	region := pqt.NewColumn("region", pqt.TypeText())
+	bucket := pqt.NewColumn("bucket", pqt.TypeInteger())
+	for _, t1 := range []*pqt.Table{
+		pqt.NewTable("t1").AddColumn(region),
+		pqt.NewTable("t1", pqt.WithPartitionBy(pqt.PartitionByList, region)).AddColumn(region),
+		pqt.NewTable("t1", pqt.WithPartitionBy(pqt.PartitionByRange, bucket)).AddColumn(bucket),
+	} {
+		g := &gogen.Generator{}
+		g.Reset()
+		g.RepositoryMethodPrivateCreatePartitions(t1)
+		g.RepositoryMethodCreatePartitions(t1)
+		g.RepositoryTxMethodCreatePartitions(t1)
+		if g.Len() != 0 {
+			t.Errorf("unexpected output for table that is not partitioned by time:\n%s", g.String())
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package gogen
+
+import (
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtfmt"
+)
+
+func (g *Generator) RepositoryMethodRefresh(v *pqt.View) {
+	if !v.Materialized {
+		return
+	}
+	entityName := pqtfmt.Public(viewName(v))
+
+	g.Printf(`
+		// %s refreshes content of the materialized view.
+		// Concurrent refresh does not lock out reads, but requires a unique index on the view.
+		func (r *%sRepositoryBase) %s(ctx context.Context, concurrently bool) error {
+			return r.%s(ctx, nil, concurrently)
+		}`, pqtfmt.Public("refresh"), entityName, pqtfmt.Public("refresh"), pqtfmt.Private("refresh"))
+}
+
+func (g *Generator) RepositoryTxMethodRefresh(v *pqt.View) {
+	if !v.Materialized {
+		return
+	}
+	entityName := pqtfmt.Public(viewName(v))
+
+	g.Printf(`
+		func (r *%sRepositoryBaseTx) %s(ctx context.Context, concurrently bool) error {
+			return r.base.%s(ctx, r.tx, concurrently)
+		}`, entityName, pqtfmt.Public("refresh"), pqtfmt.Private("refresh"))
+}
+
+func (g *Generator) RepositoryMethodPrivateRefresh(v *pqt.View) {
+	if !v.Materialized {
+		return
+	}
+	entityName := pqtfmt.Public(viewName(v))
+
+	g.Printf(`
+		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, concurrently bool) error {
+			query := "REFRESH MATERIALIZED VIEW "
+			if concurrently {
+				query += "CONCURRENTLY "
+			}
+			query += r.%s
+
+			var err error
+			if tx == nil {
+				_, err = r.%s.ExecContext(ctx, query)
+			} else {
+				_, err = tx.ExecContext(ctx, query)
+			}
+			if r.%s != nil {
+				if tx == nil {
+					r.%s(err, %s, "refresh", query)
+				} else {
+					r.%s(err, %s, "refresh tx", query)
+				}
+			}
+			return err
+		}`,
+		entityName,
+		pqtfmt.Private("refresh"),
+		pqtfmt.Public("table"),
+		pqtfmt.Public("db"),
+		pqtfmt.Public("log"),
+		pqtfmt.Public("log"),
+		pqtfmt.Public("table", viewName(v)),
+		pqtfmt.Public("log"),
+		pqtfmt.Public("table", viewName(v)),
+	)
+}
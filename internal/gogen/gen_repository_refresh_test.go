@@ -0,0 +1,76 @@
+package gogen_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/internal/gogen"
+	"github.com/piotrkowalczuk/pqt/internal/testutil"
+)
+
+func TestGenerator_RepositoryMethodPrivateRefresh(t *testing.T) {
+	v := pqt.NewView("v1", "SELECT 1", pqt.WithMaterialized()).
+		AddColumn(pqt.NewColumn("one", pqt.TypeInteger()))
+
+	g := &gogen.Generator{}
+	g.Reset()
+	g.RepositoryTx(pqt.NewTable("v1"))
+	g.NewLine()
+	g.RepositoryMethodPrivateRefresh(v)
+	g.NewLine()
+	g.RepositoryMethodRefresh(v)
+	g.NewLine()
+	g.RepositoryTxMethodRefresh(v)
+	testutil.AssertOutput(t, g.Printer, `
+type V1RepositoryBaseTx struct {
+	base *V1RepositoryBase
+	tx   *sql.Tx
+}
+
+func (r *V1RepositoryBase) refresh(ctx context.Context, tx *sql.Tx, concurrently bool) error {
+	query := "REFRESH MATERIALIZED VIEW "
+	if concurrently {
+		query += "CONCURRENTLY "
+	}
+	query += r.Table
+
+	var err error
+	if tx == nil {
+		_, err = r.DB.ExecContext(ctx, query)
+	} else {
+		_, err = tx.ExecContext(ctx, query)
+	}
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TableV1, "refresh", query)
+		} else {
+			r.Log(err, TableV1, "refresh tx", query)
+		}
+	}
+	return err
+}
+
+// Refresh refreshes content of the materialized view.
+// Concurrent refresh does not lock out reads, but requires a unique index on the view.
+func (r *V1RepositoryBase) Refresh(ctx context.Context, concurrently bool) error {
+	return r.refresh(ctx, nil, concurrently)
+}
+
+func (r *V1RepositoryBaseTx) Refresh(ctx context.Context, concurrently bool) error {
+	return r.base.refresh(ctx, r.tx, concurrently)
+}`)
+}
+
+func TestGenerator_RepositoryMethodRefresh_notMaterialized(t *testing.T) {
+	v := pqt.NewView("v1", "SELECT 1").
+		AddColumn(pqt.NewColumn("one", pqt.TypeInteger()))
+
+	g := &gogen.Generator{}
+	g.Reset()
+	g.RepositoryMethodPrivateRefresh(v)
+	g.RepositoryMethodRefresh(v)
+	g.RepositoryTxMethodRefresh(v)
+	if g.Len() != 0 {
+		t.Errorf("unexpected output for regular view:\n%s", g.String())
+	}
+}
@@ -6,13 +6,16 @@ import (
 )
 
 func (g *Generator) RepositoryTx(t *pqt.Table) {
+	if t.Comment != "" {
+		g.Print(docComment(pqtfmt.Public(tableName(t))+"RepositoryBaseTx", t.Comment))
+	}
 	g.Printf(`
 type %sRepositoryBaseTx struct {
 	base *%sRepositoryBase
 	tx *sql.Tx
 }`,
-		pqtfmt.Public(t.Name),
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
+		pqtfmt.Public(tableName(t)),
 	)
 }
 
@@ -21,7 +24,7 @@ func (g *Generator) RepositoryTxMethodCommitMethod(t *pqt.Table) {
 func (r %sRepositoryBaseTx) Commit() error {
 	return r.tx.Commit()
 }`,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 	)
 }
 
@@ -30,6 +33,6 @@ func (g *Generator) RepositoryTxMethodRollbackMethod(t *pqt.Table) {
 func (r %sRepositoryBaseTx) Rollback() error {
 	return r.tx.Rollback()
 }`,
-		pqtfmt.Public(t.Name),
+		pqtfmt.Public(tableName(t)),
 	)
 }
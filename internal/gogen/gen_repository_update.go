@@ -9,51 +9,51 @@ import (
 )
 
 func (g *Generator) RepositoryMethodUpdateOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
 
 	g.Printf(`
-		func (r *%sRepositoryBase) %s(ctx context.Context, pk %s, p *%sPatch) (*%sEntity, error) {`, entityName, pqtfmt.Public("updateOneBy", pk.Name), g.columnType(pk, pqtgo.ModeMandatory), entityName, entityName)
+		func (r *%sRepositoryBase) %s(ctx context.Context, pk %s, p *%sPatch) (*%sEntity, error) {`, entityName, pqtfmt.Public("updateOneBy", primaryKeyName(pk)), g.primaryKeyType(t, pk), entityName, entityName)
 	g.Printf(`
 		return r.%s(ctx, nil, pk, p)
 		}`,
-		pqtfmt.Private("updateOneBy", pk.Name),
+		pqtfmt.Private("updateOneBy", primaryKeyName(pk)),
 	)
 }
 
 func (g *Generator) RepositoryTxMethodUpdateOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
 
 	g.Printf(`
-		func (r *%sRepositoryBaseTx) %s(ctx context.Context, pk %s, p *%sPatch) (*%sEntity, error) {`, entityName, pqtfmt.Public("updateOneBy", pk.Name), g.columnType(pk, pqtgo.ModeMandatory), entityName, entityName)
+		func (r *%sRepositoryBaseTx) %s(ctx context.Context, pk %s, p *%sPatch) (*%sEntity, error) {`, entityName, pqtfmt.Public("updateOneBy", primaryKeyName(pk)), g.primaryKeyType(t, pk), entityName, entityName)
 	g.Printf(`
 		return r.base.%s(ctx, r.tx, pk, p)
 		}`,
-		pqtfmt.Private("updateOneBy", pk.Name),
+		pqtfmt.Private("updateOneBy", primaryKeyName(pk)),
 	)
 }
 
 func (g *Generator) RepositoryMethodPrivateUpdateOneByPrimaryKey(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
 
 	g.Printf(`
-		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, pk %s, p *%sPatch) (*%sEntity, error) {`, entityName, pqtfmt.Private("updateOneBy", pk.Name), g.columnType(pk, pqtgo.ModeMandatory), entityName, entityName)
+		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, pk %s, p *%sPatch) (*%sEntity, error) {`, entityName, pqtfmt.Private("updateOneBy", primaryKeyName(pk)), g.primaryKeyType(t, pk), entityName, entityName)
 	g.Printf(`
 		query, args, err := r.%sQuery(pk, p)
 		if err != nil {
 			return nil, err
-		}`, pqtfmt.Public("updateOneBy", pk.Name))
+		}`, pqtfmt.Public("updateOneBy", primaryKeyName(pk)))
 
 	g.Printf(`
 		var ent %sEntity
@@ -94,8 +94,8 @@ func (g *Generator) RepositoryMethodPrivateUpdateOneByPrimaryKey(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodUpdateOneByPrimaryKeyQuery(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
-	pk, ok := t.PrimaryKey()
+	entityName := pqtfmt.Public(tableName(t))
+	pk, ok := primaryKey(t)
 	if !ok {
 		return
 	}
@@ -103,8 +103,8 @@ func (g *Generator) RepositoryMethodUpdateOneByPrimaryKeyQuery(t *pqt.Table) {
 	g.Printf(`
 		func (r *%sRepositoryBase) %sQuery(pk %s, p *%sPatch) (string, []interface{}, error) {`,
 		entityName,
-		pqtfmt.Public("UpdateOneBy", pk.Name),
-		g.columnType(pk, pqtgo.ModeMandatory),
+		pqtfmt.Public("UpdateOneBy", primaryKeyName(pk)),
+		g.primaryKeyType(t, pk),
 		entityName,
 	)
 	g.Printf(`
@@ -123,24 +123,21 @@ func (g *Generator) RepositoryMethodUpdateOneByPrimaryKeyQuery(t *pqt.Table) {
 		return "", nil, errors.New("%s update failure, nothing to update")
 	}`, entityName)
 
-	g.Printf(`
+	g.Print(`
 		buf.WriteString(" SET ")
 		buf.ReadFrom(update)
 		buf.WriteString(" WHERE ")
-
-		update.WriteString(%s)
-		update.WriteString("=")
-		update.WritePlaceholder()
-		update.Add(pk)
+`)
+	g.primaryKeyCondition(t, pk, "update")
+	g.Printf(`
 
 		buf.ReadFrom(update)
 		buf.WriteString(" RETURNING ")
 		if len(r.%s) > 0 {
-			buf.WriteString(strings.Join(r.%s, ", "))
+			%s
 		} else {`,
-		pqtfmt.Public("table", t.Name, "column", pk.Name),
-		pqtfmt.Public("columns"),
 		pqtfmt.Public("columns"),
+		writeColumns(t, "buf", "r."+pqtfmt.Public("columns")),
 	)
 
 	g.Print(`
@@ -154,7 +151,7 @@ func (g *Generator) RepositoryMethodUpdateOneByPrimaryKeyQuery(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryMethodUpdateOneByUniqueConstraintQuery(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	for i, u := range uniqueConstraints(t) {
 		if i > 0 {
@@ -214,7 +211,7 @@ func (g *Generator) RepositoryMethodUpdateOneByUniqueConstraintQuery(t *pqt.Tabl
 				update.WriteString("=")
 				update.WritePlaceholder()
 				update.Add(%s)`,
-				pqtfmt.Public("table", t.Name, "column", c.Name),
+				columnIdentifier(c),
 				pqtfmt.Private(columnForeignName(c)),
 			)
 		}
@@ -222,10 +219,10 @@ func (g *Generator) RepositoryMethodUpdateOneByUniqueConstraintQuery(t *pqt.Tabl
 			buf.ReadFrom(update)
 			buf.WriteString(" RETURNING ")
 			if len(r.%s) > 0 {
-				buf.WriteString(strings.Join(r.%s, ", "))
+				%s
 			} else {`,
 			pqtfmt.Public("columns"),
-			pqtfmt.Public("columns"),
+			writeColumns(t, "buf", "r."+pqtfmt.Public("columns")),
 		)
 
 		g.Print(`
@@ -245,7 +242,7 @@ func (g *Generator) RepositoryMethodUpdateOneByUniqueConstraintQuery(t *pqt.Tabl
 }
 
 func (g *Generator) RepositoryMethodUpdateOneByUniqueConstraint(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 	for i, u := range uniqueConstraints(t) {
 		if i > 0 {
 			g.NewLine()
@@ -285,7 +282,7 @@ func (g *Generator) RepositoryMethodUpdateOneByUniqueConstraint(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodUpdateOneByUniqueConstraint(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 	for i, u := range uniqueConstraints(t) {
 		if i > 0 {
 			g.NewLine()
@@ -325,7 +322,7 @@ func (g *Generator) RepositoryTxMethodUpdateOneByUniqueConstraint(t *pqt.Table)
 }
 
 func (g *Generator) RepositoryMethodPrivateUpdateOneByUniqueConstraint(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 	for i, u := range uniqueConstraints(t) {
 		if i > 0 {
 			g.NewLine()
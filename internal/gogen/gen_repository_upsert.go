@@ -6,7 +6,7 @@ import (
 )
 
 func (g *Generator) RepositoryMethodUpsert(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, e *%sEntity, p *%sPatch, inf ...string) (*%sEntity, error) {
@@ -22,7 +22,7 @@ func (g *Generator) RepositoryMethodUpsert(t *pqt.Table) {
 }
 
 func (g *Generator) RepositoryTxMethodUpsert(t *pqt.Table) {
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBaseTx) %s(ctx context.Context, e *%sEntity, p *%sPatch, inf ...string) (*%sEntity, error) {
@@ -42,7 +42,7 @@ func (g *Generator) RepositoryMethodPrivateUpsert(t *pqt.Table) {
 		return
 	}
 
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %s(ctx context.Context, tx *sql.Tx, e *%sEntity, p *%sPatch, inf ...string) (*%sEntity, error) {`,
@@ -100,7 +100,7 @@ func (g *Generator) RepositoryMethodUpsertQuery(t *pqt.Table) {
 		return
 	}
 
-	entityName := pqtfmt.Public(t.Name)
+	entityName := pqtfmt.Public(tableName(t))
 
 	g.Printf(`
 		func (r *%sRepositoryBase) %sQuery(e *%sEntity, p *%sPatch, inf ...string) (string, []interface{}, error) {`,
@@ -117,7 +117,7 @@ func (g *Generator) RepositoryMethodUpsertQuery(t *pqt.Table) {
 	`, len(t.Columns)*2, pqtfmt.Public("table"))
 
 	for _, c := range t.Columns {
-		if c.IsDynamic {
+		if c.IsReadOnly() {
 			continue
 		}
 		g.generateRepositoryInsertClause(c, "upsert")
@@ -138,7 +138,7 @@ func (g *Generator) RepositoryMethodUpsertQuery(t *pqt.Table) {
 		if len(inf) > 0 {
 		upsert.Dirty=false`)
 	for _, c := range t.Columns {
-		if c.IsDynamic {
+		if c.IsReadOnly() {
 			continue
 		}
 		g.generateRepositorySetClause(c, "upsert")
@@ -163,10 +163,10 @@ func (g *Generator) RepositoryMethodUpsertQuery(t *pqt.Table) {
 		if upsert.Dirty {
 			buf.WriteString(" RETURNING ")
 			if len(r.%s) > 0 {
-				buf.WriteString(strings.Join(r.%s, ", "))
+				%s
 			} else {`,
 		pqtfmt.Public("columns"),
-		pqtfmt.Public("columns"),
+		writeColumns(t, "buf", "r."+pqtfmt.Public("columns")),
 	)
 	g.Print(`
 		buf.WriteString("`)
@@ -369,7 +369,7 @@ func (r *T2RepositoryBase) UpsertQuery(e *T2Entity, p *T2Patch, inf ...string) (
 		if len(r.Columns) > 0 {
 			buf.WriteString(strings.Join(r.Columns, ", "))
 		} else {
-			buf.WriteString("age, created_at, description, id, name, slugify() AS slug, t1_id")
+			buf.WriteString("age, created_at, description, id, name, constraints_test.slugify() AS slug, t1_id")
 		}
 	}
 	return buf.String(), upsert.Args(), nil
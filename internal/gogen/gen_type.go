@@ -0,0 +1,362 @@
+package gogen
+
+import (
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtfmt"
+	"github.com/piotrkowalczuk/pqt/pqtgo"
+)
+
+// Enum generates named string type, its nullable counterpart and constants for each value of the enumerated type.
+func (g *Generator) Enum(t pqt.EnumeratedType) {
+	name := pqtfmt.EnumeratedType(t)
+
+	g.Printf(`
+// %s represents values of %s enumerated type.
+type %s string
+
+const (`, name, t.String(), name)
+	for _, e := range t.Enums {
+		g.Printf(`
+	%s %s = %q`, pqtfmt.EnumeratedValue(t, e), name, e)
+	}
+	g.Printf(`
+)
+
+// Valid returns true if value is one of the %s values.
+func (e %s) Valid() bool {
+	switch e {
+	case `, name, name)
+	for i, e := range t.Enums {
+		if i != 0 {
+			g.Print(", ")
+		}
+		g.Print(pqtfmt.EnumeratedValue(t, e))
+	}
+	g.Printf(`:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *%s) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*e = %s(v)
+	case string:
+		*e = %s(v)
+	default:
+		return fmt.Errorf("%s: unsupported source type %%T", src)
+	}
+	if !e.Valid() {
+		return fmt.Errorf("%s: invalid value %%q", *e)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (e %s) Value() (driver.Value, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("%s: invalid value %%q", e)
+	}
+	return string(e), nil
+}
+
+// Null%s represents a %s that may be null.
+type Null%s struct {
+	%s %s
+	Valid bool
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *Null%s) Scan(src interface{}) error {
+	if src == nil {
+		n.%s, n.Valid = "", false
+		return nil
+	}
+	n.Valid = true
+	return n.%s.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n Null%s) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.%s.Value()
+}`,
+		name, name, name, name, name,
+		name, name,
+		name, name, name, name, name,
+		name, name, name,
+		name, name,
+	)
+}
+
+// Composite generates struct that represents the composite type.
+// It implements sql.Scanner and driver.Valuer interfaces using row literal encoding.
+// It relies on functions generated by CompositeHelpers.
+func (g *Generator) Composite(t pqt.CompositeType) {
+	name := pqtfmt.CompositeType(t)
+
+	g.Printf(`
+// %s represents values of %s composite type.
+type %s struct {`, name, t.String(), name)
+	for _, a := range t.Attributes {
+		g.Printf(`
+	%s %s`, pqtfmt.Public(a.Name), attributeType(a))
+	}
+	g.Printf(`
+}
+
+// Scan implements the sql.Scanner interface.
+func (ct *%s) Scan(src interface{}) error {
+	fields, err := compositeFields(src)
+	if err != nil {
+		return err
+	}
+	if len(fields) != %d {
+		return fmt.Errorf("%s: expected %d fields, got %%d", len(fields))
+	}`, name, len(t.Attributes), name, len(t.Attributes))
+	for i, a := range t.Attributes {
+		g.Printf(`
+	if err := compositeScan(&ct.%s, fields[%d]); err != nil {
+		return fmt.Errorf("%s.%s: %%s", err.Error())
+	}`, pqtfmt.Public(a.Name), i, name, pqtfmt.Public(a.Name))
+	}
+	g.Printf(`
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ct %s) Value() (driver.Value, error) {
+	return compositeLiteral(`, name)
+	for i, a := range t.Attributes {
+		if i != 0 {
+			g.Print(", ")
+		}
+		if a.Type == pqt.TypeBytea() {
+			g.Printf("compositeBytea(ct.%s)", pqtfmt.Public(a.Name))
+			continue
+		}
+		g.Printf("ct.%s", pqtfmt.Public(a.Name))
+	}
+	g.Print(`)
+}`)
+}
+
+func attributeType(a *pqt.Attribute) string {
+	var m int32 = pqtgo.ModeOptional
+	if a.NotNull || a.PrimaryKey {
+		m = pqtgo.ModeMandatory
+	}
+	if t := pqtfmt.Type(a.Type, m); t != "" {
+		return t
+	}
+	return "interface{}"
+}
+
+// CompositeHelpers generates functions that encode and decode composite type row literals.
+func (g *Generator) CompositeHelpers() {
+	code := `
+// compositeFields splits composite type row literal into fields. Nil represents NULL.
+func compositeFields(src interface{}) ([]*string, error) {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return nil, fmt.Errorf("composite: unsupported source type %T", src)
+	}
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("composite: malformed row literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+
+	var (
+		fields           []*string
+		buf              bytes.Buffer
+		quoted, inQuotes bool
+	)
+	field := func() {
+		if !quoted && buf.Len() == 0 {
+			fields = append(fields, nil)
+		} else {
+			f := buf.String()
+			fields = append(fields, &f)
+		}
+		buf.Reset()
+		quoted = false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inQuotes && c == '"' && i+1 < len(s) && s[i+1] == '"':
+			buf.WriteByte('"')
+			i++
+		case c == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case c == '\\' && i+1 < len(s):
+			i++
+			buf.WriteByte(s[i])
+		case c == ',' && !inQuotes:
+			field()
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	field()
+
+	return fields, nil
+}
+
+// compositeScan assigns field of the row literal to the destination.
+func compositeScan(dst interface{}, src *string) error {
+	if nt, ok := dst.(*pq.NullTime); ok {
+		if src == nil {
+			nt.Time, nt.Valid = time.Time{}, false
+			return nil
+		}
+		if err := compositeScan(&nt.Time, src); err != nil {
+			return err
+		}
+		nt.Valid = true
+		return nil
+	}
+	if s, ok := dst.(sql.Scanner); ok {
+		if src == nil {
+			return s.Scan(nil)
+		}
+		return s.Scan(*src)
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("composite: destination needs to be a non nil pointer")
+	}
+	rv = rv.Elem()
+	if src == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+	if rv.Kind() == reflect.Ptr {
+		rv.Set(reflect.New(rv.Type().Elem()))
+		return compositeScan(rv.Interface(), src)
+	}
+
+	switch d := rv.Addr().Interface().(type) {
+	case *time.Time:
+		for _, layout := range []string{
+			"2006-01-02 15:04:05.999999999Z07:00",
+			"2006-01-02 15:04:05.999999999Z07",
+			"2006-01-02 15:04:05.999999999",
+			"2006-01-02",
+			time.RFC3339Nano,
+		} {
+			if t, err := time.Parse(layout, *src); err == nil {
+				*d = t
+				return nil
+			}
+		}
+		return fmt.Errorf("composite: malformed time %q", *src)
+	case *[]byte:
+		if strings.HasPrefix(*src, "\\x") {
+			b, err := hex.DecodeString((*src)[2:])
+			if err != nil {
+				return err
+			}
+			*d = b
+			return nil
+		}
+		*d = []byte(*src)
+		return nil
+	case *interface{}:
+		*d = *src
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(*src)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(*src)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(*src, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(*src, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("composite: unsupported destination type %T", dst)
+	}
+	return nil
+}
+
+// compositeBytea encodes binary data using hex format, so it can be passed as a field of the row literal.
+func compositeBytea(b []byte) interface{} {
+	if b == nil {
+		return nil
+	}
+	return "\\x" + hex.EncodeToString(b)
+}
+
+// compositeLiteral encodes given values as a composite type row literal.
+func compositeLiteral(values ...interface{}) (driver.Value, error) {
+	buf := bytes.NewBufferString("(")
+	for i, v := range values {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		dv, err := driver.DefaultParameterConverter.ConvertValue(v)
+		if err != nil {
+			return nil, err
+		}
+
+		var s string
+		switch x := dv.(type) {
+		case nil:
+			continue
+		case []byte:
+			s = string(x)
+		case string:
+			s = x
+		case int64:
+			s = strconv.FormatInt(x, 10)
+		case float64:
+			s = strconv.FormatFloat(x, 'g', -1, 64)
+		case bool:
+			s = strconv.FormatBool(x)
+		case time.Time:
+			s = x.Format(time.RFC3339Nano)
+		default:
+			return nil, fmt.Errorf("composite: unsupported value type %T", dv)
+		}
+
+		buf.WriteByte('"')
+		for _, r := range s {
+			if r == '"' || r == '\\' {
+				buf.WriteRune('\\')
+			}
+			buf.WriteRune(r)
+		}
+		buf.WriteByte('"')
+	}
+	buf.WriteByte(')')
+
+	return buf.String(), nil
+}`
+	g.Print(code)
+}
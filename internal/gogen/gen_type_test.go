@@ -0,0 +1,123 @@
+package gogen_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/internal/gogen"
+	"github.com/piotrkowalczuk/pqt/internal/testutil"
+)
+
+func TestGenerator_Enum(t *testing.T) {
+	g := &gogen.Generator{}
+	g.Enum(pqt.TypeEnumerated("example.order_status", "new", "in-progress", "DONE"))
+	testutil.AssertOutput(t, g.Printer, `
+// OrderStatus represents values of example.order_status enumerated type.
+type OrderStatus string
+
+const (
+	OrderStatusNew        OrderStatus = "new"
+	OrderStatusInProgress OrderStatus = "in-progress"
+	OrderStatusDone       OrderStatus = "DONE"
+)
+
+// Valid returns true if value is one of the OrderStatus values.
+func (e OrderStatus) Valid() bool {
+	switch e {
+	case OrderStatusNew, OrderStatusInProgress, OrderStatusDone:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan implements the sql.Scanner interface.
+func (e *OrderStatus) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*e = OrderStatus(v)
+	case string:
+		*e = OrderStatus(v)
+	default:
+		return fmt.Errorf("OrderStatus: unsupported source type %T", src)
+	}
+	if !e.Valid() {
+		return fmt.Errorf("OrderStatus: invalid value %q", *e)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (e OrderStatus) Value() (driver.Value, error) {
+	if !e.Valid() {
+		return nil, fmt.Errorf("OrderStatus: invalid value %q", e)
+	}
+	return string(e), nil
+}
+
+// NullOrderStatus represents a OrderStatus that may be null.
+type NullOrderStatus struct {
+	OrderStatus OrderStatus
+	Valid       bool
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NullOrderStatus) Scan(src interface{}) error {
+	if src == nil {
+		n.OrderStatus, n.Valid = "", false
+		return nil
+	}
+	n.Valid = true
+	return n.OrderStatus.Scan(src)
+}
+
+// Value implements the driver.Valuer interface.
+func (n NullOrderStatus) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.OrderStatus.Value()
+}`)
+}
+
+func TestGenerator_Composite(t *testing.T) {
+	g := &gogen.Generator{}
+	g.Composite(pqt.TypeComposite("example.address",
+		&pqt.Attribute{Name: "street", Type: pqt.TypeText(), NotNull: true},
+		&pqt.Attribute{Name: "number", Type: pqt.TypeInteger()},
+		&pqt.Attribute{Name: "photo", Type: pqt.TypeBytea()},
+	))
+	testutil.AssertOutput(t, g.Printer, `
+// Address represents values of example.address composite type.
+type Address struct {
+	Street string
+	Number *int32
+	Photo  []byte
+}
+
+// Scan implements the sql.Scanner interface.
+func (ct *Address) Scan(src interface{}) error {
+	fields, err := compositeFields(src)
+	if err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("Address: expected 3 fields, got %d", len(fields))
+	}
+	if err := compositeScan(&ct.Street, fields[0]); err != nil {
+		return fmt.Errorf("Address.Street: %s", err.Error())
+	}
+	if err := compositeScan(&ct.Number, fields[1]); err != nil {
+		return fmt.Errorf("Address.Number: %s", err.Error())
+	}
+	if err := compositeScan(&ct.Photo, fields[2]); err != nil {
+		return fmt.Errorf("Address.Photo: %s", err.Error())
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (ct Address) Value() (driver.Value, error) {
+	return compositeLiteral(ct.Street, ct.Number, compositeBytea(ct.Photo))
+}`)
+}
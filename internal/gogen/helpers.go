@@ -3,9 +3,9 @@ package gogen
 import (
 	"fmt"
 	"io"
-	"os"
 	"reflect"
-	"runtime/debug"
+	"strconv"
+	"strings"
 
 	"github.com/piotrkowalczuk/pqt"
 	"github.com/piotrkowalczuk/pqt/pqtfmt"
@@ -17,6 +17,7 @@ type structField struct {
 	Type     string
 	Tags     reflect.StructTag
 	ReadOnly bool
+	Comment  string
 }
 
 func closeBrace(w io.Writer, n int) {
@@ -26,6 +27,15 @@ func closeBrace(w io.Writer, n int) {
 	}
 }
 
+// docComment turns database comment into Go doc comment of given identifier.
+// Identifier is prepended, unless the comment already starts with it.
+func docComment(name, comment string) string {
+	if !strings.HasPrefix(comment, name+" ") {
+		comment = name + " " + comment
+	}
+	return "\n// " + strings.Replace(strings.TrimSpace(comment), "\n", "\n// ", -1)
+}
+
 func columnMode(c *pqt.Column, m int32) int32 {
 	switch m {
 	case pqtgo.ModeCriteria:
@@ -35,10 +45,75 @@ func columnMode(c *pqt.Column, m int32) int32 {
 		if c.NotNull || c.PrimaryKey {
 			m = pqtgo.ModeMandatory
 		}
+		if dt, ok := c.Type.(pqt.DomainType); ok && dt.NotNull {
+			m = pqtgo.ModeMandatory
+		}
 	}
 	return m
 }
 
+// underlyingType returns type domains are based on, other types are returned as they are.
+func underlyingType(t pqt.Type) pqt.Type {
+	for {
+		dt, ok := t.(pqt.DomainType)
+		if !ok {
+			return t
+		}
+		t = dt.Base
+	}
+}
+
+// tableName returns name that identifies the table in generated code.
+func tableName(t *pqt.Table) string {
+	return QualifiedName(t.Schema, t.Name)
+}
+
+// viewName returns name that identifies the view in generated code.
+func viewName(v *pqt.View) string {
+	return QualifiedName(v.Schema, v.Name)
+}
+
+// QualifiedName returns name of a schema object that is unique across the database.
+// Objects of a database schema other than the default one are prefixed with the schema name,
+// otherwise the name is returned as is.
+func QualifiedName(s *pqt.Schema, name string) string {
+	if s == nil || s.Database == nil || s.Name == "" || s.Name == pqt.DefaultSchema {
+		return name
+	}
+	return s.Name + "_" + name
+}
+
+// primaryKey returns columns that primary key of the table consists of.
+func primaryKey(t *pqt.Table) (pqt.Columns, bool) {
+	pk := t.PrimaryKeyColumns()
+	return pk, len(pk) > 0
+}
+
+// keyColumn returns true if the column is a part of primary key of its table.
+func keyColumn(c *pqt.Column) bool {
+	if c.PrimaryKey {
+		return true
+	}
+	if c.Table == nil {
+		return false
+	}
+	for _, pk := range c.Table.PrimaryKeyColumns() {
+		if pk == c {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKeyName returns name of the primary key as it is used in names of generated methods.
+// Single column key is named after the column.
+func primaryKeyName(pk pqt.Columns) string {
+	if len(pk) == 1 {
+		return pk[0].Name
+	}
+	return "primaryKey"
+}
+
 func columnForeignName(c *pqt.Column) string {
 	return c.Table.Name + "_" + c.Name
 }
@@ -64,12 +139,35 @@ func hasJoinableRelationships(t *pqt.Table) bool {
 	return len(joinableRelationships(t)) > 0
 }
 
+// inherited returns true if any other table of the schema inherits from given table.
+func inherited(t *pqt.Table) bool {
+	if t.Schema == nil {
+		return false
+	}
+	for _, tt := range t.Schema.Tables {
+		for _, p := range tt.Inherits {
+			if p.QualifiedName() == t.QualifiedName() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func uniqueConstraints(t *pqt.Table) []*pqt.Constraint {
 	var unique []*pqt.Constraint
+ConstraintsLoop:
 	for _, c := range t.Constraints {
-		if c.Type == pqt.ConstraintTypeUnique || c.Type == pqt.ConstraintTypeUniqueIndex {
-			unique = append(unique, c)
+		if c.Type != pqt.ConstraintTypeUnique && c.Type != pqt.ConstraintTypeUniqueIndex {
+			continue
 		}
+		// Expressions cannot be passed as method arguments.
+		for _, e := range c.Elements {
+			if e.Column == nil {
+				continue ConstraintsLoop
+			}
+		}
+		unique = append(unique, c)
 	}
 	if len(unique) < 1 {
 		return nil
@@ -77,18 +175,24 @@ func uniqueConstraints(t *pqt.Table) []*pqt.Constraint {
 	return unique
 }
 
+// computed returns true if value of the column is computed by the database when the row is written.
+// Unlike dynamic columns, such columns are stored.
+func computed(c *pqt.Column) bool {
+	return c.Identity != "" || c.Generated != ""
+}
+
 func sqlSelector(c *pqt.Column, id string) string {
 	if !c.IsDynamic {
-		return pqtfmt.Public("table", c.Table.Name, "column", c.Name)
+		return columnIdentifier(c)
 	}
-	sel := c.Func.Name
+	sel := escaped(functionName(c))
 	sel += "("
 	for i := range c.Func.Args {
 		if i != 0 {
 			sel += ", "
 		}
 		sel += "t%d."
-		sel += c.Columns[i].Name
+		sel += escaped(c.Columns[i].QuotedName())
 	}
 	sel += ")"
 
@@ -100,10 +204,75 @@ func sqlSelector(c *pqt.Column, id string) string {
 	return ret + ")"
 }
 
-func functionName(f *pqt.Function) string {
-	if f.Name == "" {
-		fmt.Println(fmt.Sprintf("function name is missing\n\n%s", debug.Stack()))
-		os.Exit(1)
+// columnIdentifier returns Go expression that evaluates to name of the column as it is written into a query.
+// Exported constants hold plain names, so names that have to be quoted are written as literals instead.
+func columnIdentifier(c *pqt.Column) string {
+	if c.QuotedName() != c.Name {
+		return strconv.Quote(c.QuotedName())
 	}
-	return f.Name
+	return pqtfmt.Public("table", tableName(c.Table), "column", c.Name)
+}
+
+// quotedColumns returns true if any column of the table has to be quoted in a query.
+func quotedColumns(t *pqt.Table) bool {
+	for _, c := range t.Columns {
+		if c.QuotedName() != c.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteColumnFunc returns name of the generated function that quotes column names given at runtime.
+func quoteColumnFunc(t *pqt.Table) string {
+	return pqtfmt.Private("quote", tableName(t), "column")
+}
+
+// writeColumns returns Go statement that writes comma separated column names held by cols into w.
+// Names are passed through the quoting function if any column of the table has to be quoted.
+func writeColumns(t *pqt.Table, w, cols string) string {
+	if !quotedColumns(t) {
+		return fmt.Sprintf(`%s.WriteString(strings.Join(%s, ", "))`, w, cols)
+	}
+	return fmt.Sprintf(`for i, cn := range %s {
+		if i != 0 {
+			%s.WriteString(", ")
+		}
+		%s.WriteString(%s(cn))
+	}`, cols, w, w, quoteColumnFunc(t))
+}
+
+// functionName returns name of the function that computes dynamic column, qualified by the schema that declares it.
+// Built-in functions are resolved through the search path, so their names are left as they are.
+func functionName(c *pqt.Column) string {
+	if c.Func.BuiltIn {
+		return c.Func.Name
+	}
+	s := functionSchema(c.Table.Schema, c.Func)
+	if s == nil || s.Name == "" {
+		return s.Quote(c.Func.Name)
+	}
+	return s.QuotedName() + "." + s.Quote(c.Func.Name)
+}
+
+// functionSchema returns schema that declares given function.
+// If the schema is a part of a database, the function can be declared by any other schema of the database.
+func functionSchema(s *pqt.Schema, f *pqt.Function) *pqt.Schema {
+	if s == nil || s.Database == nil {
+		return s
+	}
+	for _, ds := range s.Database.Schemas {
+		for _, df := range ds.Functions {
+			if df == f {
+				return ds
+			}
+		}
+	}
+	return s
+}
+
+// escaped returns s escaped, so it can be embedded into Go string literal, e.g. quoted identifier.
+func escaped(s string) string {
+	q := strconv.Quote(s)
+	return q[1 : len(q)-1]
 }
@@ -0,0 +1,103 @@
+package pqt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// NamingStrategy decides how constraints and indexes are named.
+// It can be set on a schema using WithNamingStrategy.
+type NamingStrategy interface {
+	// ConstraintName returns name of the given constraint.
+	ConstraintName(c *Constraint) string
+	// ConstraintType returns type of the constraint of given name.
+	// It returns ConstraintTypeUnknown if the type cannot be determined.
+	ConstraintType(name string) ConstraintType
+}
+
+// DefaultNamingStrategy names constraints using schema, table short name, columns and constraint type,
+// e.g. public.user_email_key.
+// Names longer than MaxIdentifierLength are shortened and suffixed with a hash of the full name,
+// so that they are not silently truncated by Postgres and do not collide with each other.
+type DefaultNamingStrategy struct{}
+
+var _ NamingStrategy = DefaultNamingStrategy{}
+
+// ConstraintName implements NamingStrategy interface.
+func (DefaultNamingStrategy) ConstraintName(c *Constraint) string {
+	if c.PrimaryTable == nil {
+		return "<missing table>"
+	}
+
+	schema := DefaultSchema
+	if c.PrimaryTable.Schema != nil && c.PrimaryTable.Schema.Name != "" {
+		schema = c.PrimaryTable.Schema.Name
+	}
+
+	tmp := make([]string, 0, len(c.PrimaryColumns))
+	for _, col := range c.PrimaryColumns {
+		if col.ShortName != "" {
+			tmp = append(tmp, col.ShortName)
+			continue
+		}
+		tmp = append(tmp, col.Name)
+	}
+	var expressions []string
+	for _, e := range c.Elements {
+		if e.Column == nil {
+			expressions = append(expressions, e.Expression)
+		}
+	}
+	if len(expressions) > 0 {
+		tmp = append(tmp, shortHash(strings.Join(expressions, ",")))
+	}
+	if len(tmp) == 0 {
+		return shortenName(fmt.Sprintf("%s.%s_%s", schema, c.PrimaryTable.ShortName, c.Type), c.Type)
+	}
+
+	if len(c.Where) > 0 {
+		tmp = append(tmp, shortHash(c.Where))
+	}
+
+	return shortenName(fmt.Sprintf("%s.%s_%s_%s", schema, c.PrimaryTable.ShortName, strings.Join(tmp, "_"), c.Type), c.Type)
+}
+
+// ConstraintType implements NamingStrategy interface.
+// Type is determined by the suffix that follows the last underscore.
+func (DefaultNamingStrategy) ConstraintType(name string) ConstraintType {
+	switch t := ConstraintType(name[strings.LastIndex(name, "_")+1:]); t {
+	case ConstraintTypePrimaryKey,
+		ConstraintTypeCheck,
+		ConstraintTypeUnique,
+		ConstraintTypeIndex,
+		ConstraintTypeForeignKey,
+		ConstraintTypeExclusion,
+		ConstraintTypeUniqueIndex:
+		return t
+	default:
+		return ConstraintTypeUnknown
+	}
+}
+
+// shortenName cuts name that exceeds MaxIdentifierLength and replaces its tail with a hash of the full name.
+// Constraint type suffix is preserved.
+func shortenName(name string, typ ConstraintType) string {
+	if len(name) <= MaxIdentifierLength {
+		return name
+	}
+	suffix := "_" + shortHash(name) + "_" + string(typ)
+	end := MaxIdentifierLength - len(suffix)
+	for end > 0 && !utf8.RuneStart(name[end]) {
+		end--
+	}
+	return name[:end] + suffix
+}
+
+// namingStrategy returns naming strategy of the schema or the default one.
+func namingStrategy(s *Schema) NamingStrategy {
+	if s == nil || s.NamingStrategy == nil {
+		return DefaultNamingStrategy{}
+	}
+	return s.NamingStrategy
+}
@@ -0,0 +1,104 @@
+package pqt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+type prefixNamingStrategy struct{}
+
+func (prefixNamingStrategy) ConstraintName(c *pqt.Constraint) string {
+	return string(c.Type) + "_" + c.PrimaryTable.Name + "_" + pqt.JoinColumns(c.PrimaryColumns, "_")
+}
+
+func (prefixNamingStrategy) ConstraintType(name string) pqt.ConstraintType {
+	return pqt.ConstraintType(name[:strings.Index(name, "_")])
+}
+
+func TestDefaultNamingStrategy_ConstraintName(t *testing.T) {
+	tbl := pqt.NewTable("subscription")
+	var columns pqt.Columns
+	for _, name := range []string{"organization_id", "workspace_id", "project_id", "environment_id"} {
+		col := pqt.NewColumn(name, pqt.TypeIntegerBig())
+		tbl.AddColumn(col)
+		columns = append(columns, col)
+	}
+
+	ns := pqt.DefaultNamingStrategy{}
+	short := ns.ConstraintName(pqt.Unique(tbl, columns[0]))
+	if short != "public.subscription_organization_id_key" {
+		t.Errorf("wrong name: %s", short)
+	}
+
+	long := ns.ConstraintName(pqt.Unique(tbl, columns...))
+	if len(long) != pqt.MaxIdentifierLength {
+		t.Errorf("name should be cut to %d bytes, got %d: %s", pqt.MaxIdentifierLength, len(long), long)
+	}
+	if !strings.HasPrefix(long, "public.subscription_organization_id_workspace_id_") || !pqt.IsUnique(long) {
+		t.Errorf("wrong name: %s", long)
+	}
+	if again := ns.ConstraintName(pqt.Unique(tbl, columns...)); again != long {
+		t.Errorf("name is not deterministic, %s != %s", long, again)
+	}
+	if other := ns.ConstraintName(pqt.Unique(tbl, columns[0], columns[1], columns[2], columns[2])); other == long {
+		t.Errorf("names collide: %s", other)
+	}
+}
+
+func TestDefaultNamingStrategy_ConstraintType(t *testing.T) {
+	cases := map[string]pqt.ConstraintType{
+		"public.user_id_pkey":         pqt.ConstraintTypePrimaryKey,
+		"public.user_email_key":       pqt.ConstraintTypeUnique,
+		"public.user_group_id_fkey":   pqt.ConstraintTypeForeignKey,
+		"public.user_age_check":       pqt.ConstraintTypeCheck,
+		"public.user_name_idx":        pqt.ConstraintTypeIndex,
+		"public.user_name_uidx":       pqt.ConstraintTypeUniqueIndex,
+		"public.user_period_excl":     pqt.ConstraintTypeExclusion,
+		"public.user_monkey":          pqt.ConstraintTypeUnknown,
+		"user_pk":                     pqt.ConstraintTypeUnknown,
+		"constraint-without-a-suffix": pqt.ConstraintTypeUnknown,
+	}
+
+	ns := pqt.DefaultNamingStrategy{}
+	for name, expected := range cases {
+		if got := ns.ConstraintType(name); got != expected {
+			t.Errorf("%s: wrong type, expected %s but got %s", name, expected, got)
+		}
+	}
+}
+
+func TestWithNamingStrategy(t *testing.T) {
+	id := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	email := pqt.NewColumn("email", pqt.TypeText(), pqt.WithUnique())
+	tbl := pqt.NewTable("user").AddColumn(id).AddColumn(email)
+	s := pqt.NewSchema("example", pqt.WithNamingStrategy(prefixNamingStrategy{})).AddTable(tbl)
+
+	pk := pqt.PrimaryKey(tbl, id)
+	if pk.Name() != "pkey_user_id" {
+		t.Errorf("wrong name: %s", pk.Name())
+	}
+	if !s.IsPrimaryKey(pk.Name()) {
+		t.Errorf("%s should be recognized as a primary key", pk.Name())
+	}
+	// Name that is not declared by the schema is interpreted by its naming strategy.
+	if !s.IsIndex("idx_user_name") || s.IsUnique("idx_user_name") {
+		t.Error("idx_user_name should be recognized as an index")
+	}
+
+	unique := pqt.UniqueOn(tbl, pqt.Columns{email}, pqt.WithConstraintName("user_email_uq"))
+	if unique.Name() != "user_email_uq" {
+		t.Errorf("explicit name should take precedence, got: %s", unique.Name())
+	}
+	if s.IsUnique(unique.Name()) {
+		t.Errorf("%s should not be recognized before it is added to the table", unique.Name())
+	}
+	tbl.AddConstraint(unique)
+	if !s.IsUnique(unique.Name()) {
+		t.Errorf("%s should be recognized as a unique constraint", unique.Name())
+	}
+	if pqt.NewSchema("other").IsUnique(unique.Name()) {
+		t.Errorf("%s should be recognized only by the schema it belongs to", unique.Name())
+	}
+}
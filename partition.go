@@ -0,0 +1,104 @@
+package pqt
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PartitionByRange partitions table into ranges defined by key columns, e.g. time periods.
+	PartitionByRange PartitionStrategy = "RANGE"
+	// PartitionByList partitions table by explicitly listing which key values appear in each partition.
+	PartitionByList PartitionStrategy = "LIST"
+	// PartitionByHash partitions table by specifying a modulus and a remainder for each partition.
+	PartitionByHash PartitionStrategy = "HASH"
+)
+
+// PartitionStrategy is a method of distributing rows of partitioned table.
+type PartitionStrategy string
+
+// Partition describes child table of partitioned table.
+// It shares columns and constraints with its parent, so it is not a part of schema tables.
+type Partition struct {
+	Name string
+	// Bound is a FOR VALUES clause without the keywords, e.g. FROM ('2020-01-01') TO ('2020-02-01').
+	Bound string
+	// Default if true means that partition holds rows that do not fit into any other partition.
+	Default bool
+	// Parent references partitioned table.
+	Parent *Table
+}
+
+// NewPartition allocates new partition using given name and bound.
+func NewPartition(name, bound string) *Partition {
+	return &Partition{
+		Name:  name,
+		Bound: bound,
+	}
+}
+
+// PartitionRange returns partition that holds rows with key values from given lower bound (inclusive) up to upper bound (exclusive).
+// Bounds are SQL expressions, e.g. '2020-01-01' or MINVALUE.
+func PartitionRange(name, from, to string) *Partition {
+	return NewPartition(name, fmt.Sprintf("FROM (%s) TO (%s)", from, to))
+}
+
+// PartitionList returns partition that holds rows with given key values.
+func PartitionList(name string, values ...string) *Partition {
+	return NewPartition(name, fmt.Sprintf("IN (%s)", strings.Join(values, ", ")))
+}
+
+// PartitionHash returns partition that holds rows for which the hash of the key divided by modulus produces given remainder.
+func PartitionHash(name string, modulus, remainder int) *Partition {
+	return NewPartition(name, fmt.Sprintf("WITH (MODULUS %d, REMAINDER %d)", modulus, remainder))
+}
+
+// PartitionDefault returns partition that holds rows that do not fit into any other partition.
+func PartitionDefault(name string) *Partition {
+	return &Partition{
+		Name:    name,
+		Default: true,
+	}
+}
+
+// FullName if schema of the parent table is defined returns name in format <schema>.<name> or just <name> if not set.
+// Both parts are quoted according to quoting policy of the schema.
+func (p *Partition) FullName() string {
+	s := p.schema()
+	if s != nil && s.Name != "" {
+		return s.QuotedName() + "." + s.Quote(p.Name)
+	}
+
+	return s.Quote(p.Name)
+}
+
+// QualifiedName works like FullName, but name is never quoted.
+func (p *Partition) QualifiedName() string {
+	if s := p.schema(); s != nil && s.Name != "" {
+		return s.Name + "." + p.Name
+	}
+
+	return p.Name
+}
+
+func (p *Partition) schema() *Schema {
+	if p.Parent == nil {
+		return nil
+	}
+	return p.Parent.Schema
+}
+
+// AddPartition adds child partition to the partitioned table.
+func (t *Table) AddPartition(p *Partition) *Table {
+	p.Parent = t
+	t.Partitions = append(t.Partitions, p)
+	return t
+}
+
+// WithPartitionBy makes table partitioned using given strategy and key columns.
+func WithPartitionBy(strategy PartitionStrategy, key ...*Column) TableOption {
+	return func(t *Table) {
+		t.PartitionBy = strategy
+		t.PartitionKey = key
+	}
+}
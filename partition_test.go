@@ -0,0 +1,73 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestPartition_bound(t *testing.T) {
+	cases := map[string]struct {
+		given    *pqt.Partition
+		expected string
+	}{
+		"range": {
+			given:    pqt.PartitionRange("p", "'2020-01-01'", "'2020-02-01'"),
+			expected: "FROM ('2020-01-01') TO ('2020-02-01')",
+		},
+		"list": {
+			given:    pqt.PartitionList("p", "'pl'", "'de'"),
+			expected: "IN ('pl', 'de')",
+		},
+		"hash": {
+			given:    pqt.PartitionHash("p", 4, 1),
+			expected: "WITH (MODULUS 4, REMAINDER 1)",
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			if c.given.Bound != c.expected {
+				t.Errorf("wrong bound, expected %s but got %s", c.expected, c.given.Bound)
+			}
+			if c.given.Default {
+				t.Error("partition should not be default")
+			}
+		})
+	}
+}
+
+func TestPartitionDefault(t *testing.T) {
+	p := pqt.PartitionDefault("p")
+	if !p.Default {
+		t.Error("partition should be default")
+	}
+	if p.Bound != "" {
+		t.Errorf("default partition should not have bound, got %s", p.Bound)
+	}
+}
+
+func TestTable_AddPartition(t *testing.T) {
+	key := pqt.NewColumn("created_at", pqt.TypeTimestampTZ())
+	tbl := pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByRange, key)).AddColumn(key)
+	p := pqt.PartitionDefault("event_default")
+	tbl.AddPartition(p)
+
+	if tbl.PartitionBy != pqt.PartitionByRange {
+		t.Errorf("wrong partition strategy: %s", tbl.PartitionBy)
+	}
+	if len(tbl.PartitionKey) != 1 || tbl.PartitionKey[0] != key {
+		t.Errorf("wrong partition key: %v", tbl.PartitionKey)
+	}
+	if len(tbl.Partitions) != 1 || p.Parent != tbl {
+		t.Fatal("partition should be added to the table")
+	}
+	if p.FullName() != "event_default" {
+		t.Errorf("wrong full name: %s", p.FullName())
+	}
+
+	pqt.NewSchema("example").AddTable(tbl)
+	if p.FullName() != "example.event_default" {
+		t.Errorf("wrong full name: %s", p.FullName())
+	}
+}
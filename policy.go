@@ -0,0 +1,87 @@
+package pqt
+
+const (
+	// PolicyCommandAll applies policy to all commands.
+	PolicyCommandAll PolicyCommand = "ALL"
+	// PolicyCommandSelect applies policy to SELECT queries, only USING expression is allowed.
+	PolicyCommandSelect PolicyCommand = "SELECT"
+	// PolicyCommandInsert applies policy to INSERT commands, only WITH CHECK expression is allowed.
+	PolicyCommandInsert PolicyCommand = "INSERT"
+	// PolicyCommandUpdate applies policy to UPDATE commands.
+	PolicyCommandUpdate PolicyCommand = "UPDATE"
+	// PolicyCommandDelete applies policy to DELETE commands, only USING expression is allowed.
+	PolicyCommandDelete PolicyCommand = "DELETE"
+)
+
+// PolicyCommand is a command that row level security policy applies to.
+type PolicyCommand string
+
+// Policy describes row level security policy of a table.
+// It takes effect only if row level security is enabled for the table.
+type Policy struct {
+	Name string
+	// Command is a command the policy applies to, ALL if empty.
+	Command PolicyCommand
+	// Restrictive if true means that policy has to pass together with other restrictive policies,
+	// otherwise it is permissive and at least one of permissive policies has to pass.
+	Restrictive bool
+	// Roles the policy applies to, PUBLIC if empty.
+	Roles []string
+	// Using is an expression that existing rows have to satisfy to be visible or modified.
+	Using string
+	// WithCheck is an expression that new rows have to satisfy.
+	WithCheck string
+	// Table references table that policy is attached to.
+	Table *Table
+}
+
+// NewPolicy allocates new policy using given name and options.
+func NewPolicy(name string, opts ...PolicyOption) *Policy {
+	p := &Policy{
+		Name: name,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// PolicyOption configures how we set up a policy.
+type PolicyOption func(*Policy)
+
+// WithPolicyCommand is policy option that sets command the policy applies to.
+func WithPolicyCommand(cmd PolicyCommand) PolicyOption {
+	return func(p *Policy) {
+		p.Command = cmd
+	}
+}
+
+// WithPolicyRoles is policy option that sets roles the policy applies to.
+func WithPolicyRoles(roles ...string) PolicyOption {
+	return func(p *Policy) {
+		p.Roles = roles
+	}
+}
+
+// WithUsing is policy option that sets USING expression.
+func WithUsing(expr string) PolicyOption {
+	return func(p *Policy) {
+		p.Using = expr
+	}
+}
+
+// WithPolicyCheck is policy option that sets WITH CHECK expression.
+func WithPolicyCheck(expr string) PolicyOption {
+	return func(p *Policy) {
+		p.WithCheck = expr
+	}
+}
+
+// WithRestrictive is policy option that makes policy restrictive.
+func WithRestrictive() PolicyOption {
+	return func(p *Policy) {
+		p.Restrictive = true
+	}
+}
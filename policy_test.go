@@ -0,0 +1,38 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestNewPolicy(t *testing.T) {
+	p := pqt.NewPolicy("owner",
+		pqt.WithPolicyCommand(pqt.PolicyCommandUpdate),
+		pqt.WithPolicyRoles("app", "admin"),
+		pqt.WithUsing("owner = current_user"),
+		pqt.WithPolicyCheck("owner = current_user"),
+		pqt.WithRestrictive(),
+	)
+
+	if p.Command != pqt.PolicyCommandUpdate {
+		t.Errorf("wrong command: %s", p.Command)
+	}
+	if len(p.Roles) != 2 {
+		t.Errorf("wrong number of roles: %d", len(p.Roles))
+	}
+	if p.Using != "owner = current_user" || p.WithCheck != "owner = current_user" {
+		t.Errorf("wrong expressions: %s, %s", p.Using, p.WithCheck)
+	}
+	if !p.Restrictive {
+		t.Error("policy should be restrictive")
+	}
+
+	tbl := pqt.NewTable("document", pqt.WithForceRowLevelSecurity()).AddPolicy(p)
+	if p.Table != tbl {
+		t.Error("policy should reference the table")
+	}
+	if !tbl.EnableRowLevelSecurity || !tbl.ForceRowLevelSecurity {
+		t.Error("row level security should be enabled and forced")
+	}
+}
@@ -0,0 +1,33 @@
+package pqtddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error describes part of the input that could not be turned into the schema.
+type Error struct {
+	// Line is a line number the problem was found at.
+	Line int
+	// Statement is the source of the statement that contains the problem.
+	Statement string
+	// Reason explains what went wrong.
+	Reason string
+}
+
+// Error implements error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+}
+
+// Errors is a collection of errors found during parsing.
+type Errors []*Error
+
+// Error implements error interface.
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
@@ -0,0 +1,226 @@
+package pqtddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenIdent is an unquoted identifier or keyword. Its value is folded to lower case, the same way Postgres does it.
+	tokenIdent
+	// tokenQuotedIdent is a double quoted identifier. Its value is unquoted, but case is preserved.
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	// tokenSymbol is a punctuation or an operator.
+	tokenSymbol
+)
+
+type tokenKind int
+
+type token struct {
+	kind tokenKind
+	// value is a normalized representation of the token.
+	value string
+	// start and end are byte offsets of the token in the source.
+	start, end int
+	line       int
+}
+
+// is returns true if token is an unquoted identifier equal to any of given keywords.
+func (t token) is(keywords ...string) bool {
+	if t.kind != tokenIdent {
+		return false
+	}
+	for _, k := range keywords {
+		if t.value == k {
+			return true
+		}
+	}
+	return false
+}
+
+// isSymbol returns true if token is a symbol equal to s.
+func (t token) isSymbol(s string) bool {
+	return t.kind == tokenSymbol && t.value == s
+}
+
+// isName returns true if token can be used as a name of a database object.
+func (t token) isName() bool {
+	return t.kind == tokenIdent || t.kind == tokenQuotedIdent
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenQuotedIdent:
+		return `"` + t.value + `"`
+	case tokenString:
+		return "'" + t.value + "'"
+	default:
+		return t.value
+	}
+}
+
+// lex splits given source into tokens. Comments and white spaces are skipped.
+func lex(src string) ([]token, error) {
+	var (
+		tokens []token
+		line   = 1
+		i      = 0
+	)
+
+	for i < len(src) {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case r == '\n':
+			line++
+			i += size
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(src[i:], "--"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end+2], "\n")
+			i += 2 + end + 2
+		case r == '"':
+			end := i + 1
+			for {
+				next := strings.IndexByte(src[end:], '"')
+				if next < 0 {
+					return nil, fmt.Errorf("line %d: unterminated quoted identifier", line)
+				}
+				end += next + 1
+				if end < len(src) && src[end] == '"' {
+					end++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, token{
+				kind:  tokenQuotedIdent,
+				value: strings.Replace(src[i+1:end-1], `""`, `"`, -1),
+				start: i,
+				end:   end,
+				line:  line,
+			})
+			line += strings.Count(src[i:end], "\n")
+			i = end
+		case r == '\'':
+			end := i + 1
+			for {
+				next := strings.IndexByte(src[end:], '\'')
+				if next < 0 {
+					return nil, fmt.Errorf("line %d: unterminated string literal", line)
+				}
+				end += next + 1
+				if end < len(src) && src[end] == '\'' {
+					end++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, token{
+				kind:  tokenString,
+				value: strings.Replace(src[i+1:end-1], `''`, `'`, -1),
+				start: i,
+				end:   end,
+				line:  line,
+			})
+			line += strings.Count(src[i:end], "\n")
+			i = end
+		case r == '$' && dollarTag(src[i:]) != "":
+			tag := dollarTag(src[i:])
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated dollar quoted string", line)
+			}
+			end = i + len(tag) + end + len(tag)
+			tokens = append(tokens, token{
+				kind:  tokenString,
+				value: src[i+len(tag) : end-len(tag)],
+				start: i,
+				end:   end,
+				line:  line,
+			})
+			line += strings.Count(src[i:end], "\n")
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i
+			for end < len(src) {
+				r, size := utf8.DecodeRuneInString(src[end:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, token{
+				kind:  tokenIdent,
+				value: strings.ToLower(src[i:end]),
+				start: i,
+				end:   end,
+				line:  line,
+			})
+			i = end
+		case unicode.IsDigit(r):
+			end := i
+			for end < len(src) && (src[end] >= '0' && src[end] <= '9' || src[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{
+				kind:  tokenNumber,
+				value: src[i:end],
+				start: i,
+				end:   end,
+				line:  line,
+			})
+			i = end
+		default:
+			end := i + size
+			// Multi character operators are kept together, so that expressions can be reproduced as they were written.
+			if r == ':' && end < len(src) && src[end] == ':' {
+				end++
+			} else if strings.ContainsRune("<>=!~@#%^&|+-*/", r) {
+				for end < len(src) && strings.ContainsRune("<>=!~@#%^&|", rune(src[end])) {
+					end++
+				}
+			}
+			tokens = append(tokens, token{
+				kind:  tokenSymbol,
+				value: src[i:end],
+				start: i,
+				end:   end,
+				line:  line,
+			})
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, start: len(src), end: len(src), line: line}), nil
+}
+
+// dollarTag returns opening tag of dollar quoted string ($$ or $tag$) or empty string if there is none.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '$':
+			return s[:i+1]
+		case s[i] == '_' || unicode.IsLetter(rune(s[i])) || (i > 1 && unicode.IsDigit(rune(s[i]))):
+		default:
+			return ""
+		}
+	}
+	return ""
+}
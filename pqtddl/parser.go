@@ -0,0 +1,2383 @@
+// Package pqtddl builds pqt schema out of PostgreSQL data definition language.
+//
+// It understands the subset of DDL that pqt is able to express:
+// schemas, tables with their partitions and parents, columns, defaults, identity and generated columns, check, unique, primary key, foreign key and exclusion constraints, indexes, sequences owned by columns
+// enumerated and composite types, functions, table triggers, comments,
+// row level security policies and privileges granted on the schema, tables, columns and functions. Session settings (SET statements and set_config calls) are ignored.
+// Everything else is reported as an Error.
+//
+// ParseDatabase accepts DDL of multiple schemas that reference each other, objects without schema belong to the public schema then.
+package pqtddl
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+// Parse reads PostgreSQL DDL and builds matching schema.
+// If some statements cannot be expressed by pqt, Errors is returned together with the schema built out of remaining statements.
+func Parse(r io.Reader) (*pqt.Schema, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseString(string(src))
+}
+
+// ParseString works like Parse, but takes DDL as a string.
+func ParseString(src string) (*pqt.Schema, error) {
+	p, err := newParser(src, false)
+	if err != nil {
+		return nil, err
+	}
+	p.parse()
+
+	s := p.build()[0]
+	for _, e := range p.extensions {
+		s.AddExtension(e)
+	}
+	if len(p.errs) > 0 {
+		return s, p.errs
+	}
+	return s, nil
+}
+
+// ParseDatabase reads PostgreSQL DDL and builds matching database, schemas are added in order of declaration.
+// Public schema is a part of the database only if it is not empty.
+// If some statements cannot be expressed by pqt, Errors is returned together with the database built out of remaining statements.
+func ParseDatabase(r io.Reader) (*pqt.Database, error) {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDatabaseString(string(src))
+}
+
+// ParseDatabaseString works like ParseDatabase, but takes DDL as a string.
+func ParseDatabaseString(src string) (*pqt.Database, error) {
+	p, err := newParser(src, true)
+	if err != nil {
+		return nil, err
+	}
+	p.parse()
+
+	d := pqt.NewDatabase()
+	for _, e := range p.extensions {
+		d.AddExtension(e)
+	}
+	for i, s := range p.build() {
+		if i == 0 && len(s.Tables) == 0 && len(s.Types) == 0 && len(s.Functions) == 0 && len(s.Grants) == 0 {
+			continue
+		}
+		d.AddSchema(s)
+	}
+	if len(p.errs) > 0 {
+		return d, p.errs
+	}
+	return d, nil
+}
+
+func newParser(src string, database bool) (*parser, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser{
+		src:       src,
+		tokens:    tokens,
+		database:  database,
+		schemas:   []*schemaDef{newSchemaDef("")},
+		sequences: make(map[string]string),
+	}, nil
+}
+
+type tableDef struct {
+	schema *schemaDef
+	table  *pqt.Table
+	// columns preserves declaration order until they are added to the table.
+	columns     []*pqt.Column
+	constraints []*constraintDef
+	triggers    []*pqt.Trigger
+}
+
+type constraintDef struct {
+	kind pqt.ConstraintType
+	// name is given by CONSTRAINT clause or CREATE INDEX statement, empty if not given.
+	name string
+	// columns are names of the constrained columns.
+	columns []string
+	// identifiers are all identifiers used by check expression, in order of appearance.
+	identifiers                              []string
+	check, where                             string
+	refSchema                                *schemaDef
+	refTable                                 string
+	refColumns                               []string
+	onDelete, onUpdate, match                int32
+	deferrable, initiallyDeferred, noInherit bool
+	// method and exclude describe exclusion constraint, method, elements, include and concurrently describe an index.
+	// Elements without expression refer to columns, in order.
+	method       string
+	exclude      []*pqt.ExclusionElement
+	elements     []*pqt.IndexElement
+	include      []string
+	concurrently bool
+	line         int
+	statement    string
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+	// stmt is an index of the first token of the statement that is currently parsed.
+	stmt int
+
+	// database if true allows multiple schemas, otherwise all objects belong to a single schema.
+	database bool
+	// schemas are in order of declaration, the first one is the schema objects without schema belong to.
+	schemas    []*schemaDef
+	extensions []*pqt.Extension
+	// sequences maps sequence name to the column that owns it, in format <table>.<column>.
+	sequences map[string]string
+	errs      Errors
+}
+
+// schemaDef collects definitions of objects that belong to a single schema.
+type schemaDef struct {
+	name        string
+	ifNotExists bool
+	declared    bool
+	order       []string
+	tables      map[string]*tableDef
+	functions   []*pqt.Function
+	types       []pqt.Type
+	// comments on constraints and indexes are applied once constraints are built.
+	comments []*commentDef
+	// grants are privileges given on the schema.
+	grants []*pqt.Grant
+}
+
+func newSchemaDef(name string) *schemaDef {
+	return &schemaDef{
+		name:   name,
+		tables: make(map[string]*tableDef),
+	}
+}
+
+// commentDef is a comment on a constraint of the table, or on an index if table is empty.
+type commentDef struct {
+	schema               *schemaDef
+	table, name, comment string
+	line                 int
+	statement            string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes sequence of keywords if all of them are next in the stream.
+func (p *parser) accept(keywords ...string) bool {
+	for i, k := range keywords {
+		if !p.peekAt(i).is(k) {
+			return false
+		}
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+func (p *parser) acceptSymbol(s string) bool {
+	if p.peek().isSymbol(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(keywords ...string) error {
+	for _, k := range keywords {
+		if t := p.next(); !t.is(k) {
+			return fmt.Errorf("expected %s, got %s", strings.ToUpper(k), t)
+		}
+	}
+	return nil
+}
+
+func (p *parser) expectSymbol(s string) error {
+	if t := p.next(); !t.isSymbol(s) {
+		return fmt.Errorf("expected %s, got %s", s, t)
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	t := p.next()
+	if !t.isName() {
+		return "", fmt.Errorf("expected name, got %s", t)
+	}
+	return t.value, nil
+}
+
+// qualifiedName parses name in format [<schema>.]<name>.
+func (p *parser) qualifiedName() (string, string, error) {
+	name, err := p.name()
+	if err != nil {
+		return "", "", err
+	}
+	if !p.acceptSymbol(".") {
+		return "", name, nil
+	}
+	object, err := p.name()
+	if err != nil {
+		return "", "", err
+	}
+	return name, object, nil
+}
+
+// nameList parses parenthesized, comma separated list of names.
+func (p *parser) nameList() ([]string, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if p.acceptSymbol(")") {
+			return names, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// expression consumes tokens until one of the terminators is found at the top nesting level.
+// It returns expression exactly as it was written in the source.
+func (p *parser) expression(terminate func(t token) bool) (string, []string, error) {
+	var (
+		depth       int
+		identifiers []string
+		first       = p.peek()
+		last        token
+	)
+	for {
+		t := p.peek()
+		if t.kind == tokenEOF || t.isSymbol(";") || (depth == 0 && terminate(t)) {
+			if depth > 0 {
+				return "", nil, fmt.Errorf("unexpected %s", t)
+			}
+			if last.end == 0 {
+				return "", nil, fmt.Errorf("expected expression, got %s", t)
+			}
+			return p.src[first.start:last.end], identifiers, nil
+		}
+		switch {
+		case t.isSymbol("("), t.isSymbol("["):
+			depth++
+		case t.isSymbol(")"), t.isSymbol("]"):
+			if depth == 0 {
+				return "", nil, fmt.Errorf("unexpected %s", t)
+			}
+			depth--
+		case t.isName():
+			identifiers = append(identifiers, t.value)
+		}
+		last = p.next()
+	}
+}
+
+// parenthesized parses expression enclosed in parentheses and returns its content.
+func (p *parser) parenthesized() (string, []string, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return "", nil, err
+	}
+	expr, identifiers, err := p.expression(func(t token) bool {
+		return t.isSymbol(")")
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return expr, identifiers, p.expectSymbol(")")
+}
+
+func (p *parser) statementSource() string {
+	end := p.pos
+	for end < len(p.tokens)-1 && !p.tokens[end].isSymbol(";") {
+		end++
+	}
+	if end <= p.stmt {
+		return ""
+	}
+	return p.src[p.tokens[p.stmt].start:p.tokens[end-1].end]
+}
+
+// report records an error related to the statement that is currently parsed.
+func (p *parser) report(line int, format string, args ...interface{}) {
+	p.errs = append(p.errs, &Error{
+		Line:      line,
+		Statement: p.statementSource(),
+		Reason:    fmt.Sprintf(format, args...),
+	})
+}
+
+func (p *parser) parse() {
+	for p.peek().kind != tokenEOF {
+		if p.acceptSymbol(";") {
+			continue
+		}
+
+		p.stmt = p.pos
+		err := p.statement()
+		if err == nil && !p.peek().isSymbol(";") && p.peek().kind != tokenEOF {
+			err = fmt.Errorf("unexpected %s", p.peek())
+		}
+		if err != nil {
+			p.report(p.tokens[p.stmt].line, "%s", err.Error())
+		}
+		for !p.peek().isSymbol(";") && p.peek().kind != tokenEOF {
+			p.next()
+		}
+	}
+}
+
+func (p *parser) statement() error {
+	t := p.peek()
+	switch {
+	case t.is("set"):
+		// Session settings do not affect the schema.
+		p.skip()
+		return nil
+	case t.is("select") && p.peekAt(1).is("pg_catalog") && p.peekAt(3).is("set_config"):
+		p.skip()
+		return nil
+	case p.accept("create", "schema"):
+		return p.createSchema()
+	case p.accept("create", "table"):
+		return p.createTable(false)
+	case p.accept("create", "temporary", "table"), p.accept("create", "temp", "table"):
+		return p.createTable(true)
+	case p.accept("create", "index"):
+		return p.createIndex(false)
+	case p.accept("create", "unique", "index"):
+		return p.createIndex(true)
+	case p.accept("create", "sequence"):
+		return p.createSequence()
+	case p.accept("create", "type"):
+		return p.createType()
+	case p.accept("create", "domain"):
+		return p.createDomain()
+	case p.accept("create", "extension"):
+		return p.createExtension()
+	case p.accept("create", "function"), p.accept("create", "or", "replace", "function"):
+		return p.createFunction()
+	case p.accept("create", "trigger"), p.accept("create", "or", "replace", "trigger"):
+		return p.createTrigger()
+	case p.accept("alter", "table"):
+		return p.alterTable()
+	case p.accept("alter", "sequence"):
+		return p.alterSequence()
+	case p.accept("comment", "on"):
+		return p.comment()
+	case p.accept("create", "policy"):
+		return p.createPolicy()
+	case p.accept("grant"):
+		return p.grant()
+	case t.is("create", "alter", "drop", "comment", "grant", "revoke"):
+		if next := p.peekAt(1); next.kind != tokenEOF {
+			return fmt.Errorf("unsupported statement %s %s", strings.ToUpper(t.value), strings.ToUpper(next.value))
+		}
+	}
+	return fmt.Errorf("unsupported statement %s", strings.ToUpper(t.value))
+}
+
+// skip moves to the end of the current statement.
+func (p *parser) skip() {
+	for !p.peek().isSymbol(";") && p.peek().kind != tokenEOF {
+		p.next()
+	}
+}
+
+func (p *parser) createSchema() error {
+	ifNotExists := p.accept("if", "not", "exists")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if p.database {
+		if p.lookupSchema(name) != nil {
+			if ifNotExists {
+				return nil
+			}
+			return fmt.Errorf("schema %s already exists", name)
+		}
+		sd := newSchemaDef(name)
+		sd.ifNotExists = ifNotExists
+		sd.declared = true
+		p.schemas = append(p.schemas, sd)
+		return nil
+	}
+
+	sd := p.schemas[0]
+	if sd.declared && sd.name != name {
+		return fmt.Errorf("schema %s already declared, multiple schemas are supported only by ParseDatabase", sd.name)
+	}
+	sd.name = name
+	sd.ifNotExists = ifNotExists
+	sd.declared = true
+	return nil
+}
+
+// lookupSchema returns schema of given name, empty name means the schema objects without schema belong to.
+// It returns nil if schema is not known.
+func (p *parser) lookupSchema(name string) *schemaDef {
+	def := p.schemas[0]
+	switch {
+	case name == "" || name == def.name:
+		return def
+	case name == pqt.DefaultSchema && (p.database || !def.declared):
+		return def
+	case p.database:
+		for _, sd := range p.schemas[1:] {
+			if sd.name == name {
+				return sd
+			}
+		}
+	}
+	return nil
+}
+
+// schemaOf returns schema the object of given kind and name belongs to.
+// If schema is not declared, the first one that is referenced becomes the schema of all objects, unless multiple schemas are allowed.
+func (p *parser) schemaOf(kind, schema, name string) (*schemaDef, error) {
+	if sd := p.lookupSchema(schema); sd != nil {
+		return sd, nil
+	}
+	def := p.schemas[0]
+	switch {
+	case p.database:
+		return nil, fmt.Errorf("%s %s.%s is outside of declared schemas", kind, schema, name)
+	case !def.declared && def.name == "":
+		def.name = schema
+		return def, nil
+	}
+	return nil, fmt.Errorf("%s %s.%s is outside of schema %s", kind, schema, name, or(def.name, pqt.DefaultSchema))
+}
+
+func (p *parser) table(schema, name string) (*tableDef, error) {
+	sd, err := p.schemaOf("table", schema, name)
+	if err != nil {
+		return nil, err
+	}
+	td, ok := sd.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("table %s does not exist", name)
+	}
+	return td, nil
+}
+
+// function returns function of given name, if args are not nil they have to match as well.
+func (p *parser) function(schema, name string, args *string) (*pqt.Function, error) {
+	sd, err := p.schemaOf("function", schema, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range sd.functions {
+		if f.Name == name && (args == nil || functionArgs(f) == *args) {
+			return f, nil
+		}
+	}
+	if args == nil {
+		return nil, fmt.Errorf("function %s does not exist", name)
+	}
+	return nil, fmt.Errorf("function %s(%s) does not exist", name, *args)
+}
+
+func (p *parser) createTable(temporary bool) error {
+	// Columns are kept in order they are declared in, so generated DDL matches the parsed one.
+	opts := []pqt.TableOption{pqt.WithColumnOrder(pqt.ColumnOrderDeclared)}
+	if temporary {
+		opts = append(opts, pqt.WithTemporary())
+	}
+	if p.accept("if", "not", "exists") {
+		opts = append(opts, pqt.WithTableIfNotExists())
+	}
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	sd, err := p.schemaOf("table", schema, name)
+	if err != nil {
+		return err
+	}
+	if _, ok := sd.tables[name]; ok {
+		return fmt.Errorf("table %s already exists", name)
+	}
+	if p.accept("partition", "of") {
+		return p.partitionOf(name)
+	}
+
+	td := &tableDef{schema: sd, table: pqt.NewTable(name, opts...)}
+
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for !p.acceptSymbol(")") {
+		if len(td.columns) > 0 || len(td.constraints) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return err
+			}
+		}
+		if p.peek().is("constraint", "primary", "unique", "check", "foreign", "exclude") {
+			cd, err := p.tableConstraint()
+			if err != nil {
+				return err
+			}
+			td.constraints = append(td.constraints, cd)
+			continue
+		}
+		if err := p.columnDefinition(td); err != nil {
+			return err
+		}
+	}
+	if p.accept("inherits") {
+		if err := p.inherits(td); err != nil {
+			return err
+		}
+	}
+	if p.accept("partition", "by") {
+		if err := p.partitionBy(td); err != nil {
+			return err
+		}
+	}
+	if p.accept("tablespace") {
+		ts, err := p.name()
+		if err != nil {
+			return err
+		}
+		td.table.TableSpace = ts
+	}
+
+	sd.tables[name] = td
+	sd.order = append(sd.order, name)
+	return nil
+}
+
+// inherits parses parenthesized list of parent tables, they have to be created first.
+func (p *parser) inherits(td *tableDef) error {
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for {
+		schema, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		parent, err := p.table(schema, name)
+		if err != nil {
+			return err
+		}
+		td.table.Inherits = append(td.table.Inherits, parent.table)
+		if p.acceptSymbol(")") {
+			return nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+}
+
+// partitionBy parses partitioning strategy and key, key expressions are not supported.
+func (p *parser) partitionBy(td *tableDef) error {
+	strategy, err := p.name()
+	if err != nil {
+		return err
+	}
+	td.table.PartitionBy = pqt.PartitionStrategy(strings.ToUpper(strategy))
+	names, err := p.nameList()
+	if err != nil {
+		return fmt.Errorf("only plain column partition keys are supported")
+	}
+NamesLoop:
+	for _, name := range names {
+		for _, c := range td.columns {
+			if c.Name == name {
+				td.table.PartitionKey = append(td.table.PartitionKey, c)
+				continue NamesLoop
+			}
+		}
+		return fmt.Errorf("partition key column %s does not exist", name)
+	}
+	return nil
+}
+
+// partitionOf parses the remaining part of CREATE TABLE <name> PARTITION OF <parent> statement.
+func (p *parser) partitionOf(name string) error {
+	schema, parent, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	td, err := p.table(schema, parent)
+	if err != nil {
+		return err
+	}
+	if p.accept("default") {
+		td.table.AddPartition(pqt.PartitionDefault(name))
+		return nil
+	}
+	if err := p.expect("for", "values"); err != nil {
+		return err
+	}
+	bound, _, err := p.expression(func(t token) bool {
+		return t.is("partition", "tablespace")
+	})
+	if err != nil {
+		return err
+	}
+	td.table.AddPartition(pqt.NewPartition(name, bound))
+	return nil
+}
+
+func (p *parser) columnDefinition(td *tableDef) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	for _, c := range td.columns {
+		if c.Name == name {
+			return fmt.Errorf("column %s specified more than once", name)
+		}
+	}
+	typ, err := p.dataType()
+	if err != nil {
+		return err
+	}
+
+	c := pqt.NewColumn(name, typ)
+	if p.accept("collate") {
+		t := p.next()
+		if !t.isName() {
+			return fmt.Errorf("expected collation, got %s", t)
+		}
+		// Collation is kept as written, since pqt renders it verbatim.
+		c.Collate = p.src[t.start:t.end]
+	}
+	var constraintName string
+	for {
+		t := p.peek()
+		switch {
+		case p.accept("constraint"):
+			if constraintName, err = p.name(); err != nil {
+				return err
+			}
+			// Names of not null, default and other column options are not kept by pqt.
+			if !p.peek().is("primary", "unique", "check", "references") {
+				constraintName = ""
+			}
+		case p.accept("not", "null"):
+			c.NotNull = true
+		case p.accept("null"):
+			c.NotNull = false
+		case p.accept("default"):
+			d, _, err := p.expression(isColumnConstraintEnd)
+			if err != nil {
+				return err
+			}
+			pqt.WithDefault(d)(c)
+		case p.accept("generated", "always", "as", "identity"):
+			c.Identity = pqt.IdentityAlways
+			if p.peek().isSymbol("(") {
+				return fmt.Errorf("identity sequence options are not supported")
+			}
+		case p.accept("generated", "by", "default", "as", "identity"):
+			c.Identity = pqt.IdentityByDefault
+			if p.peek().isSymbol("(") {
+				return fmt.Errorf("identity sequence options are not supported")
+			}
+		case p.accept("generated", "always", "as"):
+			expr, _, err := p.parenthesized()
+			if err != nil {
+				return err
+			}
+			if err := p.expect("stored"); err != nil {
+				return err
+			}
+			c.Generated = expr
+		case t.is("primary", "unique", "check", "references"):
+			cd, err := p.constraintBody()
+			if err != nil {
+				return err
+			}
+			if cd.kind != pqt.ConstraintTypeForeignKey || len(cd.columns) == 0 {
+				cd.columns = []string{name}
+			}
+			cd.name, constraintName = constraintName, ""
+			td.constraints = append(td.constraints, cd)
+		case t.isSymbol(","), t.isSymbol(")"):
+			td.columns = append(td.columns, c)
+			return nil
+		default:
+			return fmt.Errorf("unsupported column option %s", t)
+		}
+	}
+}
+
+// isColumnConstraintEnd returns true if given token starts next column constraint or ends column definition.
+func isColumnConstraintEnd(t token) bool {
+	return t.isSymbol(",") || t.isSymbol(")") ||
+		t.is("not", "null", "primary", "unique", "check", "references", "constraint", "collate", "generated", "deferrable", "initially")
+}
+
+func (p *parser) tableConstraint() (*constraintDef, error) {
+	var name string
+	if p.accept("constraint") {
+		var err error
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	cd, err := p.constraintBody()
+	if err != nil {
+		return nil, err
+	}
+	cd.name = name
+	return cd, nil
+}
+
+// constraintBody parses constraint that follows optional CONSTRAINT <name> clause.
+// Column level constraints have no column list, it is up to the caller to set it.
+func (p *parser) constraintBody() (*constraintDef, error) {
+	var err error
+
+	cd := &constraintDef{
+		line:      p.peek().line,
+		statement: p.statementSource(),
+	}
+	switch {
+	case p.accept("primary", "key"):
+		cd.kind = pqt.ConstraintTypePrimaryKey
+		if p.peek().isSymbol("(") {
+			if cd.columns, err = p.nameList(); err != nil {
+				return nil, err
+			}
+		}
+	case p.accept("unique"):
+		cd.kind = pqt.ConstraintTypeUnique
+		if p.peek().isSymbol("(") {
+			if cd.columns, err = p.nameList(); err != nil {
+				return nil, err
+			}
+		}
+	case p.accept("check"):
+		cd.kind = pqt.ConstraintTypeCheck
+		if cd.check, cd.identifiers, err = p.parenthesized(); err != nil {
+			return nil, err
+		}
+		cd.noInherit = p.accept("no", "inherit")
+	case p.accept("foreign", "key"):
+		cd.kind = pqt.ConstraintTypeForeignKey
+		if cd.columns, err = p.nameList(); err != nil {
+			return nil, err
+		}
+		if err = p.expect("references"); err != nil {
+			return nil, err
+		}
+		if err = p.references(cd); err != nil {
+			return nil, err
+		}
+	case p.accept("references"):
+		cd.kind = pqt.ConstraintTypeForeignKey
+		if err = p.references(cd); err != nil {
+			return nil, err
+		}
+	case p.accept("exclude"):
+		cd.kind = pqt.ConstraintTypeExclusion
+		if err = p.exclusion(cd); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported constraint %s", p.peek())
+	}
+
+	for {
+		switch {
+		case p.accept("deferrable"):
+			cd.deferrable = true
+		case p.accept("not", "deferrable"):
+			cd.deferrable = false
+		case p.accept("initially", "deferred"):
+			cd.initiallyDeferred = true
+		case p.accept("initially", "immediate"):
+			cd.initiallyDeferred = false
+		default:
+			return cd, nil
+		}
+	}
+}
+
+func (p *parser) references(cd *constraintDef) error {
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	if cd.refSchema, err = p.schemaOf("table", schema, name); err != nil {
+		return err
+	}
+	cd.refTable = name
+	if p.peek().isSymbol("(") {
+		if cd.refColumns, err = p.nameList(); err != nil {
+			return err
+		}
+	}
+	for {
+		switch {
+		case p.accept("on", "delete"):
+			if cd.onDelete, err = p.referentialAction(); err != nil {
+				return err
+			}
+		case p.accept("on", "update"):
+			if cd.onUpdate, err = p.referentialAction(); err != nil {
+				return err
+			}
+		case p.accept("match", "full"):
+			cd.match = pqt.MatchFull
+		case p.accept("match", "simple"):
+			cd.match = pqt.MatchSimple
+		case p.peek().is("match"):
+			return fmt.Errorf("unsupported foreign key option MATCH %s", p.peekAt(1))
+		default:
+			return nil
+		}
+	}
+}
+
+// exclusion parses [USING method] (element WITH operator [, ...]) [WHERE (predicate)].
+// Element is either a column name or an expression in parentheses.
+func (p *parser) exclusion(cd *constraintDef) error {
+	var err error
+	if p.accept("using") {
+		if cd.method, err = p.name(); err != nil {
+			return err
+		}
+	}
+	if err = p.expectSymbol("("); err != nil {
+		return err
+	}
+	for {
+		el := &pqt.ExclusionElement{}
+		if p.peek().isSymbol("(") {
+			if el.Expression, _, err = p.parenthesized(); err != nil {
+				return err
+			}
+		} else {
+			name, err := p.name()
+			if err != nil {
+				return err
+			}
+			cd.columns = append(cd.columns, name)
+		}
+		if err = p.expect("with"); err != nil {
+			return err
+		}
+		if el.Operator, _, err = p.expression(func(t token) bool {
+			return t.isSymbol(",") || t.isSymbol(")")
+		}); err != nil {
+			return err
+		}
+		cd.exclude = append(cd.exclude, el)
+		if p.acceptSymbol(")") {
+			break
+		}
+		if err = p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+	if p.accept("where") {
+		if cd.where, _, err = p.parenthesized(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) referentialAction() (int32, error) {
+	switch {
+	case p.accept("cascade"):
+		return pqt.Cascade, nil
+	case p.accept("restrict"):
+		return pqt.Restrict, nil
+	case p.accept("set", "null"):
+		return pqt.SetNull, nil
+	case p.accept("set", "default"):
+		return pqt.SetDefault, nil
+	case p.accept("no", "action"):
+		return pqt.NoAction, nil
+	default:
+		return 0, fmt.Errorf("unsupported referential action %s", p.peek())
+	}
+}
+
+func (p *parser) createIndex(unique bool) error {
+	cd := &constraintDef{
+		kind:      pqt.ConstraintTypeIndex,
+		line:      p.tokens[p.stmt].line,
+		statement: p.statementSource(),
+	}
+	if unique {
+		cd.kind = pqt.ConstraintTypeUniqueIndex
+	}
+
+	cd.concurrently = p.accept("concurrently")
+	p.accept("if", "not", "exists")
+	if !p.peek().is("on") {
+		var err error
+		if _, cd.name, err = p.qualifiedName(); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	p.accept("only")
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	td, err := p.table(schema, name)
+	if err != nil {
+		return err
+	}
+	if p.accept("using") {
+		if cd.method, err = p.name(); err != nil {
+			return err
+		}
+		// Btree is the default method.
+		if cd.method == "btree" {
+			cd.method = ""
+		}
+	}
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for {
+		el, err := p.indexElement(cd)
+		if err != nil {
+			return err
+		}
+		cd.elements = append(cd.elements, el)
+		if p.acceptSymbol(")") {
+			break
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+	if p.accept("include") {
+		if cd.include, err = p.nameList(); err != nil {
+			return err
+		}
+	}
+	if p.peek().is("with", "tablespace") {
+		return fmt.Errorf("index storage parameters and tablespace are not supported")
+	}
+	if p.accept("where") {
+		if cd.where, _, err = p.expression(func(token) bool { return false }); err != nil {
+			return err
+		}
+	}
+
+	td.constraints = append(td.constraints, cd)
+	return nil
+}
+
+// indexElement parses column name or expression followed by optional operator class, ordering and nulls position.
+// Columns are appended to the constraint columns, the element itself carries no expression then.
+func (p *parser) indexElement(cd *constraintDef) (*pqt.IndexElement, error) {
+	var (
+		el  = &pqt.IndexElement{}
+		err error
+	)
+	switch {
+	case p.peek().isSymbol("("):
+		if el.Expression, _, err = p.parenthesized(); err != nil {
+			return nil, err
+		}
+	case p.peekAt(1).isSymbol("("):
+		// Function call does not require additional parentheses.
+		first := p.next()
+		if _, _, err = p.parenthesized(); err != nil {
+			return nil, err
+		}
+		el.Expression = p.src[first.start:p.tokens[p.pos-1].end]
+	default:
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		cd.columns = append(cd.columns, name)
+	}
+	if t := p.peek(); t.isName() && !t.is("asc", "desc", "nulls") {
+		if el.OpClass, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if !p.accept("asc") {
+		el.Descending = p.accept("desc")
+	}
+	switch {
+	case p.accept("nulls", "first"):
+		el.Nulls = pqt.NullsFirst
+	case p.accept("nulls", "last"):
+		el.Nulls = pqt.NullsLast
+	}
+	return el, nil
+}
+
+func (p *parser) createSequence() error {
+	p.accept("if", "not", "exists")
+	_, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	// Sequence options are irrelevant, since only sequences owned by columns can be expressed as serial types.
+	p.skip()
+	p.sequences[name] = ""
+	return nil
+}
+
+func (p *parser) alterSequence() error {
+	p.accept("if", "exists")
+	_, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	if _, ok := p.sequences[name]; !ok {
+		return fmt.Errorf("sequence %s does not exist", name)
+	}
+	if err := p.expect("owned", "by"); err != nil {
+		return err
+	}
+	if p.accept("none") {
+		p.sequences[name] = ""
+		return nil
+	}
+	var parts []string
+	for {
+		part, err := p.name()
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+		if !p.acceptSymbol(".") {
+			break
+		}
+	}
+	if len(parts) < 2 {
+		return fmt.Errorf("expected <table>.<column>, got %s", strings.Join(parts, "."))
+	}
+	p.sequences[name] = strings.Join(parts[len(parts)-2:], ".")
+	return nil
+}
+
+func (p *parser) alterTable() error {
+	p.accept("if", "exists")
+	p.accept("only")
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	td, err := p.table(schema, name)
+	if err != nil {
+		return err
+	}
+
+	for {
+		switch {
+		case p.accept("add"):
+			if p.peek().is("constraint", "primary", "unique", "check", "foreign", "exclude") {
+				cd, err := p.tableConstraint()
+				if err != nil {
+					return err
+				}
+				td.constraints = append(td.constraints, cd)
+				break
+			}
+			p.accept("column")
+			if err := p.columnDefinition(td); err != nil {
+				return err
+			}
+		case p.accept("alter"):
+			p.accept("column")
+			name, err := p.name()
+			if err != nil {
+				return err
+			}
+			var c *pqt.Column
+			for _, cc := range td.columns {
+				if cc.Name == name {
+					c = cc
+				}
+			}
+			if c == nil {
+				return fmt.Errorf("column %s does not exist", name)
+			}
+			switch {
+			case p.accept("set", "default"):
+				d, _, err := p.expression(func(t token) bool { return t.isSymbol(",") })
+				if err != nil {
+					return err
+				}
+				pqt.WithDefault(d)(c)
+			case p.accept("drop", "default"):
+				delete(c.Default, pqt.EventInsert)
+			case p.accept("set", "not", "null"):
+				c.NotNull = true
+			case p.accept("drop", "not", "null"):
+				c.NotNull = false
+			default:
+				return fmt.Errorf("unsupported ALTER COLUMN action %s", p.peek())
+			}
+		case p.accept("enable", "row", "level", "security"):
+			td.table.EnableRowLevelSecurity = true
+		case p.accept("disable", "row", "level", "security"):
+			td.table.EnableRowLevelSecurity = false
+		case p.accept("force", "row", "level", "security"):
+			td.table.ForceRowLevelSecurity = true
+		case p.accept("no", "force", "row", "level", "security"):
+			td.table.ForceRowLevelSecurity = false
+		default:
+			return fmt.Errorf("unsupported ALTER TABLE action %s", p.peek())
+		}
+		if !p.acceptSymbol(",") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) createPolicy() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	schema, table, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	td, err := p.table(schema, table)
+	if err != nil {
+		return err
+	}
+	for _, pl := range td.table.Policies {
+		if pl.Name == name {
+			return fmt.Errorf("policy %s already exists", name)
+		}
+	}
+
+	pl := pqt.NewPolicy(name)
+	if p.accept("as") {
+		switch {
+		case p.accept("restrictive"):
+			pl.Restrictive = true
+		case p.accept("permissive"):
+		default:
+			return fmt.Errorf("expected PERMISSIVE or RESTRICTIVE, got %s", p.peek())
+		}
+	}
+	if p.accept("for") {
+		t := p.next()
+		if !t.is("all", "select", "insert", "update", "delete") {
+			return fmt.Errorf("expected policy command, got %s", t)
+		}
+		pl.Command = pqt.PolicyCommand(strings.ToUpper(t.value))
+	}
+	if p.accept("to") {
+		for len(pl.Roles) == 0 || p.acceptSymbol(",") {
+			role, err := p.role()
+			if err != nil {
+				return err
+			}
+			pl.Roles = append(pl.Roles, role)
+		}
+	}
+	if p.accept("using") {
+		if pl.Using, _, err = p.parenthesized(); err != nil {
+			return err
+		}
+	}
+	if p.accept("with", "check") {
+		if pl.WithCheck, _, err = p.parenthesized(); err != nil {
+			return err
+		}
+	}
+	td.table.AddPolicy(pl)
+	return nil
+}
+
+// grant parses GRANT statement that gives privileges on a table, its columns, the schema or a function.
+func (p *parser) grant() error {
+	type privilege struct {
+		privilege pqt.Privilege
+		columns   []string
+	}
+	var privileges []privilege
+	for len(privileges) == 0 || p.acceptSymbol(",") {
+		t := p.next()
+		if !t.is("all", "select", "insert", "update", "delete", "truncate", "references", "trigger", "usage", "create", "execute") {
+			return fmt.Errorf("expected privilege, got %s", t)
+		}
+		pr := privilege{privilege: pqt.Privilege(strings.ToUpper(t.value))}
+		if t.is("all") {
+			p.accept("privileges")
+			pr.privilege = pqt.PrivilegeAll
+		}
+		if p.peek().isSymbol("(") {
+			columns, err := p.nameList()
+			if err != nil {
+				return err
+			}
+			pr.columns = columns
+		}
+		privileges = append(privileges, pr)
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+
+	var (
+		target *[]*pqt.Grant
+		td     *tableDef
+	)
+	switch {
+	case p.accept("schema"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		sd := p.lookupSchema(name)
+		switch {
+		case sd == nil && p.database:
+			return fmt.Errorf("schema %s does not exist", name)
+		case sd == nil || name != or(sd.name, pqt.DefaultSchema):
+			return fmt.Errorf("schema %s is outside of schema %s", name, or(p.schemas[0].name, pqt.DefaultSchema))
+		}
+		target = &sd.grants
+	case p.accept("function"):
+		schema, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		args, err := p.argumentTypes()
+		if err != nil {
+			return err
+		}
+		f, err := p.function(schema, name, &args)
+		if err != nil {
+			return err
+		}
+		target = &f.Grants
+	default:
+		p.accept("table")
+		schema, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		if td, err = p.table(schema, name); err != nil {
+			return err
+		}
+		target = &td.table.Grants
+	}
+
+	if err := p.expect("to"); err != nil {
+		return err
+	}
+	var roles []string
+	for len(roles) == 0 || p.acceptSymbol(",") {
+		role, err := p.role()
+		if err != nil {
+			return err
+		}
+		roles = append(roles, role)
+	}
+	grantOption := p.accept("with", "grant", "option")
+
+	// Privileges limited to the same columns are kept together.
+	for _, role := range roles {
+		var grants []*pqt.Grant
+	PrivilegesLoop:
+		for _, pr := range privileges {
+			columns, err := grantColumns(td, pr.columns)
+			if err != nil {
+				return err
+			}
+			for _, g := range grants {
+				if pqt.JoinColumns(g.Columns, ",") == strings.Join(pr.columns, ",") {
+					g.Privileges = append(g.Privileges, pr.privilege)
+					continue PrivilegesLoop
+				}
+			}
+			g := pqt.NewGrant(role, pr.privilege).OnColumns(columns...)
+			g.GrantOption = grantOption
+			grants = append(grants, g)
+		}
+		*target = append(*target, grants...)
+	}
+	return nil
+}
+
+// grantColumns looks up columns of the table privileges are given on.
+func grantColumns(td *tableDef, names []string) (pqt.Columns, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if td == nil {
+		return nil, fmt.Errorf("column privileges can be given only on a table")
+	}
+	columns := make(pqt.Columns, 0, len(names))
+NamesLoop:
+	for _, name := range names {
+		for _, c := range td.columns {
+			if c.Name == name {
+				columns = append(columns, c)
+				continue NamesLoop
+			}
+		}
+		return nil, fmt.Errorf("column %s of table %s does not exist", name, td.table.Name)
+	}
+	return columns, nil
+}
+
+// role parses role name, special role names are kept upper case.
+func (p *parser) role() (string, error) {
+	t := p.next()
+	switch {
+	case t.is("public", "current_user", "current_role", "session_user"):
+		return strings.ToUpper(t.value), nil
+	case t.isName():
+		return t.value, nil
+	default:
+		return "", fmt.Errorf("expected role, got %s", t)
+	}
+}
+
+// argumentTypes parses parenthesized list of function argument types and returns them rendered the way pqt does.
+func (p *parser) argumentTypes() (string, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return "", err
+	}
+	var args []string
+	for !p.acceptSymbol(")") {
+		if len(args) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return "", err
+			}
+		}
+		typ, err := p.dataType()
+		if err != nil {
+			return "", err
+		}
+		args = append(args, typ.String())
+	}
+	return strings.Join(args, ", "), nil
+}
+
+// comment parses COMMENT ON statement for a table, column, constraint, index or function.
+func (p *parser) comment() error {
+	var (
+		set func(string)
+		cd  *commentDef
+	)
+	switch {
+	case p.accept("table"):
+		schema, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		td, err := p.table(schema, name)
+		if err != nil {
+			return err
+		}
+		set = func(s string) { td.table.Comment = s }
+	case p.accept("column"):
+		names := make([]string, 0, 3)
+		for len(names) == 0 || p.acceptSymbol(".") {
+			name, err := p.name()
+			if err != nil {
+				return err
+			}
+			names = append(names, name)
+		}
+		if len(names) < 2 || len(names) > 3 {
+			return fmt.Errorf("expected column name in format [<schema>.]<table>.<column>")
+		}
+		var schema string
+		if len(names) == 3 {
+			schema, names = names[0], names[1:]
+		}
+		td, err := p.table(schema, names[0])
+		if err != nil {
+			return err
+		}
+		for _, c := range td.columns {
+			if c.Name == names[1] {
+				set = func(s string) { c.Comment = s }
+			}
+		}
+		if set == nil {
+			return fmt.Errorf("column %s of table %s does not exist", names[1], names[0])
+		}
+	case p.accept("constraint"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err := p.expect("on"); err != nil {
+			return err
+		}
+		schema, table, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		td, err := p.table(schema, table)
+		if err != nil {
+			return err
+		}
+		cd = &commentDef{schema: td.schema, table: table, name: name}
+	case p.accept("index"):
+		schema, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		sd, err := p.schemaOf("index", schema, name)
+		if err != nil {
+			return err
+		}
+		cd = &commentDef{schema: sd, name: name}
+	case p.accept("function"):
+		schema, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		args, err := p.argumentTypes()
+		if err != nil {
+			return err
+		}
+		f, err := p.function(schema, name, &args)
+		if err != nil {
+			return err
+		}
+		set = func(s string) { f.Comment = s }
+	default:
+		return fmt.Errorf("unsupported statement COMMENT ON %s", strings.ToUpper(p.peek().value))
+	}
+
+	if err := p.expect("is"); err != nil {
+		return err
+	}
+	var comment string
+	if !p.accept("null") {
+		t := p.next()
+		if t.kind != tokenString {
+			return fmt.Errorf("expected comment, got %s", t)
+		}
+		comment = t.value
+	}
+
+	if cd == nil {
+		set(comment)
+		return nil
+	}
+	cd.comment = comment
+	cd.line = p.tokens[p.stmt].line
+	cd.statement = p.statementSource()
+	cd.schema.comments = append(cd.schema.comments, cd)
+	return nil
+}
+
+func functionArgs(f *pqt.Function) string {
+	args := make([]string, 0, len(f.Args))
+	for _, arg := range f.Args {
+		args = append(args, arg.Type.String())
+	}
+	return strings.Join(args, ", ")
+}
+
+func (p *parser) createFunction() error {
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	sd, err := p.schemaOf("function", schema, name)
+	if err != nil {
+		return err
+	}
+	f := &pqt.Function{Name: name}
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for !p.acceptSymbol(")") {
+		if len(f.Args) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return err
+			}
+		}
+		argName, err := p.name()
+		if err != nil {
+			return err
+		}
+		argType, err := p.dataType()
+		if err != nil {
+			return err
+		}
+		f.Args = append(f.Args, &pqt.FunctionArg{Name: argName, Type: argType})
+	}
+	if err := p.expect("returns"); err != nil {
+		return err
+	}
+	switch {
+	case p.accept("trigger"):
+		f.Type = pqt.TypeTrigger()
+	case p.accept("table"):
+		if err := p.expectSymbol("("); err != nil {
+			return err
+		}
+		for !p.acceptSymbol(")") {
+			if len(f.ReturnsTable) > 0 {
+				if err := p.expectSymbol(","); err != nil {
+					return err
+				}
+			}
+			colName, err := p.name()
+			if err != nil {
+				return err
+			}
+			colType, err := p.dataType()
+			if err != nil {
+				return err
+			}
+			f.ReturnsTable = append(f.ReturnsTable, &pqt.FunctionArg{Name: colName, Type: colType})
+		}
+	default:
+		f.SetOf = p.accept("setof")
+		if f.Type, err = p.dataType(); err != nil {
+			return err
+		}
+	}
+	for !p.peek().isSymbol(";") && p.peek().kind != tokenEOF {
+		switch {
+		case p.accept("as"):
+			t := p.next()
+			if t.kind != tokenString {
+				return fmt.Errorf("expected function body, got %s", t)
+			}
+			f.Body = t.value
+		case p.accept("language"):
+			lang, err := p.name()
+			if err != nil {
+				return err
+			}
+			if lang != "sql" {
+				f.Language = lang
+			}
+		case p.accept("volatile"):
+			f.Behaviour = pqt.FunctionBehaviourVolatile
+		case p.accept("immutable"):
+			f.Behaviour = pqt.FunctionBehaviourImmutable
+		case p.accept("stable"):
+			f.Behaviour = pqt.FunctionBehaviourStable
+		case p.accept("security", "definer"), p.accept("external", "security", "definer"):
+			f.SecurityDefiner = true
+		case p.accept("security", "invoker"), p.accept("external", "security", "invoker"):
+			f.SecurityDefiner = false
+		default:
+			return fmt.Errorf("unsupported function option %s", p.peek())
+		}
+	}
+
+	sd.functions = append(sd.functions, f)
+	return nil
+}
+
+func (p *parser) createTrigger() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	tr := pqt.NewTrigger(name, pqt.TriggerTimingBefore, nil)
+	switch {
+	case p.accept("before"):
+	case p.accept("after"):
+		tr.Timing = pqt.TriggerTimingAfter
+	case p.accept("instead", "of"):
+		tr.Timing = pqt.TriggerTimingInsteadOf
+	default:
+		return fmt.Errorf("expected BEFORE, AFTER or INSTEAD OF, got %s", p.peek())
+	}
+	var updateOf []string
+	for {
+		switch {
+		case p.accept("insert"):
+			tr.Events = append(tr.Events, pqt.EventInsert)
+		case p.accept("update"):
+			tr.Events = append(tr.Events, pqt.EventUpdate)
+			if p.accept("of") {
+				for {
+					column, err := p.name()
+					if err != nil {
+						return err
+					}
+					updateOf = append(updateOf, column)
+					if !p.acceptSymbol(",") {
+						break
+					}
+				}
+			}
+		case p.accept("delete"):
+			tr.Events = append(tr.Events, pqt.EventDelete)
+		case p.accept("truncate"):
+			tr.Events = append(tr.Events, pqt.EventTruncate)
+		default:
+			return fmt.Errorf("expected trigger event, got %s", p.peek())
+		}
+		if !p.accept("or") {
+			break
+		}
+	}
+	if err := p.expect("on"); err != nil {
+		return err
+	}
+	schema, table, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	td, err := p.table(schema, table)
+	if err != nil {
+		return err
+	}
+	if tr.UpdateOf, err = lookupColumns(&pqt.Table{Name: td.table.Name, Columns: td.columns}, updateOf); err != nil {
+		return err
+	}
+	if p.accept("for") {
+		p.accept("each")
+		switch {
+		case p.accept("row"):
+			tr.ForEachRow = true
+		case p.accept("statement"):
+		default:
+			return fmt.Errorf("expected ROW or STATEMENT, got %s", p.peek())
+		}
+	}
+	if p.accept("when") {
+		if tr.When, _, err = p.parenthesized(); err != nil {
+			return err
+		}
+	}
+	if !p.accept("execute", "function") && !p.accept("execute", "procedure") {
+		return fmt.Errorf("expected EXECUTE FUNCTION, got %s", p.peek())
+	}
+	schema, function, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	if tr.Function, err = p.function(schema, function, nil); err != nil {
+		return err
+	}
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for !p.acceptSymbol(")") {
+		if len(tr.Args) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return err
+			}
+		}
+		tr.Args = append(tr.Args, p.next().value)
+	}
+
+	td.triggers = append(td.triggers, tr)
+	return nil
+}
+
+// dataType parses type name and maps it to the pqt type.
+func (p *parser) createType() error {
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	sd, err := p.schemaOf("type", schema, name)
+	if err != nil {
+		return err
+	}
+	if err := p.expect("as"); err != nil {
+		return err
+	}
+	if p.peek().isSymbol("(") {
+		return p.compositeType(sd, name)
+	}
+	if !p.accept("enum") {
+		return fmt.Errorf("only enumerated and composite types are supported")
+	}
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	var enums []string
+	for !p.acceptSymbol(")") {
+		if len(enums) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return err
+			}
+		}
+		t := p.next()
+		if t.kind != tokenString {
+			return fmt.Errorf("expected enum value, got %s", t)
+		}
+		enums = append(enums, t.value)
+	}
+	sd.types = append(sd.types, pqt.TypeEnumerated(name, enums...))
+	return nil
+}
+
+func (p *parser) createDomain() error {
+	schema, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	sd, err := p.schemaOf("domain", schema, name)
+	if err != nil {
+		return err
+	}
+	p.accept("as")
+	base, err := p.dataType()
+	if err != nil {
+		return err
+	}
+
+	var opts []pqt.DomainOption
+	for !p.peek().isSymbol(";") && p.peek().kind != tokenEOF {
+		t := p.peek()
+		switch {
+		case p.accept("constraint"):
+			// Domain constraints are not named by pqt.
+			if _, err := p.name(); err != nil {
+				return err
+			}
+		case p.accept("not", "null"):
+			opts = append(opts, pqt.WithDomainNotNull())
+		case p.accept("null"):
+		case p.accept("default"):
+			d, _, err := p.expression(func(t token) bool {
+				return t.is("not", "null", "check", "constraint", "collate")
+			})
+			if err != nil {
+				return err
+			}
+			opts = append(opts, pqt.WithDomainDefault(d))
+		case p.accept("check"):
+			check, _, err := p.parenthesized()
+			if err != nil {
+				return err
+			}
+			opts = append(opts, pqt.WithDomainCheck(check))
+		default:
+			return fmt.Errorf("unsupported domain option %s", t)
+		}
+	}
+	sd.types = append(sd.types, pqt.TypeDomain(name, base, opts...))
+	return nil
+}
+
+func (p *parser) createExtension() error {
+	p.accept("if", "not", "exists")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	var opts []pqt.ExtensionOption
+	p.accept("with")
+	if p.accept("schema") {
+		schema, err := p.name()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, pqt.WithExtensionSchema(schema))
+	}
+	if t := p.peek(); !t.isSymbol(";") && t.kind != tokenEOF {
+		return fmt.Errorf("unsupported extension option %s", t)
+	}
+	p.extensions = append(p.extensions, pqt.NewExtension(name, opts...))
+	return nil
+}
+
+func (p *parser) compositeType(sd *schemaDef, name string) error {
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	var attributes []*pqt.Attribute
+	for !p.acceptSymbol(")") {
+		if len(attributes) > 0 {
+			if err := p.expectSymbol(","); err != nil {
+				return err
+			}
+		}
+		an, err := p.name()
+		if err != nil {
+			return err
+		}
+		typ, err := p.dataType()
+		if err != nil {
+			return err
+		}
+		a := &pqt.Attribute{Name: an, Type: typ}
+		if p.accept("collate") {
+			t := p.next()
+			if !t.isName() {
+				return fmt.Errorf("expected collation, got %s", t)
+			}
+			a.Collate = p.src[t.start:t.end]
+		}
+		attributes = append(attributes, a)
+	}
+	sd.types = append(sd.types, pqt.TypeComposite(name, attributes...))
+	return nil
+}
+
+// userType returns type declared by CREATE TYPE statement.
+func (p *parser) userType(schema, name string) (pqt.Type, bool) {
+	sd := p.lookupSchema(schema)
+	if sd == nil {
+		return nil, false
+	}
+	for _, t := range sd.types {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func (p *parser) dataType() (pqt.Type, error) {
+	start := p.peek()
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if p.acceptSymbol(".") {
+		object, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.userType(name, object); ok {
+			return p.arrayOf(t)
+		}
+		raw := p.src[start.start:p.tokens[p.pos-1].end]
+		p.report(start.line, "unsupported type %s", raw)
+		return pqt.TypePseudo(raw), nil
+	}
+	if t, ok := p.userType("", name); ok {
+		return p.arrayOf(t)
+	}
+	switch {
+	case name == "double" && p.accept("precision"):
+		name = "double precision"
+	case (name == "character" || name == "char") && p.accept("varying"):
+		name = "varchar"
+	case name == "bit" && p.accept("varying"):
+		name = "varbit"
+	}
+
+	var args []int
+	if p.acceptSymbol("(") {
+		for !p.acceptSymbol(")") {
+			if len(args) > 0 {
+				if err := p.expectSymbol(","); err != nil {
+					return nil, err
+				}
+			}
+			t := p.next()
+			n, err := strconv.Atoi(t.value)
+			if t.kind != tokenNumber || err != nil {
+				return nil, fmt.Errorf("expected type modifier, got %s", t)
+			}
+			args = append(args, n)
+		}
+	}
+	switch {
+	case (name == "timestamp" || name == "time") && p.accept("with", "time", "zone"):
+		name += "tz"
+	case (name == "timestamp" || name == "time") && p.accept("without", "time", "zone"):
+	}
+
+	dims, err := p.arrayDims()
+	if err != nil {
+		return nil, err
+	}
+	if len(dims) == 1 {
+		// Dedicated array constructors are preferred, so the types stay compatible with the older schemas.
+		if t, ok := baseType(name, args, true, dims[0]); ok {
+			return t, nil
+		}
+	}
+	if t, ok := baseType(name, args, false, 0); ok {
+		if len(dims) > 0 {
+			return pqt.TypeArray(t, dims...), nil
+		}
+		return t, nil
+	}
+	raw := p.src[start.start:p.tokens[p.pos-1].end]
+	p.report(start.line, "unsupported type %s", raw)
+	return pqt.TypePseudo(raw), nil
+}
+
+// arrayDims parses array declaration that can follow a type name.
+// It returns size of each dimension, zero if the size is not specified.
+func (p *parser) arrayDims() ([]int, error) {
+	var dims []int
+	for p.acceptSymbol("[") {
+		var size int
+		if t := p.peek(); t.kind == tokenNumber {
+			n, err := strconv.Atoi(p.next().value)
+			if err != nil {
+				return nil, err
+			}
+			size = n
+		}
+		if err := p.expectSymbol("]"); err != nil {
+			return nil, err
+		}
+		dims = append(dims, size)
+	}
+	return dims, nil
+}
+
+// arrayOf returns array of given type if array declaration follows, otherwise the type itself.
+func (p *parser) arrayOf(t pqt.Type) (pqt.Type, error) {
+	dims, err := p.arrayDims()
+	if err != nil {
+		return nil, err
+	}
+	if len(dims) == 0 {
+		return t, nil
+	}
+	return pqt.TypeArray(t, dims...), nil
+}
+
+func baseType(name string, args []int, array bool, length int) (pqt.Type, bool) {
+	arg := func(i int) int {
+		if len(args) > i {
+			return args[i]
+		}
+		return 0
+	}
+	if array {
+		switch name {
+		case "integer", "int", "int4":
+			return pqt.TypeIntegerArray(length), true
+		case "bigint", "int8":
+			return pqt.TypeIntegerBigArray(length), true
+		case "smallint", "int2":
+			return pqt.TypeIntegerSmallArray(length), true
+		case "double precision", "float8":
+			return pqt.TypeDoubleArray(length), true
+		case "text":
+			return pqt.TypeTextArray(length), true
+		case "character", "char":
+			// pqt renders fixed length character type using brackets.
+			return pqt.TypeCharacter(length), true
+		}
+		return nil, false
+	}
+
+	switch name {
+	case "integer", "int", "int4":
+		return pqt.TypeInteger(), true
+	case "bigint", "int8":
+		return pqt.TypeIntegerBig(), true
+	case "smallint", "int2":
+		return pqt.TypeIntegerSmall(), true
+	case "serial", "serial4":
+		return pqt.TypeSerial(), true
+	case "bigserial", "serial8":
+		return pqt.TypeSerialBig(), true
+	case "smallserial", "serial2":
+		return pqt.TypeSerialSmall(), true
+	case "real", "float4":
+		return pqt.TypeReal(), true
+	case "double precision", "float8":
+		return pqt.TypeDoublePrecision(), true
+	case "decimal":
+		return pqt.TypeDecimal(arg(0), arg(1)), true
+	case "numeric":
+		return pqt.TypeNumeric(arg(0), arg(1)), true
+	case "bool", "boolean":
+		return pqt.TypeBool(), true
+	case "uuid":
+		return pqt.TypeUUID(), true
+	case "character", "char", "bpchar":
+		return pqt.TypeCharacter(arg(0)), true
+	case "varchar":
+		return pqt.TypeVarchar(arg(0)), true
+	case "text":
+		return pqt.TypeText(), true
+	case "bytea":
+		return pqt.TypeBytea(), true
+	case "timestamp":
+		return pqt.TypeTimestamp(), true
+	case "timestamptz":
+		return pqt.TypeTimestampTZ(), true
+	case "date":
+		return pqt.TypeDate(), true
+	case "json":
+		return pqt.TypeJSON(), true
+	case "jsonb":
+		return pqt.TypeJSONB(), true
+	case "interval":
+		return pqt.TypeInterval(), true
+	case "time":
+		return pqt.TypeTime(), true
+	case "timetz":
+		return pqt.TypeTimeTZ(), true
+	case "inet":
+		return pqt.TypeInet(), true
+	case "cidr":
+		return pqt.TypeCIDR(), true
+	case "macaddr":
+		return pqt.TypeMacAddr(), true
+	case "tsvector":
+		return pqt.TypeTSVector(), true
+	case "money":
+		return pqt.TypeMoney(), true
+	case "xml":
+		return pqt.TypeXML(), true
+	case "bit":
+		return pqt.TypeBit(arg(0)), true
+	case "varbit":
+		return pqt.TypeVarbit(arg(0)), true
+	case "citext":
+		return pqt.TypeCIText(), true
+	case "hstore":
+		return pqt.TypeHstore(), true
+	case "int4range":
+		return pqt.TypeInt4Range(), true
+	case "int8range":
+		return pqt.TypeInt8Range(), true
+	case "numrange":
+		return pqt.TypeNumRange(), true
+	case "tsrange":
+		return pqt.TypeTSRange(), true
+	case "tstzrange":
+		return pqt.TypeTSTZRange(), true
+	case "daterange":
+		return pqt.TypeDateRange(), true
+	case "point":
+		return pqt.TypePoint(), true
+	case "line":
+		return pqt.TypeLine(), true
+	case "lseg":
+		return pqt.TypeLineSegment(), true
+	case "box":
+		return pqt.TypeBox(), true
+	case "path":
+		return pqt.TypePath(), true
+	case "polygon":
+		return pqt.TypePolygon(), true
+	case "circle":
+		return pqt.TypeCircle(), true
+	}
+	return nil, false
+}
+
+// build turns collected definitions into schemas, in order of declaration.
+// Constraints are resolved after all tables are known, so foreign keys can reference tables declared later, also in other schemas.
+func (p *parser) build() []*pqt.Schema {
+	schemas := make([]*pqt.Schema, 0, len(p.schemas))
+	for _, sd := range p.schemas {
+		var opts []pqt.SchemaOption
+		if sd.ifNotExists {
+			opts = append(opts, pqt.WithSchemaIfNotExists())
+		}
+		s := pqt.NewSchema(sd.name, opts...)
+		for _, t := range sd.types {
+			s.AddType(t)
+		}
+		for _, f := range sd.functions {
+			s.AddFunction(f)
+		}
+		s.Grants = sd.grants
+
+		for _, name := range sd.order {
+			td := sd.tables[name]
+			for _, c := range td.columns {
+				p.serial(td.table.Name, c)
+				td.table.AddColumn(c)
+			}
+			for _, tr := range td.triggers {
+				td.table.AddTrigger(tr)
+			}
+			s.AddTable(td.table)
+		}
+		schemas = append(schemas, s)
+	}
+
+	// Primary keys go first, foreign keys without explicit column list reference them.
+	for _, primary := range []bool{true, false} {
+		for _, sd := range p.schemas {
+			for _, name := range sd.order {
+				td := sd.tables[name]
+				for _, cd := range td.constraints {
+					if (cd.kind == pqt.ConstraintTypePrimaryKey) != primary {
+						continue
+					}
+					if err := p.buildConstraint(td.table, cd); err != nil {
+						p.errs = append(p.errs, &Error{
+							Line:      cd.line,
+							Statement: cd.statement,
+							Reason:    err.Error(),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	for i, sd := range p.schemas {
+		for _, cd := range sd.comments {
+			if err := p.buildComment(schemas[i], cd); err != nil {
+				p.errs = append(p.errs, &Error{
+					Line:      cd.line,
+					Statement: cd.statement,
+					Reason:    err.Error(),
+				})
+			}
+		}
+	}
+
+	return schemas
+}
+
+// buildComment finds the constraint or index by its name and sets the comment.
+func (p *parser) buildComment(s *pqt.Schema, cd *commentDef) error {
+	for _, t := range s.Tables {
+		if cd.table != "" && t.Name != cd.table {
+			continue
+		}
+		for _, c := range t.Constraints {
+			isIndex := c.Type == pqt.ConstraintTypeIndex || c.Type == pqt.ConstraintTypeUniqueIndex
+			if isIndex == (cd.table == "") && (c.Name() == cd.name || strings.TrimPrefix(c.Name(), s.Name+".") == cd.name) {
+				c.Comment = cd.comment
+				return nil
+			}
+		}
+	}
+	if cd.table == "" {
+		return fmt.Errorf("index %s does not exist", cd.name)
+	}
+	return fmt.Errorf("constraint %s of table %s does not exist", cd.name, cd.table)
+}
+
+// serial converts column that takes its default value from the owned sequence into the serial type.
+func (p *parser) serial(table string, c *pqt.Column) {
+	d, ok := c.DefaultOn(pqt.EventInsert)
+	if !ok || !strings.HasPrefix(d, "nextval(") {
+		return
+	}
+	for seq, owner := range p.sequences {
+		if owner != table+"."+c.Name || !strings.Contains(d, "'"+seq+"'") && !strings.Contains(d, "."+seq+"'") {
+			continue
+		}
+		switch c.Type {
+		case pqt.TypeInteger():
+			c.Type = pqt.TypeSerial()
+		case pqt.TypeIntegerBig():
+			c.Type = pqt.TypeSerialBig()
+		case pqt.TypeIntegerSmall():
+			c.Type = pqt.TypeSerialSmall()
+		default:
+			return
+		}
+		delete(c.Default, pqt.EventInsert)
+		// Serial types are implicitly not null.
+		c.NotNull = false
+		return
+	}
+}
+
+func (p *parser) buildConstraint(t *pqt.Table, cd *constraintDef) error {
+	columns, err := lookupColumns(t, cd.columns)
+	if err != nil {
+		return err
+	}
+
+	var c *pqt.Constraint
+	switch cd.kind {
+	case pqt.ConstraintTypePrimaryKey:
+		c = pqt.PrimaryKey(t, columns...)
+		if len(columns) == 1 {
+			columns[0].PrimaryKey = true
+		}
+	case pqt.ConstraintTypeUnique:
+		c = pqt.Unique(t, columns...)
+	case pqt.ConstraintTypeCheck:
+		if len(columns) == 0 {
+			columns = referencedColumns(t, cd.identifiers)
+		}
+		c = pqt.Check(t, cd.check, columns...)
+		c.NoInherit = cd.noInherit
+	case pqt.ConstraintTypeIndex, pqt.ConstraintTypeUniqueIndex:
+		if c, err = buildIndex(t, cd, columns); err != nil {
+			return err
+		}
+	case pqt.ConstraintTypeExclusion:
+		elements := make([]*pqt.ExclusionElement, 0, len(cd.exclude))
+		for _, el := range cd.exclude {
+			if el.Expression == "" {
+				elements = append(elements, pqt.ExcludeColumn(columns[0], el.Operator))
+				columns = columns[1:]
+				continue
+			}
+			elements = append(elements, el)
+		}
+		c = pqt.Exclusion(t, cd.method, elements...)
+		c.Where = cd.where
+	case pqt.ConstraintTypeForeignKey:
+		ref, ok := cd.refSchema.tables[cd.refTable]
+		if !ok {
+			return fmt.Errorf("referenced table %s does not exist", cd.refTable)
+		}
+		var refColumns pqt.Columns
+		if len(cd.refColumns) == 0 {
+			if refColumns = ref.table.PrimaryKeyColumns(); len(refColumns) == 0 {
+				return fmt.Errorf("referenced table %s has no primary key", cd.refTable)
+			}
+		} else if refColumns, err = lookupColumns(ref.table, cd.refColumns); err != nil {
+			return err
+		}
+		if len(refColumns) != len(columns) {
+			return fmt.Errorf("number of referencing and referenced columns for foreign key disagree")
+		}
+		c = pqt.ForeignKey(columns, refColumns, pqt.WithMatch(cd.match))
+		c.OnDelete = cd.onDelete
+		c.OnUpdate = cd.onUpdate
+
+		t.OwnedRelationships = append(t.OwnedRelationships, &pqt.Relationship{
+			Type:            pqt.RelationshipTypeManyToOne,
+			OwnerTable:      t,
+			InversedTable:   ref.table,
+			OwnerColumns:    columns,
+			InversedColumns: refColumns,
+			OnDelete:        cd.onDelete,
+			OnUpdate:        cd.onUpdate,
+		})
+	}
+	// INITIALLY DEFERRED implies DEFERRABLE.
+	c.DeferrableInitiallyDeferred = cd.initiallyDeferred
+	c.DeferrableInitiallyImmediate = cd.deferrable && !cd.initiallyDeferred
+	// Name is kept only if it differs from the one naming strategy gives.
+	if cd.name != "" && cd.name != c.Name() {
+		c.ExplicitName = cd.name
+	}
+
+	t.AddConstraint(c)
+	return nil
+}
+
+// buildIndex keeps indexes made of plain columns as simple as pqt.Index and pqt.UniqueIndex create them.
+func buildIndex(t *pqt.Table, cd *constraintDef, columns pqt.Columns) (*pqt.Constraint, error) {
+	var (
+		plain = true
+		next  int
+	)
+	for _, el := range cd.elements {
+		if el.Expression == "" {
+			el.Column = columns[next]
+			next++
+		}
+		plain = plain && el.Column != nil && el.OpClass == "" && !el.Descending && el.Nulls == pqt.NullsDefault
+	}
+	include, err := lookupColumns(t, cd.include)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *pqt.Constraint
+	if plain {
+		c = pqt.Index(t, columns...)
+	} else {
+		c = pqt.IndexOn(t, cd.elements)
+	}
+	c.Type = cd.kind
+	c.Where = cd.where
+	c.Method = cd.method
+	c.Include = include
+	c.Concurrently = cd.concurrently
+	return c, nil
+}
+
+func lookupColumns(t *pqt.Table, names []string) (pqt.Columns, error) {
+	columns := make(pqt.Columns, 0, len(names))
+NamesLoop:
+	for _, name := range names {
+		for _, c := range t.Columns {
+			if c.Name == name {
+				columns = append(columns, c)
+				continue NamesLoop
+			}
+		}
+		return nil, fmt.Errorf("column %s of table %s does not exist", name, t.Name)
+	}
+	return columns, nil
+}
+
+// referencedColumns returns columns of the table that appear in given identifiers, in order of appearance.
+func referencedColumns(t *pqt.Table, identifiers []string) pqt.Columns {
+	var columns pqt.Columns
+IdentifiersLoop:
+	for _, id := range identifiers {
+		for _, c := range columns {
+			if c.Name == id {
+				continue IdentifiersLoop
+			}
+		}
+		for _, c := range t.Columns {
+			if c.Name == id {
+				columns = append(columns, c)
+			}
+		}
+	}
+	return columns
+}
+
+func or(s1, s2 string) string {
+	if s1 == "" {
+		return s2
+	}
+	return s1
+}
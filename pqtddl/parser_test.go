@@ -0,0 +1,483 @@
+package pqtddl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtddl"
+	"github.com/piotrkowalczuk/pqt/pqtsql"
+)
+
+func TestParseString(t *testing.T) {
+	given := `
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE SCHEMA IF NOT EXISTS example;
+
+CREATE EXTENSION IF NOT EXISTS citext WITH SCHEMA example;
+
+CREATE DOMAIN example.email AS citext CONSTRAINT email_check CHECK (VALUE ~ '@') NOT NULL;
+
+CREATE SEQUENCE example.news_id_seq;
+
+CREATE TABLE example.news (
+	id bigint DEFAULT nextval('example.news_id_seq'::regclass) NOT NULL,
+	title character varying(255) NOT NULL,
+	lead text,
+	contact example.email,
+	score numeric(10, 2) DEFAULT 0 CHECK (score >= 0),
+	tags text[],
+	labels uuid[],
+	matrix integer[3][3],
+	published_at timestamp with time zone,
+	reading_time time with time zone,
+	flags bit varying(4),
+	during tstzrange,
+	author_id integer REFERENCES example."user" MATCH SIMPLE ON DELETE CASCADE INITIALLY DEFERRED,
+	CONSTRAINT news_pkey PRIMARY KEY (id)
+);
+
+ALTER SEQUENCE example.news_id_seq OWNED BY example.news.id;
+
+CREATE TABLE example."user" (
+	id SERIAL PRIMARY KEY,
+	email TEXT NOT NULL UNIQUE
+);
+
+CREATE UNIQUE INDEX news_title_uidx ON example.news USING btree (title) WHERE published_at IS NOT NULL;
+ALTER TABLE ONLY example.news ALTER COLUMN lead SET DEFAULT '';
+`
+	s, err := pqtddl.ParseString(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if s.Name != "example" || !s.IfNotExists {
+		t.Errorf("wrong schema: %s (if not exists: %t)", s.Name, s.IfNotExists)
+	}
+	if len(s.Tables) != 2 {
+		t.Fatalf("wrong number of tables, expected 2 but got %d", len(s.Tables))
+	}
+
+	news := s.Tables[0]
+	expected := map[string]string{
+		"author_id":    "INTEGER",
+		"contact":      "email",
+		"during":       "TSTZRANGE",
+		"flags":        "VARBIT(4)",
+		"id":           "BIGSERIAL",
+		"labels":       "UUID[]",
+		"matrix":       "INTEGER[3][3]",
+		"lead":         "TEXT",
+		"published_at": "TIMESTAMPTZ",
+		"reading_time": "TIMETZ",
+		"score":        "NUMERIC(10,2)",
+		"tags":         "TEXT[]",
+		"title":        "VARCHAR(255)",
+	}
+	if len(news.Columns) != len(expected) {
+		t.Fatalf("wrong number of columns, expected %d but got %d", len(expected), len(news.Columns))
+	}
+	for _, c := range news.Columns {
+		if c.Type.String() != expected[c.Name] {
+			t.Errorf("column %s has wrong type, expected %s but got %s", c.Name, expected[c.Name], c.Type.String())
+		}
+		if c.Name == "matrix" {
+			if at, ok := c.Type.(pqt.ArrayType); !ok || at.Elem != pqt.TypeInteger() || at.Dimensions() != 2 {
+				t.Errorf("column matrix should be two dimensional array of integers, got %#v", c.Type)
+			}
+		}
+	}
+	if pk, ok := news.PrimaryKey(); !ok || pk.Name != "id" {
+		t.Errorf("missing primary key")
+	}
+	if len(news.OwnedRelationships) != 1 || news.OwnedRelationships[0].InversedTable != s.Tables[1] {
+		t.Errorf("missing relationship")
+	}
+
+	types := make(map[pqt.ConstraintType]int)
+	for _, c := range news.Constraints {
+		types[c.Type]++
+		if c.Type == pqt.ConstraintTypeForeignKey && (c.Match != pqt.MatchSimple || !c.DeferrableInitiallyDeferred) {
+			t.Errorf("foreign key should be match simple and initially deferred")
+		}
+	}
+	if len(s.Extensions) != 1 || s.Extensions[0].Name != "citext" || s.Extensions[0].Schema != "example" {
+		t.Errorf("wrong extensions: %v", s.Extensions)
+	}
+	if len(s.Types) != 1 {
+		t.Fatalf("wrong number of types, expected 1 but got %d", len(s.Types))
+	}
+	if dt, ok := s.Types[0].(pqt.DomainType); !ok || dt.Base != pqt.TypeCIText() || !dt.NotNull || len(dt.Checks) != 1 || dt.Checks[0] != "VALUE ~ '@'" {
+		t.Errorf("wrong domain: %#v", s.Types[0])
+	}
+	for _, typ := range []pqt.ConstraintType{
+		pqt.ConstraintTypePrimaryKey,
+		pqt.ConstraintTypeCheck,
+		pqt.ConstraintTypeForeignKey,
+		pqt.ConstraintTypeUniqueIndex,
+	} {
+		if types[typ] != 1 {
+			t.Errorf("expected single %s constraint, got %d", typ, types[typ])
+		}
+	}
+}
+
+func TestParseString_roundTrip(t *testing.T) {
+	schema := func() *pqt.Schema {
+		status := pqt.TypeEnumerated("status", "active", "it's banned")
+		email := pqt.TypeDomain("email", pqt.TypeVarchar(255), pqt.WithDomainDefault("''"), pqt.WithDomainNotNull(), pqt.WithDomainCheck("VALUE ~ '@'"))
+		address := pqt.TypeComposite("address",
+			&pqt.Attribute{Name: "street", Type: pqt.TypeText(), Collate: "C"},
+			&pqt.Attribute{Name: "number", Type: pqt.TypeInteger()},
+		)
+		id := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+		user := pqt.NewTable("user", pqt.WithTableIfNotExists(), pqt.WithTableComment("registered user")).
+			AddColumn(id).
+			AddColumn(pqt.NewColumn("status", status, pqt.WithNotNull(), pqt.WithDefault("'active'"))).
+			AddColumn(pqt.NewColumn("address", address)).
+			AddColumn(pqt.NewColumn("email", email)).
+			AddColumn(pqt.NewColumn("history", pqt.TypeArray(status))).
+			AddColumn(pqt.NewColumn("logins", pqt.TypeArray(pqt.TypeTimestampTZ(), 0, 2))).
+			AddColumn(pqt.NewColumn("username", pqt.TypeVarchar(50), pqt.WithNotNull(), pqt.WithUnique(), pqt.WithComment("user's login"))).
+			AddColumn(pqt.NewColumn("first_name", pqt.TypeText(), pqt.WithCollate("C"))).
+			AddColumn(pqt.NewColumn("number", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityAlways))).
+			AddColumn(pqt.NewColumn("login", pqt.TypeText(), pqt.WithGenerated("lower(username)"), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull(), pqt.WithDefault("NOW()"))).
+			AddColumn(pqt.NewColumn("age", pqt.TypeInteger(), pqt.WithCheck("age > 0"), pqt.WithIndex()))
+		title := pqt.NewColumn("title", pqt.TypeText(), pqt.WithNotNull())
+		userID := pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(id), pqt.WithOnDelete(pqt.Cascade),
+			pqt.WithReferenceMatch(pqt.MatchFull), pqt.WithReferenceInitiallyDeferred())
+		code := pqt.NewColumn("code", pqt.TypeCharacter(3))
+		ratings := pqt.NewColumn("ratings", pqt.TypeIntegerArray(0))
+		post := pqt.NewTable("post").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())).
+			AddColumn(title).
+			AddColumn(code).
+			AddColumn(pqt.NewColumn("price", pqt.TypeDecimal(10, 2))).
+			AddColumn(ratings).
+			AddColumn(userID)
+		unique := pqt.UniqueOn(post, pqt.Columns{title, userID}, pqt.WithDeferrable())
+		unique.Comment = "one title per user"
+		post.AddConstraint(unique).
+			AddUniqueIndex("", "title IS NOT NULL", userID).
+			AddExclusion("gist", "price > 0", pqt.ExcludeColumn(userID, "="), pqt.ExcludeExpression("lower(code)", "<>")).
+			AddConstraint(pqt.IndexOn(post, []*pqt.IndexElement{pqt.IndexColumn(ratings)}, pqt.WithIndexMethod("gin"), pqt.WithConstraintComment("ratings lookup"))).
+			AddConstraint(pqt.IndexOn(post, []*pqt.IndexElement{
+				pqt.IndexExpression("lower(title)", pqt.WithOpClass("text_pattern_ops")),
+				pqt.IndexColumn(userID, pqt.WithDescending(), pqt.WithNulls(pqt.NullsLast)),
+			}, pqt.WithInclude(code), pqt.WithWhere("price > 0"), pqt.WithConcurrently(), pqt.WithUniqueIndex("")))
+		occurredAt := pqt.NewColumn("occurred_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+		event := pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByRange, occurredAt)).
+			AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+			AddColumn(pqt.NewColumn("duration", pqt.TypeInterval())).
+			AddColumn(pqt.NewColumn("source", pqt.TypeInet())).
+			AddColumn(pqt.NewColumn("location", pqt.TypePoint())).
+			AddColumn(pqt.NewColumn("attributes", pqt.TypeHstore())).
+			AddColumn(pqt.NewColumn("flags", pqt.TypeBit(8))).
+			AddColumn(pqt.NewColumn("seats", pqt.TypeInt4Range())).
+			AddColumn(occurredAt).
+			AddPartition(pqt.PartitionRange("event_2020", "'2020-01-01'", "'2021-01-01'")).
+			AddPartition(pqt.PartitionDefault("event_other"))
+		archive := pqt.NewTable("post_archive", pqt.WithInherits(post)).
+			AddColumn(pqt.NewColumn("archived_at", pqt.TypeTimestampTZ(), pqt.WithCheck("archived_at IS NOT NULL"), pqt.WithNoInherit()))
+		touch := &pqt.Function{
+			Name:            "touch",
+			Type:            pqt.TypeTrigger(),
+			Language:        pqt.FunctionLanguagePLpgSQL,
+			Body:            "BEGIN NEW.title := trim(NEW.title); RETURN NEW; END;",
+			SecurityDefiner: true,
+			Comment:         "trims the title",
+		}
+		post.AddTrigger(pqt.NewTrigger("post_touch", pqt.TriggerTimingBefore, touch,
+			pqt.WithTriggerEvents(pqt.EventInsert, pqt.EventUpdate),
+			pqt.WithUpdateOf(title),
+			pqt.WithForEachRow(),
+			pqt.WithWhen("NEW.title IS NOT NULL"),
+			pqt.WithTriggerArgs("it's", "x"),
+		))
+		post.EnableRowLevelSecurity = true
+		post.ForceRowLevelSecurity = true
+		post.AddPolicy(pqt.NewPolicy("post_owner", pqt.WithUsing("user_id = current_setting('app.user')::bigint")))
+		post.AddPolicy(pqt.NewPolicy("post_insert",
+			pqt.WithPolicyCommand(pqt.PolicyCommandInsert),
+			pqt.WithPolicyRoles("editor", "PUBLIC"),
+			pqt.WithPolicyCheck("price > 0"),
+			pqt.WithRestrictive(),
+		))
+		post.AddGrant(pqt.NewGrant("editor", pqt.PrivilegeSelect, pqt.PrivilegeInsert))
+		post.AddGrant(pqt.NewGrant("auditor", pqt.PrivilegeSelect, pqt.PrivilegeUpdate).OnColumns(title).WithGrantOption())
+		user.AddGrant(pqt.NewGrant("PUBLIC", pqt.PrivilegeAll))
+		touch.Grants = []*pqt.Grant{pqt.NewGrant("editor", pqt.PrivilegeExecute)}
+
+		return pqt.NewSchema("example", pqt.WithSchemaIfNotExists(), pqt.WithSchemaExtension("uuid-ossp", pqt.WithExtensionSchema("example"))).
+			AddGrant(pqt.NewGrant("editor", pqt.PrivilegeUsage)).
+			AddType(status).
+			AddType(email).
+			AddType(address).
+			AddTable(user).
+			AddTable(post).
+			AddTable(event).
+			AddTable(archive).
+			AddFunction(pqt.FunctionNow()).
+			AddFunction(touch).
+			AddFunction(&pqt.Function{
+				Name:      "user_ids",
+				Type:      pqt.TypeIntegerBig(),
+				SetOf:     true,
+				Body:      "SELECT id FROM example.user",
+				Behaviour: pqt.FunctionBehaviourStable,
+			}).
+			AddFunction(&pqt.Function{
+				Name: "titles",
+				ReturnsTable: []*pqt.FunctionArg{
+					{Name: "id", Type: pqt.TypeInteger()},
+					{Name: "title", Type: pqt.TypeText()},
+				},
+				Body: "SELECT id, title FROM example.post",
+			})
+	}
+
+	g := &pqtsql.Generator{Version: 9.5}
+	expected, err := g.Generate(schema())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	parsed, err := pqtddl.ParseString(string(expected))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	got, err := g.Generate(parsed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != string(expected) {
+		t.Errorf("wrong output, expected:\n%s\nbut got:\n%s", expected, got)
+	}
+}
+
+func TestParseString_constraintNames(t *testing.T) {
+	given := `CREATE SCHEMA app;
+
+CREATE TABLE app.users (
+	id BIGINT CONSTRAINT users_pkey PRIMARY KEY,
+	email TEXT CONSTRAINT users_email_nn NOT NULL,
+	login TEXT,
+	CONSTRAINT users_email_uq UNIQUE (email),
+	CONSTRAINT "app.users_login_key" UNIQUE (login)
+);
+
+CREATE INDEX users_lower_email_idx ON app.users (lower(email));
+`
+	s, err := pqtddl.ParseString(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := map[string]pqt.ConstraintType{
+		"users_pkey":            pqt.ConstraintTypePrimaryKey,
+		"users_email_uq":        pqt.ConstraintTypeUnique,
+		"app.users_login_key":   pqt.ConstraintTypeUnique,
+		"users_lower_email_idx": pqt.ConstraintTypeIndex,
+	}
+	constraints := s.Tables[0].Constraints
+	if len(constraints) != len(expected) {
+		t.Fatalf("wrong number of constraints, expected %d but got %d", len(expected), len(constraints))
+	}
+	for _, c := range constraints {
+		typ, ok := expected[c.Name()]
+		if !ok {
+			t.Errorf("unexpected constraint %s", c.Name())
+			continue
+		}
+		if c.Type != typ {
+			t.Errorf("%s: wrong type, expected %s but got %s", c.Name(), typ, c.Type)
+		}
+		// Name that matches the generated one is not kept explicitly.
+		if c.Name() == "app.users_login_key" && c.ExplicitName != "" {
+			t.Errorf("%s: name should be generated", c.Name())
+		}
+	}
+	if !s.IsUnique("users_email_uq") || !s.IsPrimaryKey("users_pkey") {
+		t.Error("explicit names should be recognized")
+	}
+}
+
+func TestParseString_columnOrder(t *testing.T) {
+	given := `CREATE SCHEMA app;
+
+CREATE TABLE app.users (
+	id BIGINT,
+	login TEXT NOT NULL,
+	email TEXT,
+	created_at TIMESTAMPTZ
+);
+`
+	s, err := pqtddl.ParseString(given)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA app; 
+
+CREATE TABLE app.users (
+	id BIGINT,
+	login TEXT NOT NULL,
+	email TEXT,
+	created_at TIMESTAMPTZ
+);
+
+-- sql schema end
+`
+	if string(got) != expected {
+		t.Errorf("wrong output, expected:\n%s\nbut got:\n%s", expected, got)
+	}
+}
+
+func TestParseString_errors(t *testing.T) {
+	given := `CREATE TABLE account (
+	id BIGSERIAL PRIMARY KEY,
+	period TSQUERY
+);
+
+CREATE VIEW account_view AS SELECT * FROM account;
+
+CREATE INDEX account_lower_idx ON account (lower(period)) WITH (fillfactor = 70);
+
+CREATE TABLE invoice (
+	id BIGSERIAL PRIMARY KEY,
+	account_id BIGINT REFERENCES missing (id)
+);
+
+COMMENT ON COLUMN account.missing IS 'gone';
+
+GRANT SELECT (missing) ON account TO app;
+`
+	s, err := pqtddl.ParseString(given)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	errs, ok := err.(pqtddl.Errors)
+	if !ok {
+		t.Fatalf("wrong error type: %T", err)
+	}
+
+	expected := []struct {
+		line      int
+		statement string
+		reason    string
+	}{
+		{line: 3, statement: "CREATE TABLE account", reason: "unsupported type TSQUERY"},
+		{line: 6, statement: "CREATE VIEW account_view", reason: "unsupported statement CREATE VIEW"},
+		{line: 8, statement: "CREATE INDEX account_lower_idx", reason: "index storage parameters and tablespace are not supported"},
+		{line: 15, statement: "COMMENT ON COLUMN account.missing", reason: "column missing of table account does not exist"},
+		{line: 17, statement: "GRANT SELECT (missing)", reason: "column missing of table account does not exist"},
+		{line: 12, statement: "CREATE TABLE invoice", reason: "referenced table missing does not exist"},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("wrong number of errors, expected %d but got %d: %s", len(expected), len(errs), errs.Error())
+	}
+	for i, exp := range expected {
+		if errs[i].Line != exp.line {
+			t.Errorf("%d: wrong line, expected %d but got %d", i, exp.line, errs[i].Line)
+		}
+		if !strings.HasPrefix(errs[i].Statement, exp.statement) {
+			t.Errorf("%d: wrong statement, expected to start with %q but got %q", i, exp.statement, errs[i].Statement)
+		}
+		if errs[i].Reason != exp.reason {
+			t.Errorf("%d: wrong reason, expected %q but got %q", i, exp.reason, errs[i].Reason)
+		}
+	}
+
+	if len(s.Tables) != 2 {
+		t.Errorf("statements that are supported should be part of the schema, expected 2 tables but got %d", len(s.Tables))
+	}
+}
+
+func TestParseDatabaseString_roundTrip(t *testing.T) {
+	database := func() *pqt.Database {
+		status := pqt.TypeEnumerated("status", "active", "banned")
+		userID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+		user := pqt.NewTable("user").
+			AddColumn(userID).
+			AddColumn(pqt.NewColumn("status", status, pqt.WithNotNull()))
+		invoice := pqt.NewTable("invoice").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(userID), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("user_status", status))
+		post := pqt.NewTable("post").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("author_id", pqt.TypeIntegerBig(), pqt.WithReference(userID)))
+
+		return pqt.NewDatabase(pqt.WithExtension("pgcrypto")).
+			AddSchema(pqt.NewSchema("").AddTable(post)).
+			AddSchema(pqt.NewSchema("auth").AddType(status).AddTable(user)).
+			AddSchema(pqt.NewSchema("billing").AddTable(invoice))
+	}
+
+	g := &pqtsql.Generator{Version: 9.5}
+	expected, err := g.GenerateDatabase(database())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	parsed, err := pqtddl.ParseDatabaseString(string(expected))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(parsed.Schemas) != 3 {
+		t.Fatalf("wrong number of schemas, expected 3 but got %d", len(parsed.Schemas))
+	}
+	got, err := g.GenerateDatabase(parsed)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != string(expected) {
+		t.Errorf("wrong output, expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	if _, err := pqtddl.ParseString(string(expected)); err == nil {
+		t.Error("expected error, multiple schemas are not supported by ParseString")
+	}
+}
+
+func TestParseDatabaseString_errors(t *testing.T) {
+	given := `CREATE SCHEMA auth;
+
+CREATE TABLE billing.invoice (
+	id BIGSERIAL PRIMARY KEY
+);
+
+CREATE SCHEMA auth;
+`
+	_, err := pqtddl.ParseDatabaseString(given)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	errs, ok := err.(pqtddl.Errors)
+	if !ok {
+		t.Fatalf("wrong error type: %T", err)
+	}
+
+	expected := []string{
+		"table billing.invoice is outside of declared schemas",
+		"schema auth already exists",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("wrong number of errors, expected %d but got %d: %s", len(expected), len(errs), errs.Error())
+	}
+	for i, exp := range expected {
+		if errs[i].Reason != exp {
+			t.Errorf("%d: wrong reason, expected %q but got %q", i, exp, errs[i].Reason)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package pqtfile
+
+// version is a version of the file format.
+const version = 1
+
+type databaseDecl struct {
+	Version    int              `json:"version" yaml:"version"`
+	Extensions []*extensionDecl `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	Schemas    []*schemaDecl    `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+}
+
+type schemaDecl struct {
+	// Version is omitted by schemas that are part of a database.
+	Version     int              `json:"version,omitempty" yaml:"version,omitempty"`
+	Name        string           `json:"name,omitempty" yaml:"name,omitempty"`
+	IfNotExists bool             `json:"ifNotExists,omitempty" yaml:"ifNotExists,omitempty"`
+	QuoteAlways bool             `json:"quoteAlways,omitempty" yaml:"quoteAlways,omitempty"`
+	Extensions  []*extensionDecl `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	Types       []*typeDecl      `json:"types,omitempty" yaml:"types,omitempty"`
+	Functions   []*functionDecl  `json:"functions,omitempty" yaml:"functions,omitempty"`
+	Tables      []*tableDecl     `json:"tables,omitempty" yaml:"tables,omitempty"`
+	Views       []*viewDecl      `json:"views,omitempty" yaml:"views,omitempty"`
+	Grants      []*grantDecl     `json:"grants,omitempty" yaml:"grants,omitempty"`
+
+	// DeclaredColumnOrder if true means that columns of tables that do not set their own order are kept in order they are listed.
+	DeclaredColumnOrder bool `json:"declaredColumnOrder,omitempty" yaml:"declaredColumnOrder,omitempty"`
+}
+
+type extensionDecl struct {
+	Name   string `json:"name" yaml:"name"`
+	Schema string `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+type viewDecl struct {
+	Name         string         `json:"name" yaml:"name"`
+	Query        string         `json:"query" yaml:"query"`
+	Materialized bool           `json:"materialized,omitempty" yaml:"materialized,omitempty"`
+	Columns      []*columnDecl  `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Triggers     []*triggerDecl `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+}
+
+type triggerDecl struct {
+	Name   string   `json:"name" yaml:"name"`
+	Timing string   `json:"timing" yaml:"timing"`
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+	// UpdateOf are names of the columns of the table trigger is attached to.
+	UpdateOf   []string `json:"updateOf,omitempty" yaml:"updateOf,omitempty"`
+	ForEachRow bool     `json:"forEachRow,omitempty" yaml:"forEachRow,omitempty"`
+	When       string   `json:"when,omitempty" yaml:"when,omitempty"`
+	// Function is a name of the schema function.
+	Function string   `json:"function" yaml:"function"`
+	Args     []string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+type tableDecl struct {
+	Name        string `json:"name" yaml:"name"`
+	ShortName   string `json:"shortName,omitempty" yaml:"shortName,omitempty"`
+	Collate     string `json:"collate,omitempty" yaml:"collate,omitempty"`
+	TableSpace  string `json:"tableSpace,omitempty" yaml:"tableSpace,omitempty"`
+	IfNotExists bool   `json:"ifNotExists,omitempty" yaml:"ifNotExists,omitempty"`
+	Temporary   bool   `json:"temporary,omitempty" yaml:"temporary,omitempty"`
+	// DeclaredColumnOrder if true means that columns are kept in order they are listed, if false they are sorted by name.
+	// Order of the schema is used if not set.
+	DeclaredColumnOrder *bool `json:"declaredColumnOrder,omitempty" yaml:"declaredColumnOrder,omitempty"`
+
+	Columns     []*columnDecl     `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Constraints []*constraintDecl `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+	// Relationships are relationships owned by the table.
+	Relationships []*relationshipDecl `json:"relationships,omitempty" yaml:"relationships,omitempty"`
+	// InversedRelationships and ManyToManyRelationships point to relationships owned by other tables.
+	InversedRelationships   []relationshipRef `json:"inversedRelationships,omitempty" yaml:"inversedRelationships,omitempty"`
+	ManyToManyRelationships []relationshipRef `json:"manyToManyRelationships,omitempty" yaml:"manyToManyRelationships,omitempty"`
+	Triggers                []*triggerDecl    `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+
+	PartitionBy  string           `json:"partitionBy,omitempty" yaml:"partitionBy,omitempty"`
+	PartitionKey []string         `json:"partitionKey,omitempty" yaml:"partitionKey,omitempty"`
+	Partitions   []*partitionDecl `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+	// Inherits is a list of names of parent tables.
+	Inherits []string `json:"inherits,omitempty" yaml:"inherits,omitempty"`
+	Comment  string   `json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	RowLevelSecurity      bool          `json:"rowLevelSecurity,omitempty" yaml:"rowLevelSecurity,omitempty"`
+	ForceRowLevelSecurity bool          `json:"forceRowLevelSecurity,omitempty" yaml:"forceRowLevelSecurity,omitempty"`
+	Policies              []*policyDecl `json:"policies,omitempty" yaml:"policies,omitempty"`
+	Grants                []*grantDecl  `json:"grants,omitempty" yaml:"grants,omitempty"`
+}
+
+type policyDecl struct {
+	Name        string   `json:"name" yaml:"name"`
+	Command     string   `json:"command,omitempty" yaml:"command,omitempty"`
+	Restrictive bool     `json:"restrictive,omitempty" yaml:"restrictive,omitempty"`
+	Roles       []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+	Using       string   `json:"using,omitempty" yaml:"using,omitempty"`
+	WithCheck   string   `json:"withCheck,omitempty" yaml:"withCheck,omitempty"`
+}
+
+type grantDecl struct {
+	Role       string   `json:"role" yaml:"role"`
+	Privileges []string `json:"privileges" yaml:"privileges"`
+	// Columns are names of the columns of the table privileges are given on.
+	Columns     []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	GrantOption bool     `json:"grantOption,omitempty" yaml:"grantOption,omitempty"`
+}
+
+type partitionDecl struct {
+	Name    string `json:"name" yaml:"name"`
+	Bound   string `json:"bound,omitempty" yaml:"bound,omitempty"`
+	Default bool   `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+type columnDecl struct {
+	Name       string            `json:"name" yaml:"name"`
+	ShortName  string            `json:"shortName,omitempty" yaml:"shortName,omitempty"`
+	Type       *typeDecl         `json:"type,omitempty" yaml:"type,omitempty"`
+	Collate    string            `json:"collate,omitempty" yaml:"collate,omitempty"`
+	Check      string            `json:"check,omitempty" yaml:"check,omitempty"`
+	Default    map[string]string `json:"default,omitempty" yaml:"default,omitempty"`
+	NotNull    bool              `json:"notNull,omitempty" yaml:"notNull,omitempty"`
+	Unique     bool              `json:"unique,omitempty" yaml:"unique,omitempty"`
+	PrimaryKey bool              `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
+	Index      bool              `json:"index,omitempty" yaml:"index,omitempty"`
+	// Reference is a column in format <table>.<column>.
+	Reference                    string        `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Match                        string        `json:"match,omitempty" yaml:"match,omitempty"`
+	OnDelete                     string        `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
+	OnUpdate                     string        `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+	NoInherit                    bool          `json:"noInherit,omitempty" yaml:"noInherit,omitempty"`
+	DeferrableInitiallyDeferred  bool          `json:"deferrableInitiallyDeferred,omitempty" yaml:"deferrableInitiallyDeferred,omitempty"`
+	DeferrableInitiallyImmediate bool          `json:"deferrableInitiallyImmediate,omitempty" yaml:"deferrableInitiallyImmediate,omitempty"`
+	Dynamic                      bool          `json:"dynamic,omitempty" yaml:"dynamic,omitempty"`
+	Function                     *functionDecl `json:"function,omitempty" yaml:"function,omitempty"`
+	// Columns are arguments of the dynamic column function, in format <table>.<column>.
+	Columns []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	// Identity is either ALWAYS or BY DEFAULT.
+	Identity  string `json:"identity,omitempty" yaml:"identity,omitempty"`
+	Generated string `json:"generated,omitempty" yaml:"generated,omitempty"`
+	Comment   string `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Position  int    `json:"position,omitempty" yaml:"position,omitempty"`
+}
+
+type constraintDecl struct {
+	Type string `json:"type" yaml:"type"`
+	// Name is set only if constraint has an explicit name.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// PrimaryTable is set only if it differs from the table that holds the constraint.
+	PrimaryTable string   `json:"primaryTable,omitempty" yaml:"primaryTable,omitempty"`
+	Columns      []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	// Table and ReferencedColumns describe columns referenced by foreign key.
+	Table                        string   `json:"table,omitempty" yaml:"table,omitempty"`
+	ReferencedColumns            []string `json:"referencedColumns,omitempty" yaml:"referencedColumns,omitempty"`
+	Where                        string   `json:"where,omitempty" yaml:"where,omitempty"`
+	Check                        string   `json:"check,omitempty" yaml:"check,omitempty"`
+	Match                        string   `json:"match,omitempty" yaml:"match,omitempty"`
+	OnDelete                     string   `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
+	OnUpdate                     string   `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+	NoInherit                    bool     `json:"noInherit,omitempty" yaml:"noInherit,omitempty"`
+	DeferrableInitiallyDeferred  bool     `json:"deferrableInitiallyDeferred,omitempty" yaml:"deferrableInitiallyDeferred,omitempty"`
+	DeferrableInitiallyImmediate bool     `json:"deferrableInitiallyImmediate,omitempty" yaml:"deferrableInitiallyImmediate,omitempty"`
+	MethodSuffix                 string   `json:"methodSuffix,omitempty" yaml:"methodSuffix,omitempty"`
+
+	Method       string                  `json:"method,omitempty" yaml:"method,omitempty"`
+	Exclude      []*exclusionElementDecl `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+	Elements     []*indexElementDecl     `json:"elements,omitempty" yaml:"elements,omitempty"`
+	Include      []string                `json:"include,omitempty" yaml:"include,omitempty"`
+	Concurrently bool                    `json:"concurrently,omitempty" yaml:"concurrently,omitempty"`
+	Comment      string                  `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+// indexElementDecl describes an element of an index, either Column or Expression is set.
+type indexElementDecl struct {
+	Column     string `json:"column,omitempty" yaml:"column,omitempty"`
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+	OpClass    string `json:"opClass,omitempty" yaml:"opClass,omitempty"`
+	Descending bool   `json:"descending,omitempty" yaml:"descending,omitempty"`
+	// Nulls is either first or last.
+	Nulls string `json:"nulls,omitempty" yaml:"nulls,omitempty"`
+}
+
+// exclusionElementDecl describes an element of exclusion constraint, either Column or Expression is set.
+type exclusionElementDecl struct {
+	Column     string `json:"column,omitempty" yaml:"column,omitempty"`
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+	Operator   string `json:"operator" yaml:"operator"`
+}
+
+type relationshipDecl struct {
+	Type          string `json:"type" yaml:"type"`
+	Bidirectional bool   `json:"bidirectional,omitempty" yaml:"bidirectional,omitempty"`
+	OwnerName     string `json:"ownerName,omitempty" yaml:"ownerName,omitempty"`
+	InversedName  string `json:"inversedName,omitempty" yaml:"inversedName,omitempty"`
+	ColumnName    string `json:"columnName,omitempty" yaml:"columnName,omitempty"`
+	OwnerTable    string `json:"ownerTable,omitempty" yaml:"ownerTable,omitempty"`
+	InversedTable string `json:"inversedTable,omitempty" yaml:"inversedTable,omitempty"`
+	ThroughTable  string `json:"throughTable,omitempty" yaml:"throughTable,omitempty"`
+	// OwnerColumns and InversedColumns are in format <table>.<column>.
+	OwnerColumns       []string        `json:"ownerColumns,omitempty" yaml:"ownerColumns,omitempty"`
+	InversedColumns    []string        `json:"inversedColumns,omitempty" yaml:"inversedColumns,omitempty"`
+	OwnerForeignKey    *constraintDecl `json:"ownerForeignKey,omitempty" yaml:"ownerForeignKey,omitempty"`
+	InversedForeignKey *constraintDecl `json:"inversedForeignKey,omitempty" yaml:"inversedForeignKey,omitempty"`
+	OnDelete           string          `json:"onDelete,omitempty" yaml:"onDelete,omitempty"`
+	OnUpdate           string          `json:"onUpdate,omitempty" yaml:"onUpdate,omitempty"`
+}
+
+// relationshipRef points to the relationship owned by the table.
+type relationshipRef struct {
+	Table string `json:"table" yaml:"table"`
+	// Index is a position of the relationship within relationships of the table.
+	Index int `json:"index" yaml:"index"`
+}
+
+type functionDecl struct {
+	Name      string             `json:"name" yaml:"name"`
+	BuiltIn   bool               `json:"builtIn,omitempty" yaml:"builtIn,omitempty"`
+	Type      *typeDecl          `json:"type,omitempty" yaml:"type,omitempty"`
+	Body      string             `json:"body,omitempty" yaml:"body,omitempty"`
+	Behaviour string             `json:"behaviour,omitempty" yaml:"behaviour,omitempty"`
+	Args      []*functionArgDecl `json:"args,omitempty" yaml:"args,omitempty"`
+
+	SetOf           bool               `json:"setOf,omitempty" yaml:"setOf,omitempty"`
+	ReturnsTable    []*functionArgDecl `json:"returnsTable,omitempty" yaml:"returnsTable,omitempty"`
+	Language        string             `json:"language,omitempty" yaml:"language,omitempty"`
+	SecurityDefiner bool               `json:"securityDefiner,omitempty" yaml:"securityDefiner,omitempty"`
+	Comment         string             `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Grants          []*grantDecl       `json:"grants,omitempty" yaml:"grants,omitempty"`
+}
+
+type functionArgDecl struct {
+	Name string    `json:"name" yaml:"name"`
+	Type *typeDecl `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+// typeDecl is a union of all supported types. Exactly one field is expected to be set.
+type typeDecl struct {
+	Base       string          `json:"base,omitempty" yaml:"base,omitempty"`
+	Pseudo     string          `json:"pseudo,omitempty" yaml:"pseudo,omitempty"`
+	Enumerated *enumeratedDecl `json:"enumerated,omitempty" yaml:"enumerated,omitempty"`
+	Composite  *compositeDecl  `json:"composite,omitempty" yaml:"composite,omitempty"`
+	Mappable   *mappableDecl   `json:"mappable,omitempty" yaml:"mappable,omitempty"`
+	Array      *arrayDecl      `json:"array,omitempty" yaml:"array,omitempty"`
+	Domain     *domainDecl     `json:"domain,omitempty" yaml:"domain,omitempty"`
+	GoBuiltin  string          `json:"goBuiltin,omitempty" yaml:"goBuiltin,omitempty"`
+	GoCustom   *goCustomDecl   `json:"goCustom,omitempty" yaml:"goCustom,omitempty"`
+}
+
+type enumeratedDecl struct {
+	Name  string   `json:"name" yaml:"name"`
+	Enums []string `json:"enums,omitempty" yaml:"enums,omitempty"`
+}
+
+type compositeDecl struct {
+	Name       string           `json:"name" yaml:"name"`
+	Attributes []*attributeDecl `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+type attributeDecl struct {
+	Name       string    `json:"name" yaml:"name"`
+	Type       *typeDecl `json:"type,omitempty" yaml:"type,omitempty"`
+	Collate    string    `json:"collate,omitempty" yaml:"collate,omitempty"`
+	Default    string    `json:"default,omitempty" yaml:"default,omitempty"`
+	Check      string    `json:"check,omitempty" yaml:"check,omitempty"`
+	NotNull    bool      `json:"notNull,omitempty" yaml:"notNull,omitempty"`
+	Unique     bool      `json:"unique,omitempty" yaml:"unique,omitempty"`
+	PrimaryKey bool      `json:"primaryKey,omitempty" yaml:"primaryKey,omitempty"`
+}
+
+type mappableDecl struct {
+	From    *typeDecl   `json:"from" yaml:"from"`
+	Mapping []*typeDecl `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+}
+
+type domainDecl struct {
+	Name    string    `json:"name" yaml:"name"`
+	Base    *typeDecl `json:"base" yaml:"base"`
+	Default string    `json:"default,omitempty" yaml:"default,omitempty"`
+	NotNull bool      `json:"notNull,omitempty" yaml:"notNull,omitempty"`
+	Checks  []string  `json:"checks,omitempty" yaml:"checks,omitempty"`
+}
+
+type arrayDecl struct {
+	Elem *typeDecl `json:"elem" yaml:"elem"`
+	Dims []int     `json:"dims,omitempty" yaml:"dims,omitempty"`
+}
+
+type goCustomDecl struct {
+	Mandatory *goTypeDecl `json:"mandatory,omitempty" yaml:"mandatory,omitempty"`
+	Optional  *goTypeDecl `json:"optional,omitempty" yaml:"optional,omitempty"`
+	Criteria  *goTypeDecl `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+}
+
+type goTypeDecl struct {
+	Name     string `json:"name" yaml:"name"`
+	PkgPath  string `json:"pkgPath,omitempty" yaml:"pkgPath,omitempty"`
+	Kind     string `json:"kind" yaml:"kind"`
+	Nullable bool   `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+}
@@ -0,0 +1,820 @@
+package pqtfile
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtgo"
+)
+
+type decoder struct {
+	schema *pqt.Schema
+	tables map[string]*pqt.Table
+	// schemas are decoders of all schemas of the database by name, nil if schema is decoded on its own.
+	schemas map[string]*decoder
+}
+
+func decodeDatabase(decl *databaseDecl) (*pqt.Database, error) {
+	if decl.Version != version {
+		return nil, fmt.Errorf("unsupported database file version: %d", decl.Version)
+	}
+
+	db := pqt.NewDatabase()
+	for _, ed := range decl.Extensions {
+		db.AddExtension(decodeExtension(ed))
+	}
+
+	// Tables of all schemas need to exist before references between schemas can be resolved.
+	schemas := make(map[string]*decoder, len(decl.Schemas))
+	decoders := make([]*decoder, 0, len(decl.Schemas))
+	for _, sd := range decl.Schemas {
+		name := or(sd.Name, pqt.DefaultSchema)
+		if sd.Version != 0 && sd.Version != version {
+			return nil, fmt.Errorf("schema %s: unsupported schema file version: %d", name, sd.Version)
+		}
+		if _, ok := schemas[name]; ok {
+			return nil, fmt.Errorf("schema %s declared more than once", name)
+		}
+		d, err := newDecoder(sd)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %s", name, err.Error())
+		}
+		d.schemas = schemas
+		schemas[name] = d
+		decoders = append(decoders, d)
+		db.AddSchema(d.schema)
+	}
+	for i, d := range decoders {
+		if err := d.resolveTables(decl.Schemas[i]); err != nil {
+			return nil, fmt.Errorf("schema %s: %s", or(d.schema.Name, pqt.DefaultSchema), err.Error())
+		}
+	}
+	for i, d := range decoders {
+		if err := d.resolveSchema(decl.Schemas[i]); err != nil {
+			return nil, fmt.Errorf("schema %s: %s", or(d.schema.Name, pqt.DefaultSchema), err.Error())
+		}
+	}
+	return db, nil
+}
+
+func decodeSchema(decl *schemaDecl) (*pqt.Schema, error) {
+	if decl.Version != version {
+		return nil, fmt.Errorf("unsupported schema file version: %d", decl.Version)
+	}
+
+	d, err := newDecoder(decl)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.resolveTables(decl); err != nil {
+		return nil, err
+	}
+	if err := d.resolveSchema(decl); err != nil {
+		return nil, err
+	}
+	return d.schema, nil
+}
+
+// newDecoder decodes everything that does not reference other tables, including tables and their columns.
+func newDecoder(decl *schemaDecl) (*decoder, error) {
+	var opts []pqt.SchemaOption
+	if decl.IfNotExists {
+		opts = append(opts, pqt.WithSchemaIfNotExists())
+	}
+	if decl.QuoteAlways {
+		opts = append(opts, pqt.WithQuotingPolicy(pqt.QuoteAlways))
+	}
+	if decl.DeclaredColumnOrder {
+		opts = append(opts, pqt.WithDefaultColumnOrder(pqt.ColumnOrderDeclared))
+	}
+	d := &decoder{
+		schema: pqt.NewSchema(decl.Name, opts...),
+		tables: make(map[string]*pqt.Table, len(decl.Tables)),
+	}
+
+	for _, ed := range decl.Extensions {
+		d.schema.AddExtension(decodeExtension(ed))
+	}
+	for _, td := range decl.Types {
+		t, err := decodeType(td)
+		if err != nil {
+			return nil, err
+		}
+		d.schema.Types = append(d.schema.Types, t)
+	}
+	for _, fd := range decl.Functions {
+		f, err := decodeFunction(fd)
+		if err != nil {
+			return nil, err
+		}
+		d.schema.AddFunction(f)
+	}
+
+	// Tables and their columns need to exist before any reference can be resolved.
+	for _, td := range decl.Tables {
+		if _, ok := d.tables[td.Name]; ok {
+			return nil, fmt.Errorf("table %s declared more than once", td.Name)
+		}
+		t, err := decodeTable(td)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %s", td.Name, err.Error())
+		}
+		d.tables[t.Name] = t
+		d.schema.AddTable(t)
+		// Columns are listed in order of declaration, once the order is known they can be sorted.
+		t.Columns.Sort(t.ColumnOrder)
+	}
+	return d, nil
+}
+
+// resolveTables resolves references of the tables, relationships they own included.
+func (d *decoder) resolveTables(decl *schemaDecl) error {
+	for _, td := range decl.Tables {
+		if err := d.resolveTable(td); err != nil {
+			return fmt.Errorf("table %s: %s", td.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// resolveSchema resolves references to relationships owned by other tables and decodes remaining objects.
+func (d *decoder) resolveSchema(decl *schemaDecl) error {
+	for _, td := range decl.Tables {
+		if err := d.resolveRelationshipRefs(td); err != nil {
+			return fmt.Errorf("table %s: %s", td.Name, err.Error())
+		}
+	}
+	for _, vd := range decl.Views {
+		v, err := decodeView(vd)
+		if err != nil {
+			return fmt.Errorf("view %s: %s", vd.Name, err.Error())
+		}
+		for _, td := range vd.Triggers {
+			tr, err := d.decodeTrigger(td, nil)
+			if err != nil {
+				return fmt.Errorf("view %s: %s", vd.Name, err.Error())
+			}
+			v.AddTrigger(tr)
+		}
+		d.schema.AddView(v)
+	}
+	grants, err := decodeGrants(nil, decl.Grants)
+	if err != nil {
+		return err
+	}
+	d.schema.Grants = grants
+	return nil
+}
+
+func decodeExtension(decl *extensionDecl) *pqt.Extension {
+	var opts []pqt.ExtensionOption
+	if decl.Schema != "" {
+		opts = append(opts, pqt.WithExtensionSchema(decl.Schema))
+	}
+	return pqt.NewExtension(decl.Name, opts...)
+}
+
+func decodeTable(decl *tableDecl) (*pqt.Table, error) {
+	var opts []pqt.TableOption
+	if decl.DeclaredColumnOrder != nil {
+		opts = append(opts, pqt.WithColumnOrder(columnOrder(*decl.DeclaredColumnOrder)))
+	}
+	t := pqt.NewTable(decl.Name, opts...)
+	if decl.ShortName != "" {
+		t.ShortName = decl.ShortName
+	}
+	t.Collate = decl.Collate
+	t.TableSpace = decl.TableSpace
+	t.IfNotExists = decl.IfNotExists
+	t.Temporary = decl.Temporary
+	t.Comment = decl.Comment
+
+	for _, cd := range decl.Columns {
+		c, err := decodeColumn(cd)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %s", cd.Name, err.Error())
+		}
+		// Columns are added directly, constraints they imply are part of the declaration already.
+		c.Table = t
+		t.Columns = append(t.Columns, c)
+	}
+	return t, nil
+}
+
+func columnOrder(declared bool) pqt.ColumnOrder {
+	if declared {
+		return pqt.ColumnOrderDeclared
+	}
+	return pqt.ColumnOrderAlphabetical
+}
+
+func decodeView(decl *viewDecl) (*pqt.View, error) {
+	var opts []pqt.ViewOption
+	if decl.Materialized {
+		opts = append(opts, pqt.WithMaterialized())
+	}
+	v := pqt.NewView(decl.Name, decl.Query, opts...)
+
+	for _, cd := range decl.Columns {
+		c, err := decodeColumn(cd)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %s", cd.Name, err.Error())
+		}
+		v.AddColumn(c)
+	}
+	return v, nil
+}
+
+func decodeColumn(decl *columnDecl) (*pqt.Column, error) {
+	typ, err := decodeType(decl.Type)
+	if err != nil {
+		return nil, err
+	}
+	c := &pqt.Column{
+		Name:                         decl.Name,
+		ShortName:                    decl.ShortName,
+		Type:                         typ,
+		Collate:                      decl.Collate,
+		Check:                        decl.Check,
+		NotNull:                      decl.NotNull,
+		Unique:                       decl.Unique,
+		PrimaryKey:                   decl.PrimaryKey,
+		Index:                        decl.Index,
+		NoInherit:                    decl.NoInherit,
+		DeferrableInitiallyDeferred:  decl.DeferrableInitiallyDeferred,
+		DeferrableInitiallyImmediate: decl.DeferrableInitiallyImmediate,
+		IsDynamic:                    decl.Dynamic,
+		Identity:                     pqt.Identity(decl.Identity),
+		Generated:                    decl.Generated,
+		Comment:                      decl.Comment,
+		Position:                     decl.Position,
+	}
+	if len(decl.Default) > 0 {
+		c.Default = make(map[pqt.Event]string, len(decl.Default))
+		for ev, d := range decl.Default {
+			c.Default[pqt.Event(ev)] = d
+		}
+	}
+	if c.Match, err = decodeMatch(decl.Match); err != nil {
+		return nil, err
+	}
+	if c.OnDelete, err = decodeAction(decl.OnDelete); err != nil {
+		return nil, err
+	}
+	if c.OnUpdate, err = decodeAction(decl.OnUpdate); err != nil {
+		return nil, err
+	}
+	if decl.Function != nil {
+		if c.Func, err = decodeFunction(decl.Function); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// resolveTable fills in everything that references other tables or columns.
+func (d *decoder) resolveTable(decl *tableDecl) error {
+	t := d.tables[decl.Name]
+
+	for i, cd := range decl.Columns {
+		c := t.Columns[i]
+		if cd.Reference != "" {
+			ref, err := d.columnRef(cd.Reference)
+			if err != nil {
+				return fmt.Errorf("column %s: %s", c.Name, err.Error())
+			}
+			c.Reference = ref
+		}
+		columns, err := d.columnRefs(cd.Columns)
+		if err != nil {
+			return fmt.Errorf("column %s: %s", c.Name, err.Error())
+		}
+		c.Columns = columns
+	}
+	for _, cd := range decl.Constraints {
+		c, err := d.decodeConstraint(t, cd)
+		if err != nil {
+			return err
+		}
+		t.Constraints = append(t.Constraints, c)
+	}
+	for _, rd := range decl.Relationships {
+		r, err := d.decodeRelationship(rd)
+		if err != nil {
+			return err
+		}
+		t.OwnedRelationships = append(t.OwnedRelationships, r)
+	}
+	for _, td := range decl.Triggers {
+		tr, err := d.decodeTrigger(td, t)
+		if err != nil {
+			return err
+		}
+		t.AddTrigger(tr)
+	}
+	key, err := columnsByName(t, decl.PartitionKey)
+	if err != nil {
+		return fmt.Errorf("partition key: %s", err.Error())
+	}
+	t.PartitionBy = pqt.PartitionStrategy(decl.PartitionBy)
+	t.PartitionKey = key
+	for _, pd := range decl.Partitions {
+		t.AddPartition(&pqt.Partition{
+			Name:    pd.Name,
+			Bound:   pd.Bound,
+			Default: pd.Default,
+		})
+	}
+	for _, name := range decl.Inherits {
+		p, err := d.table(name)
+		if err != nil {
+			return fmt.Errorf("inherits: %s", err.Error())
+		}
+		t.Inherits = append(t.Inherits, p)
+	}
+	t.EnableRowLevelSecurity = decl.RowLevelSecurity
+	t.ForceRowLevelSecurity = decl.ForceRowLevelSecurity
+	for _, pd := range decl.Policies {
+		t.AddPolicy(&pqt.Policy{
+			Name:        pd.Name,
+			Command:     pqt.PolicyCommand(pd.Command),
+			Restrictive: pd.Restrictive,
+			Roles:       pd.Roles,
+			Using:       pd.Using,
+			WithCheck:   pd.WithCheck,
+		})
+	}
+	if t.Grants, err = decodeGrants(t, decl.Grants); err != nil {
+		return err
+	}
+	return nil
+}
+
+// decodeGrants decodes privileges, columns are looked up in the table if given.
+func decodeGrants(t *pqt.Table, decls []*grantDecl) ([]*pqt.Grant, error) {
+	var grants []*pqt.Grant
+	for _, decl := range decls {
+		g := &pqt.Grant{
+			Role:        decl.Role,
+			GrantOption: decl.GrantOption,
+		}
+		for _, p := range decl.Privileges {
+			g.Privileges = append(g.Privileges, pqt.Privilege(p))
+		}
+		columns, err := columnsByName(t, decl.Columns)
+		if err != nil {
+			return nil, fmt.Errorf("grant to %s: %s", decl.Role, err.Error())
+		}
+		g.Columns = columns
+		grants = append(grants, g)
+	}
+	return grants, nil
+}
+
+// decodeTrigger decodes trigger, columns it updates are looked up in the holder table if given.
+func (d *decoder) decodeTrigger(decl *triggerDecl, holder *pqt.Table) (*pqt.Trigger, error) {
+	var f *pqt.Function
+	for _, sf := range d.schema.Functions {
+		if sf.Name == decl.Function {
+			f = sf
+		}
+	}
+	if f == nil {
+		return nil, fmt.Errorf("trigger %s: unknown function: %s", decl.Name, decl.Function)
+	}
+	tr := pqt.NewTrigger(decl.Name, 0, f, pqt.WithTriggerArgs(decl.Args...))
+	found := false
+	for t, name := range timings {
+		if name == decl.Timing {
+			tr.Timing = t
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("trigger %s: unknown timing: %s", decl.Name, decl.Timing)
+	}
+	for _, e := range decl.Events {
+		tr.Events = append(tr.Events, pqt.Event(e))
+	}
+	tr.ForEachRow = decl.ForEachRow
+	tr.When = decl.When
+	if len(decl.UpdateOf) > 0 {
+		if holder == nil {
+			return nil, fmt.Errorf("trigger %s: update columns are supported only by tables", decl.Name)
+		}
+		columns, err := columnsByName(holder, decl.UpdateOf)
+		if err != nil {
+			return nil, fmt.Errorf("trigger %s: %s", decl.Name, err.Error())
+		}
+		tr.UpdateOf = columns
+	}
+	return tr, nil
+}
+
+func (d *decoder) resolveRelationshipRefs(decl *tableDecl) error {
+	t := d.tables[decl.Name]
+
+	for _, ref := range decl.InversedRelationships {
+		r, err := d.relationshipRef(ref)
+		if err != nil {
+			return err
+		}
+		t.InversedRelationships = append(t.InversedRelationships, r)
+	}
+	for _, ref := range decl.ManyToManyRelationships {
+		r, err := d.relationshipRef(ref)
+		if err != nil {
+			return err
+		}
+		t.ManyToManyRelationships = append(t.ManyToManyRelationships, r)
+	}
+	return nil
+}
+
+func (d *decoder) decodeConstraint(holder *pqt.Table, decl *constraintDecl) (*pqt.Constraint, error) {
+	c := &pqt.Constraint{
+		Type:                         pqt.ConstraintType(decl.Type),
+		PrimaryTable:                 holder,
+		ExplicitName:                 decl.Name,
+		Where:                        decl.Where,
+		Check:                        decl.Check,
+		NoInherit:                    decl.NoInherit,
+		DeferrableInitiallyDeferred:  decl.DeferrableInitiallyDeferred,
+		DeferrableInitiallyImmediate: decl.DeferrableInitiallyImmediate,
+		MethodSuffix:                 decl.MethodSuffix,
+		Method:                       decl.Method,
+		Concurrently:                 decl.Concurrently,
+		Comment:                      decl.Comment,
+	}
+
+	var err error
+	if c.Match, err = decodeMatch(decl.Match); err != nil {
+		return nil, err
+	}
+	if c.OnDelete, err = decodeAction(decl.OnDelete); err != nil {
+		return nil, err
+	}
+	if c.OnUpdate, err = decodeAction(decl.OnUpdate); err != nil {
+		return nil, err
+	}
+	if decl.PrimaryTable != "" {
+		if c.PrimaryTable, err = d.table(decl.PrimaryTable); err != nil {
+			return nil, err
+		}
+	}
+	if c.PrimaryColumns, err = columnsByName(c.PrimaryTable, decl.Columns); err != nil {
+		return nil, err
+	}
+	if decl.Table != "" {
+		if c.Table, err = d.table(decl.Table); err != nil {
+			return nil, err
+		}
+	}
+	if c.Columns, err = columnsByName(c.Table, decl.ReferencedColumns); err != nil {
+		return nil, err
+	}
+	for _, elDecl := range decl.Exclude {
+		el := &pqt.ExclusionElement{Expression: elDecl.Expression, Operator: elDecl.Operator}
+		if elDecl.Column != "" {
+			columns, err := columnsByName(c.PrimaryTable, []string{elDecl.Column})
+			if err != nil {
+				return nil, err
+			}
+			el.Column = columns[0]
+		}
+		c.Exclude = append(c.Exclude, el)
+	}
+	for _, elDecl := range decl.Elements {
+		el := &pqt.IndexElement{
+			Expression: elDecl.Expression,
+			OpClass:    elDecl.OpClass,
+			Descending: elDecl.Descending,
+		}
+		if elDecl.Nulls != "" {
+			found := false
+			for nulls, name := range nullsOrders {
+				if name == elDecl.Nulls {
+					el.Nulls = nulls
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unknown nulls order: %s", elDecl.Nulls)
+			}
+		}
+		if elDecl.Column != "" {
+			columns, err := columnsByName(c.PrimaryTable, []string{elDecl.Column})
+			if err != nil {
+				return nil, err
+			}
+			el.Column = columns[0]
+		}
+		c.Elements = append(c.Elements, el)
+	}
+	if c.Include, err = columnsByName(c.PrimaryTable, decl.Include); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (d *decoder) decodeRelationship(decl *relationshipDecl) (*pqt.Relationship, error) {
+	r := &pqt.Relationship{
+		Bidirectional: decl.Bidirectional,
+		OwnerName:     decl.OwnerName,
+		InversedName:  decl.InversedName,
+		ColumnName:    decl.ColumnName,
+	}
+
+	found := false
+	for typ, name := range relationshipTypes {
+		if name == decl.Type {
+			r.Type = typ
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown relationship type: %s", decl.Type)
+	}
+
+	var err error
+	for _, t := range []struct {
+		table **pqt.Table
+		ref   string
+	}{
+		{table: &r.OwnerTable, ref: decl.OwnerTable},
+		{table: &r.InversedTable, ref: decl.InversedTable},
+		{table: &r.ThroughTable, ref: decl.ThroughTable},
+	} {
+		if t.ref == "" {
+			continue
+		}
+		if *t.table, err = d.table(t.ref); err != nil {
+			return nil, err
+		}
+	}
+	if r.OwnerColumns, err = d.columnRefs(decl.OwnerColumns); err != nil {
+		return nil, err
+	}
+	if r.InversedColumns, err = d.columnRefs(decl.InversedColumns); err != nil {
+		return nil, err
+	}
+	if decl.OwnerForeignKey != nil {
+		if r.OwnerForeignKey, err = d.decodeConstraint(nil, decl.OwnerForeignKey); err != nil {
+			return nil, err
+		}
+	}
+	if decl.InversedForeignKey != nil {
+		if r.InversedForeignKey, err = d.decodeConstraint(nil, decl.InversedForeignKey); err != nil {
+			return nil, err
+		}
+	}
+	if r.OnDelete, err = decodeAction(decl.OnDelete); err != nil {
+		return nil, err
+	}
+	if r.OnUpdate, err = decodeAction(decl.OnUpdate); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// table looks up the table by name, tables of other schemas of the database are referenced in format <schema>.<table>.
+func (d *decoder) table(name string) (*pqt.Table, error) {
+	if t, ok := d.tables[name]; ok {
+		return t, nil
+	}
+	if i := strings.Index(name, "."); i >= 0 && d.schemas != nil {
+		if sd, ok := d.schemas[name[:i]]; ok {
+			if t, ok := sd.tables[name[i+1:]]; ok {
+				return t, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("table %s does not exist", name)
+}
+
+func (d *decoder) relationshipRef(ref relationshipRef) (*pqt.Relationship, error) {
+	t, err := d.table(ref.Table)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Index < 0 || ref.Index >= len(t.OwnedRelationships) {
+		return nil, fmt.Errorf("table %s has no relationship at index %d", ref.Table, ref.Index)
+	}
+	return t.OwnedRelationships[ref.Index], nil
+}
+
+func (d *decoder) columnRef(ref string) (*pqt.Column, error) {
+	i := strings.LastIndex(ref, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("column reference %s is not in format <table>.<column>", ref)
+	}
+	t, err := d.table(ref[:i])
+	if err != nil {
+		return nil, err
+	}
+	columns, err := columnsByName(t, []string{ref[i+1:]})
+	if err != nil {
+		return nil, err
+	}
+	return columns[0], nil
+}
+
+func (d *decoder) columnRefs(refs []string) (pqt.Columns, error) {
+	var columns pqt.Columns
+	for _, ref := range refs {
+		c, err := d.columnRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+func columnsByName(t *pqt.Table, names []string) (pqt.Columns, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if t == nil {
+		return nil, fmt.Errorf("columns %s given without a table", strings.Join(names, ", "))
+	}
+
+	columns := make(pqt.Columns, 0, len(names))
+NamesLoop:
+	for _, name := range names {
+		for _, c := range t.Columns {
+			if c.Name == name {
+				columns = append(columns, c)
+				continue NamesLoop
+			}
+		}
+		return nil, fmt.Errorf("column %s of table %s does not exist", name, t.Name)
+	}
+	return columns, nil
+}
+
+func decodeAction(name string) (int32, error) {
+	if name == "" {
+		return 0, nil
+	}
+	for a, n := range actions {
+		if n == name {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown referential action: %s", name)
+}
+
+func decodeMatch(name string) (int32, error) {
+	if name == "" {
+		return pqt.MatchDefault, nil
+	}
+	for m, n := range matches {
+		if n == name {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown match type: %s", name)
+}
+
+func decodeFunction(decl *functionDecl) (*pqt.Function, error) {
+	f := &pqt.Function{
+		Name:            decl.Name,
+		BuiltIn:         decl.BuiltIn,
+		Body:            decl.Body,
+		SetOf:           decl.SetOf,
+		Language:        decl.Language,
+		SecurityDefiner: decl.SecurityDefiner,
+		Comment:         decl.Comment,
+	}
+
+	found := decl.Behaviour == ""
+	for b, name := range behaviours {
+		if name == decl.Behaviour {
+			f.Behaviour = b
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("function %s: unknown behaviour: %s", decl.Name, decl.Behaviour)
+	}
+
+	var err error
+	if f.Type, err = decodeType(decl.Type); err != nil {
+		return nil, fmt.Errorf("function %s: %s", decl.Name, err.Error())
+	}
+	for _, ad := range decl.Args {
+		typ, err := decodeType(ad.Type)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %s", decl.Name, err.Error())
+		}
+		f.Args = append(f.Args, &pqt.FunctionArg{Name: ad.Name, Type: typ})
+	}
+	for _, ad := range decl.ReturnsTable {
+		typ, err := decodeType(ad.Type)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %s", decl.Name, err.Error())
+		}
+		f.ReturnsTable = append(f.ReturnsTable, &pqt.FunctionArg{Name: ad.Name, Type: typ})
+	}
+	if f.Grants, err = decodeGrants(nil, decl.Grants); err != nil {
+		return nil, fmt.Errorf("function %s: %s", decl.Name, err.Error())
+	}
+	return f, nil
+}
+
+func decodeType(decl *typeDecl) (pqt.Type, error) {
+	switch {
+	case decl == nil:
+		return nil, nil
+	case decl.Base != "":
+		return pqt.TypeBase(decl.Base), nil
+	case decl.Pseudo != "":
+		return pqt.TypePseudo(decl.Pseudo), nil
+	case decl.Enumerated != nil:
+		return pqt.TypeEnumerated(decl.Enumerated.Name, decl.Enumerated.Enums...), nil
+	case decl.Composite != nil:
+		var attributes []*pqt.Attribute
+		for _, ad := range decl.Composite.Attributes {
+			typ, err := decodeType(ad.Type)
+			if err != nil {
+				return nil, err
+			}
+			attributes = append(attributes, &pqt.Attribute{
+				Name:       ad.Name,
+				Type:       typ,
+				Collate:    ad.Collate,
+				Default:    ad.Default,
+				Check:      ad.Check,
+				NotNull:    ad.NotNull,
+				Unique:     ad.Unique,
+				PrimaryKey: ad.PrimaryKey,
+			})
+		}
+		return pqt.TypeComposite(decl.Composite.Name, attributes...), nil
+	case decl.Mappable != nil:
+		from, err := decodeType(decl.Mappable.From)
+		if err != nil {
+			return nil, err
+		}
+		var mapping []pqt.Type
+		for _, md := range decl.Mappable.Mapping {
+			typ, err := decodeType(md)
+			if err != nil {
+				return nil, err
+			}
+			mapping = append(mapping, typ)
+		}
+		return pqt.TypeMappable(from, mapping...), nil
+	case decl.Domain != nil:
+		base, err := decodeType(decl.Domain.Base)
+		if err != nil {
+			return nil, err
+		}
+		opts := []pqt.DomainOption{pqt.WithDomainDefault(decl.Domain.Default)}
+		if decl.Domain.NotNull {
+			opts = append(opts, pqt.WithDomainNotNull())
+		}
+		for _, c := range decl.Domain.Checks {
+			opts = append(opts, pqt.WithDomainCheck(c))
+		}
+		return pqt.TypeDomain(decl.Domain.Name, base, opts...), nil
+	case decl.Array != nil:
+		elem, err := decodeType(decl.Array.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return pqt.TypeArray(elem, decl.Array.Dims...), nil
+	case decl.GoBuiltin != "":
+		for k := types.Bool; k <= types.String; k++ {
+			if bt := pqtgo.BuiltinType(k); bt.String() == decl.GoBuiltin {
+				return bt, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown go builtin type: %s", decl.GoBuiltin)
+	case decl.GoCustom != nil:
+		var modes [3]*pqtgo.GoType
+		for i, gd := range []*goTypeDecl{decl.GoCustom.Mandatory, decl.GoCustom.Optional, decl.GoCustom.Criteria} {
+			if gd == nil {
+				continue
+			}
+			kind, ok := kinds[gd.Kind]
+			if !ok {
+				return nil, fmt.Errorf("unknown kind of go type %s: %s", gd.Name, gd.Kind)
+			}
+			modes[i] = &pqtgo.GoType{
+				Name:     gd.Name,
+				PkgPath:  gd.PkgPath,
+				Kind:     kind,
+				Nullable: gd.Nullable,
+			}
+		}
+		return pqtgo.TypeCustomDeclared(modes[0], modes[1], modes[2]), nil
+	default:
+		return nil, fmt.Errorf("empty type declaration")
+	}
+}
@@ -0,0 +1,746 @@
+package pqtfile
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtgo"
+)
+
+var (
+	actions = map[int32]string{
+		pqt.NoAction:   "noAction",
+		pqt.Restrict:   "restrict",
+		pqt.Cascade:    "cascade",
+		pqt.SetNull:    "setNull",
+		pqt.SetDefault: "setDefault",
+	}
+	matches = map[int32]string{
+		pqt.MatchSimple: "SIMPLE",
+		pqt.MatchFull:   "FULL",
+	}
+	relationshipTypes = map[pqt.RelationshipType]string{
+		pqt.RelationshipTypeOneToOne:   "oneToOne",
+		pqt.RelationshipTypeOneToMany:  "oneToMany",
+		pqt.RelationshipTypeManyToOne:  "manyToOne",
+		pqt.RelationshipTypeManyToMany: "manyToMany",
+	}
+	behaviours = map[pqt.FunctionBehaviour]string{
+		pqt.FunctionBehaviourVolatile:  "volatile",
+		pqt.FunctionBehaviourImmutable: "immutable",
+		pqt.FunctionBehaviourStable:    "stable",
+	}
+	timings = map[pqt.TriggerTiming]string{
+		pqt.TriggerTimingBefore:    "before",
+		pqt.TriggerTimingAfter:     "after",
+		pqt.TriggerTimingInsteadOf: "insteadOf",
+	}
+	nullsOrders = map[pqt.NullsOrder]string{
+		pqt.NullsFirst: "first",
+		pqt.NullsLast:  "last",
+	}
+)
+
+type encoder struct {
+	schema *pqt.Schema
+	// database is set only if schema is encoded as a part of the database, tables of other schemas can be referenced then.
+	database *pqt.Database
+	// relationships maps relationships to the table that owns them and their position within it.
+	relationships map[*pqt.Relationship]ownedRelationship
+}
+
+type ownedRelationship struct {
+	table *pqt.Table
+	index int
+}
+
+func encodeDatabase(d *pqt.Database) (*databaseDecl, error) {
+	if d == nil {
+		return nil, fmt.Errorf("missing database")
+	}
+
+	decl := &databaseDecl{Version: version}
+	for _, ext := range d.Extensions {
+		decl.Extensions = append(decl.Extensions, &extensionDecl{Name: ext.Name, Schema: ext.Schema})
+	}
+	relationships := make(map[*pqt.Relationship]ownedRelationship)
+	for _, s := range d.Schemas {
+		collectRelationships(relationships, s)
+	}
+	for _, s := range d.Schemas {
+		e := &encoder{
+			schema:        s,
+			database:      d,
+			relationships: relationships,
+		}
+		sd, err := e.encodeSchema()
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %s", or(s.Name, pqt.DefaultSchema), err.Error())
+		}
+		decl.Schemas = append(decl.Schemas, sd)
+	}
+	return decl, nil
+}
+
+func encodeSchema(s *pqt.Schema) (*schemaDecl, error) {
+	if s == nil {
+		return nil, fmt.Errorf("missing schema")
+	}
+
+	e := &encoder{
+		schema:        s,
+		relationships: make(map[*pqt.Relationship]ownedRelationship),
+	}
+	collectRelationships(e.relationships, s)
+
+	decl, err := e.encodeSchema()
+	if err != nil {
+		return nil, err
+	}
+	decl.Version = version
+	return decl, nil
+}
+
+func collectRelationships(relationships map[*pqt.Relationship]ownedRelationship, s *pqt.Schema) {
+	for _, t := range s.Tables {
+		for i, r := range t.OwnedRelationships {
+			relationships[r] = ownedRelationship{table: t, index: i}
+		}
+	}
+}
+
+func (e *encoder) encodeSchema() (*schemaDecl, error) {
+	s := e.schema
+	decl := &schemaDecl{
+		Name:        s.Name,
+		IfNotExists: s.IfNotExists,
+		QuoteAlways: s.QuotingPolicy == pqt.QuoteAlways,
+
+		DeclaredColumnOrder: s.ColumnOrder == pqt.ColumnOrderDeclared,
+	}
+
+	for _, ext := range s.Extensions {
+		decl.Extensions = append(decl.Extensions, &extensionDecl{Name: ext.Name, Schema: ext.Schema})
+	}
+	for _, t := range s.Types {
+		td, err := encodeType(t)
+		if err != nil {
+			return nil, err
+		}
+		decl.Types = append(decl.Types, td)
+	}
+	for _, f := range s.Functions {
+		fd, err := encodeFunction(f)
+		if err != nil {
+			return nil, err
+		}
+		decl.Functions = append(decl.Functions, fd)
+	}
+	for _, t := range s.Tables {
+		td, err := e.encodeTable(t)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %s", t.Name, err.Error())
+		}
+		decl.Tables = append(decl.Tables, td)
+	}
+	for _, v := range s.Views {
+		vd, err := e.encodeView(v)
+		if err != nil {
+			return nil, fmt.Errorf("view %s: %s", v.Name, err.Error())
+		}
+		decl.Views = append(decl.Views, vd)
+	}
+	grants, err := encodeGrants(nil, s.Grants)
+	if err != nil {
+		return nil, err
+	}
+	decl.Grants = grants
+
+	return decl, nil
+}
+
+func (e *encoder) encodeTable(t *pqt.Table) (*tableDecl, error) {
+	decl := &tableDecl{
+		Name:        t.Name,
+		Collate:     t.Collate,
+		TableSpace:  t.TableSpace,
+		IfNotExists: t.IfNotExists,
+		Temporary:   t.Temporary,
+		Comment:     t.Comment,
+	}
+	// Order is written only if it is not inherited from the schema.
+	if t.ColumnOrder != e.schema.ColumnOrder {
+		declared := t.ColumnOrder == pqt.ColumnOrderDeclared
+		decl.DeclaredColumnOrder = &declared
+	}
+	if t.ShortName != t.Name {
+		decl.ShortName = t.ShortName
+	}
+
+	for _, c := range t.Columns {
+		cd, err := e.encodeColumn(c)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %s", c.Name, err.Error())
+		}
+		decl.Columns = append(decl.Columns, cd)
+	}
+	for _, c := range t.Constraints {
+		cd, err := e.encodeConstraint(t, c)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %s: %s", c.Name(), err.Error())
+		}
+		decl.Constraints = append(decl.Constraints, cd)
+	}
+	triggers, err := encodeTriggers(t.Triggers)
+	if err != nil {
+		return nil, err
+	}
+	decl.Triggers = triggers
+	decl.PartitionBy = string(t.PartitionBy)
+	if decl.PartitionKey, err = columnNames(t, t.PartitionKey); err != nil {
+		return nil, fmt.Errorf("partition key: %s", err.Error())
+	}
+	for _, p := range t.Partitions {
+		decl.Partitions = append(decl.Partitions, &partitionDecl{
+			Name:    p.Name,
+			Bound:   p.Bound,
+			Default: p.Default,
+		})
+	}
+	for _, p := range t.Inherits {
+		decl.Inherits = append(decl.Inherits, p.Name)
+	}
+	decl.RowLevelSecurity = t.EnableRowLevelSecurity
+	decl.ForceRowLevelSecurity = t.ForceRowLevelSecurity
+	for _, p := range t.Policies {
+		decl.Policies = append(decl.Policies, &policyDecl{
+			Name:        p.Name,
+			Command:     string(p.Command),
+			Restrictive: p.Restrictive,
+			Roles:       p.Roles,
+			Using:       p.Using,
+			WithCheck:   p.WithCheck,
+		})
+	}
+	if decl.Grants, err = encodeGrants(t, t.Grants); err != nil {
+		return nil, err
+	}
+	for _, r := range t.OwnedRelationships {
+		rd, err := e.encodeRelationship(r)
+		if err != nil {
+			return nil, err
+		}
+		decl.Relationships = append(decl.Relationships, rd)
+	}
+	for _, r := range t.InversedRelationships {
+		ref, err := e.relationshipRef(r)
+		if err != nil {
+			return nil, fmt.Errorf("inversed relationship: %s", err.Error())
+		}
+		decl.InversedRelationships = append(decl.InversedRelationships, ref)
+	}
+	for _, r := range t.ManyToManyRelationships {
+		ref, err := e.relationshipRef(r)
+		if err != nil {
+			return nil, fmt.Errorf("many to many relationship: %s", err.Error())
+		}
+		decl.ManyToManyRelationships = append(decl.ManyToManyRelationships, ref)
+	}
+
+	return decl, nil
+}
+
+func (e *encoder) encodeView(v *pqt.View) (*viewDecl, error) {
+	decl := &viewDecl{
+		Name:         v.Name,
+		Query:        v.Query,
+		Materialized: v.Materialized,
+	}
+	for _, c := range v.Columns {
+		cd, err := e.encodeColumn(c)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %s", c.Name, err.Error())
+		}
+		decl.Columns = append(decl.Columns, cd)
+	}
+	triggers, err := encodeTriggers(v.Triggers)
+	if err != nil {
+		return nil, err
+	}
+	decl.Triggers = triggers
+	return decl, nil
+}
+
+func encodeTriggers(triggers []*pqt.Trigger) ([]*triggerDecl, error) {
+	var decls []*triggerDecl
+	for _, tr := range triggers {
+		decl := &triggerDecl{
+			Name:       tr.Name,
+			Timing:     timings[tr.Timing],
+			ForEachRow: tr.ForEachRow,
+			When:       tr.When,
+			Args:       tr.Args,
+		}
+		if decl.Timing == "" {
+			return nil, fmt.Errorf("trigger %s: unknown timing: %d", tr.Name, tr.Timing)
+		}
+		if tr.Function == nil {
+			return nil, fmt.Errorf("trigger %s: missing function", tr.Name)
+		}
+		decl.Function = tr.Function.Name
+		for _, e := range tr.Events {
+			decl.Events = append(decl.Events, string(e))
+		}
+		for _, c := range tr.UpdateOf {
+			decl.UpdateOf = append(decl.UpdateOf, c.Name)
+		}
+		decls = append(decls, decl)
+	}
+	return decls, nil
+}
+
+// encodeGrants encodes privileges, t is expected only if privileges are given on a table.
+func encodeGrants(t *pqt.Table, grants []*pqt.Grant) ([]*grantDecl, error) {
+	var decls []*grantDecl
+	for _, g := range grants {
+		decl := &grantDecl{
+			Role:        g.Role,
+			GrantOption: g.GrantOption,
+		}
+		for _, p := range g.Privileges {
+			decl.Privileges = append(decl.Privileges, string(p))
+		}
+		var err error
+		if decl.Columns, err = columnNames(t, g.Columns); err != nil {
+			return nil, fmt.Errorf("grant to %s: %s", g.Role, err.Error())
+		}
+		decls = append(decls, decl)
+	}
+	return decls, nil
+}
+
+func (e *encoder) encodeColumn(c *pqt.Column) (*columnDecl, error) {
+	td, err := encodeType(c.Type)
+	if err != nil {
+		return nil, err
+	}
+	decl := &columnDecl{
+		Name:                         c.Name,
+		ShortName:                    c.ShortName,
+		Type:                         td,
+		Collate:                      c.Collate,
+		Check:                        c.Check,
+		NotNull:                      c.NotNull,
+		Unique:                       c.Unique,
+		PrimaryKey:                   c.PrimaryKey,
+		Index:                        c.Index,
+		NoInherit:                    c.NoInherit,
+		DeferrableInitiallyDeferred:  c.DeferrableInitiallyDeferred,
+		DeferrableInitiallyImmediate: c.DeferrableInitiallyImmediate,
+		Dynamic:                      c.IsDynamic,
+		Identity:                     string(c.Identity),
+		Generated:                    c.Generated,
+		Comment:                      c.Comment,
+		Position:                     c.Position,
+	}
+	if len(c.Default) > 0 {
+		decl.Default = make(map[string]string, len(c.Default))
+		for ev, d := range c.Default {
+			decl.Default[string(ev)] = d
+		}
+	}
+	if decl.Match, err = encodeMatch(c.Match); err != nil {
+		return nil, err
+	}
+	if decl.OnDelete, err = encodeAction(c.OnDelete); err != nil {
+		return nil, err
+	}
+	if decl.OnUpdate, err = encodeAction(c.OnUpdate); err != nil {
+		return nil, err
+	}
+	if c.Reference != nil {
+		if decl.Reference, err = e.columnRef(c.Reference); err != nil {
+			return nil, err
+		}
+	}
+	if c.Func != nil {
+		if decl.Function, err = encodeFunction(c.Func); err != nil {
+			return nil, err
+		}
+	}
+	if decl.Columns, err = e.columnRefs(c.Columns); err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+func (e *encoder) encodeConstraint(holder *pqt.Table, c *pqt.Constraint) (*constraintDecl, error) {
+	decl := &constraintDecl{
+		Type:                         string(c.Type),
+		Name:                         c.ExplicitName,
+		Where:                        c.Where,
+		Check:                        c.Check,
+		NoInherit:                    c.NoInherit,
+		DeferrableInitiallyDeferred:  c.DeferrableInitiallyDeferred,
+		DeferrableInitiallyImmediate: c.DeferrableInitiallyImmediate,
+		MethodSuffix:                 c.MethodSuffix,
+		Method:                       c.Method,
+		Concurrently:                 c.Concurrently,
+		Comment:                      c.Comment,
+	}
+
+	var err error
+	if decl.Match, err = encodeMatch(c.Match); err != nil {
+		return nil, err
+	}
+	if decl.OnDelete, err = encodeAction(c.OnDelete); err != nil {
+		return nil, err
+	}
+	if decl.OnUpdate, err = encodeAction(c.OnUpdate); err != nil {
+		return nil, err
+	}
+	if c.PrimaryTable != nil && c.PrimaryTable != holder {
+		if decl.PrimaryTable, err = e.tableRef(c.PrimaryTable); err != nil {
+			return nil, err
+		}
+	}
+	if decl.Columns, err = columnNames(c.PrimaryTable, c.PrimaryColumns); err != nil {
+		return nil, err
+	}
+	if c.Table != nil {
+		if decl.Table, err = e.tableRef(c.Table); err != nil {
+			return nil, err
+		}
+	}
+	if decl.ReferencedColumns, err = columnNames(c.Table, c.Columns); err != nil {
+		return nil, err
+	}
+	for _, el := range c.Exclude {
+		elDecl := &exclusionElementDecl{Expression: el.Expression, Operator: el.Operator}
+		if el.Column != nil {
+			names, err := columnNames(c.PrimaryTable, pqt.Columns{el.Column})
+			if err != nil {
+				return nil, err
+			}
+			elDecl.Column = names[0]
+		}
+		decl.Exclude = append(decl.Exclude, elDecl)
+	}
+	for _, el := range c.Elements {
+		elDecl := &indexElementDecl{
+			Expression: el.Expression,
+			OpClass:    el.OpClass,
+			Descending: el.Descending,
+			Nulls:      nullsOrders[el.Nulls],
+		}
+		if el.Column != nil {
+			names, err := columnNames(c.PrimaryTable, pqt.Columns{el.Column})
+			if err != nil {
+				return nil, err
+			}
+			elDecl.Column = names[0]
+		}
+		decl.Elements = append(decl.Elements, elDecl)
+	}
+	if decl.Include, err = columnNames(c.PrimaryTable, c.Include); err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+func (e *encoder) encodeRelationship(r *pqt.Relationship) (*relationshipDecl, error) {
+	typ, ok := relationshipTypes[r.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown relationship type: %d", r.Type)
+	}
+	decl := &relationshipDecl{
+		Type:          typ,
+		Bidirectional: r.Bidirectional,
+		OwnerName:     r.OwnerName,
+		InversedName:  r.InversedName,
+		ColumnName:    r.ColumnName,
+	}
+
+	var err error
+	for _, t := range []struct {
+		table *pqt.Table
+		ref   *string
+	}{
+		{table: r.OwnerTable, ref: &decl.OwnerTable},
+		{table: r.InversedTable, ref: &decl.InversedTable},
+		{table: r.ThroughTable, ref: &decl.ThroughTable},
+	} {
+		if t.table == nil {
+			continue
+		}
+		if *t.ref, err = e.tableRef(t.table); err != nil {
+			return nil, err
+		}
+	}
+	if decl.OwnerColumns, err = e.columnRefs(r.OwnerColumns); err != nil {
+		return nil, err
+	}
+	if decl.InversedColumns, err = e.columnRefs(r.InversedColumns); err != nil {
+		return nil, err
+	}
+	if r.OwnerForeignKey != nil {
+		if decl.OwnerForeignKey, err = e.encodeConstraint(nil, r.OwnerForeignKey); err != nil {
+			return nil, err
+		}
+	}
+	if r.InversedForeignKey != nil {
+		if decl.InversedForeignKey, err = e.encodeConstraint(nil, r.InversedForeignKey); err != nil {
+			return nil, err
+		}
+	}
+	if decl.OnDelete, err = encodeAction(r.OnDelete); err != nil {
+		return nil, err
+	}
+	if decl.OnUpdate, err = encodeAction(r.OnUpdate); err != nil {
+		return nil, err
+	}
+
+	return decl, nil
+}
+
+// tableRef returns name of the table, tables of other schemas of the database are prefixed by the schema name.
+func (e *encoder) tableRef(t *pqt.Table) (string, error) {
+	if containsTable(e.schema, t) {
+		return t.Name, nil
+	}
+	if e.database != nil {
+		for _, s := range e.database.Schemas {
+			if containsTable(s, t) {
+				return or(s.Name, pqt.DefaultSchema) + "." + t.Name, nil
+			}
+		}
+		return "", fmt.Errorf("table %s is not part of the database", t.QualifiedName())
+	}
+	return "", fmt.Errorf("table %s is not part of the schema", t.QualifiedName())
+}
+
+func containsTable(s *pqt.Schema, t *pqt.Table) bool {
+	for _, tt := range s.Tables {
+		if tt == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *encoder) relationshipRef(r *pqt.Relationship) (relationshipRef, error) {
+	owned, ok := e.relationships[r]
+	if !ok {
+		return relationshipRef{}, fmt.Errorf("relationship is not owned by any table")
+	}
+	table, err := e.tableRef(owned.table)
+	if err != nil {
+		return relationshipRef{}, err
+	}
+	return relationshipRef{Table: table, Index: owned.index}, nil
+}
+
+func (e *encoder) columnRef(c *pqt.Column) (string, error) {
+	if c.Table == nil {
+		return "", fmt.Errorf("column %s does not belong to any table", c.Name)
+	}
+	t, err := e.tableRef(c.Table)
+	if err != nil {
+		return "", err
+	}
+	return t + "." + c.Name, nil
+}
+
+func (e *encoder) columnRefs(columns pqt.Columns) ([]string, error) {
+	var refs []string
+	for _, c := range columns {
+		ref, err := e.columnRef(c)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// columnNames returns names of the columns that are expected to belong to the given table.
+func columnNames(t *pqt.Table, columns pqt.Columns) ([]string, error) {
+	var names []string
+	for _, c := range columns {
+		if c.Table != t {
+			return nil, fmt.Errorf("column %s does not belong to the constraint table", c.Name)
+		}
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
+
+func encodeAction(a int32) (string, error) {
+	if a == 0 {
+		return "", nil
+	}
+	name, ok := actions[a]
+	if !ok {
+		return "", fmt.Errorf("unknown referential action: %d", a)
+	}
+	return name, nil
+}
+
+func encodeMatch(m int32) (string, error) {
+	if m == pqt.MatchDefault {
+		return "", nil
+	}
+	name, ok := matches[m]
+	if !ok {
+		return "", fmt.Errorf("unknown match type: %d", m)
+	}
+	return name, nil
+}
+
+func encodeFunction(f *pqt.Function) (*functionDecl, error) {
+	decl := &functionDecl{
+		Name:            f.Name,
+		BuiltIn:         f.BuiltIn,
+		Body:            f.Body,
+		Behaviour:       behaviours[f.Behaviour],
+		SetOf:           f.SetOf,
+		Language:        f.Language,
+		SecurityDefiner: f.SecurityDefiner,
+		Comment:         f.Comment,
+	}
+	if decl.Behaviour == "" {
+		return nil, fmt.Errorf("function %s: unknown behaviour: %d", f.Name, f.Behaviour)
+	}
+
+	var err error
+	if decl.Type, err = encodeType(f.Type); err != nil {
+		return nil, fmt.Errorf("function %s: %s", f.Name, err.Error())
+	}
+	for _, arg := range f.Args {
+		td, err := encodeType(arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %s", f.Name, err.Error())
+		}
+		decl.Args = append(decl.Args, &functionArgDecl{Name: arg.Name, Type: td})
+	}
+	for _, arg := range f.ReturnsTable {
+		td, err := encodeType(arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %s", f.Name, err.Error())
+		}
+		decl.ReturnsTable = append(decl.ReturnsTable, &functionArgDecl{Name: arg.Name, Type: td})
+	}
+	if decl.Grants, err = encodeGrants(nil, f.Grants); err != nil {
+		return nil, fmt.Errorf("function %s: %s", f.Name, err.Error())
+	}
+	return decl, nil
+}
+
+func encodeType(t pqt.Type) (*typeDecl, error) {
+	switch tt := t.(type) {
+	case nil:
+		return nil, nil
+	case pqt.BaseType:
+		return &typeDecl{Base: tt.String()}, nil
+	case pqt.PseudoType:
+		return &typeDecl{Pseudo: tt.String()}, nil
+	case pqt.EnumeratedType:
+		return &typeDecl{Enumerated: &enumeratedDecl{Name: tt.String(), Enums: tt.Enums}}, nil
+	case pqt.CompositeType:
+		decl := &compositeDecl{Name: tt.Name()}
+		for _, a := range tt.Attributes {
+			td, err := encodeType(a.Type)
+			if err != nil {
+				return nil, err
+			}
+			decl.Attributes = append(decl.Attributes, &attributeDecl{
+				Name:       a.Name,
+				Type:       td,
+				Collate:    a.Collate,
+				Default:    a.Default,
+				Check:      a.Check,
+				NotNull:    a.NotNull,
+				Unique:     a.Unique,
+				PrimaryKey: a.PrimaryKey,
+			})
+		}
+		return &typeDecl{Composite: decl}, nil
+	case pqt.MappableType:
+		from, err := encodeType(tt.From)
+		if err != nil {
+			return nil, err
+		}
+		decl := &mappableDecl{From: from}
+		for _, m := range tt.Mapping {
+			td, err := encodeType(m)
+			if err != nil {
+				return nil, err
+			}
+			decl.Mapping = append(decl.Mapping, td)
+		}
+		return &typeDecl{Mappable: decl}, nil
+	case pqt.DomainType:
+		base, err := encodeType(tt.Base)
+		if err != nil {
+			return nil, err
+		}
+		return &typeDecl{Domain: &domainDecl{
+			Name:    tt.String(),
+			Base:    base,
+			Default: tt.Default,
+			NotNull: tt.NotNull,
+			Checks:  tt.Checks,
+		}}, nil
+	case pqt.ArrayType:
+		elem, err := encodeType(tt.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return &typeDecl{Array: &arrayDecl{Elem: elem, Dims: tt.Sizes()}}, nil
+	case pqtgo.BuiltinType:
+		if types.BasicKind(tt) == types.Invalid || tt.String() == "invalid" {
+			return nil, fmt.Errorf("unsupported go builtin type: %d", tt)
+		}
+		return &typeDecl{GoBuiltin: tt.String()}, nil
+	case pqtgo.CustomType:
+		return &typeDecl{GoCustom: &goCustomDecl{
+			Mandatory: encodeGoType(tt.GoTypeOf(pqtgo.ModeMandatory)),
+			Optional:  encodeGoType(tt.GoTypeOf(pqtgo.ModeOptional)),
+			Criteria:  encodeGoType(tt.GoTypeOf(pqtgo.ModeCriteria)),
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %T", t)
+	}
+}
+
+func encodeGoType(gt *pqtgo.GoType) *goTypeDecl {
+	if gt == nil {
+		return nil
+	}
+	return &goTypeDecl{
+		Name:     gt.Name,
+		PkgPath:  gt.PkgPath,
+		Kind:     gt.Kind.String(),
+		Nullable: gt.Nullable,
+	}
+}
+
+// kinds maps names of reflect.Kind back to their values.
+var kinds = func() map[string]reflect.Kind {
+	m := make(map[string]reflect.Kind)
+	for k := reflect.Invalid; k <= reflect.UnsafePointer; k++ {
+		m[k.String()] = k
+	}
+	return m
+}()
+
+func or(s, or string) string {
+	if s == "" {
+		return or
+	}
+	return s
+}
@@ -0,0 +1,171 @@
+// Package pqtfile reads and writes pqt schema using declarative JSON or YAML files.
+//
+// File describes schema in its final form, the way generators see it.
+// Tables and columns are referenced by name, columns of other tables in format <table>.<column>.
+// Database file lists multiple schemas, tables of other schemas are referenced in format <schema>.<table>
+// and their columns in format <schema>.<table>.<column>, public schema has to be referenced explicitly.
+package pqtfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/piotrkowalczuk/pqt"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FormatJSON represents JSON encoded file.
+	FormatJSON Format = "json"
+	// FormatYAML represents YAML encoded file.
+	FormatYAML Format = "yaml"
+)
+
+// Format is an encoding of the schema file.
+type Format string
+
+// FormatOf returns format that matches extension of the given file.
+func FormatOf(path string) (Format, error) {
+	switch filepath.Ext(path) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown schema file extension: %s", path)
+	}
+}
+
+// Marshal returns encoding of the schema in given format.
+func Marshal(s *pqt.Schema, f Format) ([]byte, error) {
+	decl, err := encodeSchema(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(decl, f)
+}
+
+// MarshalDatabase returns encoding of the database in given format.
+func MarshalDatabase(d *pqt.Database, f Format) ([]byte, error) {
+	decl, err := encodeDatabase(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(decl, f)
+}
+
+func marshal(decl interface{}, f Format) ([]byte, error) {
+	switch f {
+	case FormatJSON:
+		b, err := json.MarshalIndent(decl, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case FormatYAML:
+		buf := bytes.NewBuffer(nil)
+		enc := yaml.NewEncoder(buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(decl); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s", f)
+	}
+}
+
+// Unmarshal parses encoded schema and rebuilds it, including references between tables, columns and relationships.
+func Unmarshal(data []byte, f Format) (*pqt.Schema, error) {
+	var decl schemaDecl
+	if err := unmarshal(data, f, &decl); err != nil {
+		return nil, err
+	}
+
+	return decodeSchema(&decl)
+}
+
+// UnmarshalDatabase parses encoded database and rebuilds it, including references between schemas.
+func UnmarshalDatabase(data []byte, f Format) (*pqt.Database, error) {
+	var decl databaseDecl
+	if err := unmarshal(data, f, &decl); err != nil {
+		return nil, err
+	}
+
+	return decodeDatabase(&decl)
+}
+
+func unmarshal(data []byte, f Format, decl interface{}) error {
+	switch f {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		return dec.Decode(decl)
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		return dec.Decode(decl)
+	default:
+		return fmt.Errorf("unknown format: %s", f)
+	}
+}
+
+// Load reads schema from the file. Format is chosen based on the file extension.
+func Load(path string) (*pqt.Schema, error) {
+	f, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(data, f)
+}
+
+// Save writes schema into the file. Format is chosen based on the file extension.
+func Save(path string, s *pqt.Schema) error {
+	f, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	data, err := Marshal(s, f)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// LoadDatabase reads database from the file. Format is chosen based on the file extension.
+func LoadDatabase(path string) (*pqt.Database, error) {
+	f, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalDatabase(data, f)
+}
+
+// SaveDatabase writes database into the file. Format is chosen based on the file extension.
+func SaveDatabase(path string, d *pqt.Database) error {
+	f, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	data, err := MarshalDatabase(d, f)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,356 @@
+package pqtfile_test
+
+import (
+	"database/sql"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/internal/testutil"
+	"github.com/piotrkowalczuk/pqt/pqtfile"
+	"github.com/piotrkowalczuk/pqt/pqtgo"
+	"github.com/piotrkowalczuk/pqt/pqtgo/pqtgogen"
+	"github.com/piotrkowalczuk/pqt/pqtsql"
+)
+
+func schema() *pqt.Schema {
+	multiply := &pqt.Function{
+		Name:      "multiply",
+		Type:      pqt.TypeIntegerBig(),
+		Body:      "SELECT x * y",
+		Behaviour: pqt.FunctionBehaviourImmutable,
+		Comment:   "multiplies two numbers",
+		Grants:    []*pqt.Grant{pqt.NewGrant("PUBLIC", pqt.PrivilegeExecute)},
+		Args: []*pqt.FunctionArg{
+			{Name: "x", Type: pqt.TypeIntegerBig()},
+			{Name: "y", Type: pqt.TypeIntegerBig()},
+		},
+	}
+
+	bump := &pqt.Function{
+		Name:            "bump",
+		Type:            pqt.TypeTrigger(),
+		Body:            "BEGIN NEW.version := OLD.version + 1; RETURN NEW; END;",
+		Language:        pqt.FunctionLanguagePLpgSQL,
+		SecurityDefiner: true,
+	}
+	titles := &pqt.Function{
+		Name:         "titles",
+		ReturnsTable: []*pqt.FunctionArg{{Name: "title", Type: pqt.TypeText()}},
+		Body:         "SELECT title FROM example.news",
+		Behaviour:    pqt.FunctionBehaviourStable,
+	}
+
+	status := pqt.TypeEnumerated("news_status", "draft", "published")
+	source := pqt.TypeComposite("news_source",
+		&pqt.Attribute{Name: "name", Type: pqt.TypeText(), NotNull: true},
+		&pqt.Attribute{Name: "url", Type: pqt.TypeText()},
+		&pqt.Attribute{Name: "status", Type: status},
+	)
+
+	slug := pqt.TypeDomain("news_slug", pqt.TypeText(), pqt.WithDomainNotNull(), pqt.WithDomainDefault("''"), pqt.WithDomainCheck("VALUE ~ '^[a-z0-9-]*$'"))
+
+	title := pqt.NewColumn("title", pqt.TypeText(), pqt.WithNotNull(), pqt.WithUnique(), pqt.WithComment("headline of the news"))
+	score := pqt.NewColumn("score", pqt.TypeNumeric(20, 8), pqt.WithNotNull(), pqt.WithDefault("0"), pqt.WithCheck("score >= 0"))
+	views := pqt.NewColumn("views", pqt.TypeInteger(), pqt.WithTypeMapping(pqtgo.BuiltinType(types.Int64)))
+	news := pqt.NewTable("news", pqt.WithTableIfNotExists(), pqt.WithTableComment("news published on the site")).
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(title).
+		AddColumn(pqt.NewColumn("status", status, pqt.WithNotNull(), pqt.WithDefault("'draft'"))).
+		AddColumn(pqt.NewColumn("source", source)).
+		AddColumn(pqt.NewColumn("lead", pqt.TypeText(), pqt.WithTypeMapping(pqtgo.TypeCustom("", sql.NullString{}, sql.NullString{})))).
+		AddColumn(score).
+		AddColumn(views).
+		AddColumn(pqt.NewColumn("tags", pqt.TypeArray(pqt.TypeText()))).
+		AddColumn(pqt.NewColumn("slug", slug)).
+		AddColumn(pqt.NewColumn("history", pqt.TypeArray(status, 0, 2))).
+		AddColumn(pqt.NewColumn("version", pqt.TypeIntegerBig(), pqt.WithNotNull(), pqt.WithDefault("version+1", pqt.EventUpdate)))
+	news.AddUniqueIndex("Published", "score > 0", title)
+	news.AddExclusion("btree", "status = 'published'", pqt.ExcludeColumn(title, "="), pqt.ExcludeExpression("lower(lead)", "="))
+	news.AddConstraint(pqt.IndexOn(news, []*pqt.IndexElement{
+		pqt.IndexExpression("lower(title)", pqt.WithOpClass("text_pattern_ops")),
+		pqt.IndexColumn(score, pqt.WithDescending(), pqt.WithNulls(pqt.NullsLast)),
+	}, pqt.WithIndexMethod("btree"), pqt.WithInclude(views), pqt.WithWhere("score > 0"), pqt.WithConcurrently(), pqt.WithConstraintComment("speeds up title search"), pqt.WithConstraintName("news_title_search_idx")))
+	news.AddTrigger(pqt.NewTrigger("news_bump", pqt.TriggerTimingBefore, bump,
+		pqt.WithTriggerEvents(pqt.EventUpdate),
+		pqt.WithUpdateOf(title),
+		pqt.WithForEachRow(),
+		pqt.WithWhen("OLD.title <> NEW.title"),
+		pqt.WithTriggerArgs("title"),
+	))
+
+	commentID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	comment := pqt.NewTable("comment", pqt.WithTemporary()).
+		AddColumn(commentID).
+		AddColumn(pqt.NewColumn("news_title", pqt.TypeText(), pqt.WithNotNull(), pqt.WithIndex(), pqt.WithOnDelete(pqt.Cascade), pqt.WithReferenceMatch(pqt.MatchFull),
+			pqt.WithReference(title, pqt.WithBidirectional(), pqt.WithOwnerName("comments_by_news_title"), pqt.WithInversedName("news_by_title")),
+		)).
+		AddColumn(pqt.NewColumn("number", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityByDefault), pqt.WithPosition(-1))).
+		AddColumn(pqt.NewColumn("title_upper", pqt.TypeText(), pqt.WithGenerated("upper(news_title)"))).
+		AddColumn(pqt.NewDynamicColumn("right_now", pqt.FunctionNow())).
+		AddColumn(pqt.NewDynamicColumn("id_multiply", multiply, commentID, commentID))
+
+	category := pqt.NewTable("category", pqt.WithTableShortName("cat")).
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("name", pqt.TypeVarchar(100), pqt.WithNotNull(), pqt.WithCollate("C"))).
+		AddRelationship(pqt.OneToMany(
+			pqt.SelfReference(),
+			pqt.WithBidirectional(),
+			pqt.WithInversedName("child_category"),
+			pqt.WithOwnerName("parent_category"),
+			pqt.WithColumnName("parent_id"),
+		), pqt.WithOnDelete(pqt.SetNull))
+	comment.AddRelationship(pqt.ManyToOne(news, pqt.WithBidirectional(), pqt.WithInversedName("news_by_id")), pqt.WithNotNull())
+
+	createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+	event := pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByRange, createdAt), pqt.WithColumnOrder(pqt.ColumnOrderDeclared)).
+		AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+		AddColumn(createdAt).
+		AddPartition(pqt.PartitionRange("event_2020", "'2020-01-01'", "'2021-01-01'")).
+		AddPartition(pqt.PartitionDefault("event_default"))
+
+	archivedAt := pqt.NewColumn("archived_at", pqt.TypeTimestampTZ(), pqt.WithCheck("archived_at IS NOT NULL"), pqt.WithNoInherit())
+	archive := pqt.NewTable("news_archive", pqt.WithInherits(news), pqt.WithForceRowLevelSecurity()).
+		AddColumn(archivedAt).
+		AddPolicy(pqt.NewPolicy("news_archive_editor", pqt.WithPolicyRoles("editor"), pqt.WithUsing("true"), pqt.WithPolicyCheck("archived_at <= now()"))).
+		AddPolicy(pqt.NewPolicy("news_archive_recent", pqt.WithPolicyCommand(pqt.PolicyCommandSelect), pqt.WithRestrictive(), pqt.WithUsing("archived_at > now() - interval '1 year'"))).
+		AddGrant(pqt.NewGrant("editor", pqt.PrivilegeSelect, pqt.PrivilegeInsert)).
+		AddGrant(pqt.NewGrant("auditor", pqt.PrivilegeSelect).OnColumns(archivedAt).WithGrantOption())
+
+	newsCategory := pqt.NewTable("news_category").
+		AddRelationship(pqt.ManyToMany(news, category, pqt.WithBidirectional()))
+
+	return pqt.NewSchema("example", pqt.WithSchemaIfNotExists(), pqt.WithQuotingPolicy(pqt.QuoteAlways), pqt.WithSchemaExtension("pgcrypto", pqt.WithExtensionSchema("example"))).
+		AddGrant(pqt.NewGrant("editor", pqt.PrivilegeUsage)).
+		AddType(status).
+		AddType(slug).
+		AddType(source).
+		AddTable(category).
+		AddTable(news).
+		AddTable(comment).
+		AddTable(newsCategory).
+		AddTable(event).
+		AddTable(archive).
+		AddView(pqt.NewView("news_per_category", "SELECT category_id, COUNT(*) FROM example.news_category GROUP BY category_id", pqt.WithMaterialized()).
+			AddColumn(pqt.NewColumn("category_id", pqt.TypeIntegerBig(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("total", pqt.TypeIntegerBig(), pqt.WithNotNull()))).
+		AddFunction(multiply).
+		AddFunction(bump).
+		AddFunction(titles)
+}
+
+func generate(t *testing.T, s *pqt.Schema) (string, string) {
+	t.Helper()
+
+	sqlCode, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected sql generator error: %s", err.Error())
+	}
+	goCode, err := (&pqtgogen.Generator{Version: 9.5, Pkg: "example", Components: pqtgogen.ComponentAll}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected go generator error: %s", err.Error())
+	}
+	return string(sqlCode), string(goCode)
+}
+
+func TestMarshal(t *testing.T) {
+	expectedSQL, expectedGo := generate(t, schema())
+
+	for _, f := range []pqtfile.Format{pqtfile.FormatJSON, pqtfile.FormatYAML} {
+		t.Run(string(f), func(t *testing.T) {
+			data, err := pqtfile.Marshal(schema(), f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			s, err := pqtfile.Unmarshal(data, f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			gotSQL, gotGo := generate(t, s)
+			testutil.AssertGoCode(t, expectedSQL, gotSQL)
+			testutil.AssertGoCode(t, expectedGo, gotGo)
+
+			if !strings.Contains(string(data), "FULL") {
+				t.Error("match type should be written by name")
+			}
+
+			again, err := pqtfile.Marshal(s, f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			testutil.AssertGoCode(t, string(data), string(again))
+		})
+	}
+}
+
+func database() *pqt.Database {
+	userID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	user := pqt.NewTable("user").
+		AddColumn(userID).
+		AddColumn(pqt.NewColumn("email", pqt.TypeText(), pqt.WithNotNull(), pqt.WithUnique()))
+	invoice := pqt.NewTable("invoice").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("payer_id", pqt.TypeIntegerBig(), pqt.WithReference(userID, pqt.WithBidirectional(), pqt.WithInversedName("payer"))))
+	invoice.AddRelationship(pqt.ManyToOne(user, pqt.WithBidirectional(), pqt.WithInversedName("issuer")), pqt.WithNotNull())
+	invoiceID := pqt.NewColumn("invoice_id", pqt.TypeIntegerBig())
+	note := pqt.NewTable("note").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())).
+		AddColumn(invoiceID)
+	note.AddConstraint(pqt.ForeignKey(pqt.Columns{invoiceID}, pqt.Columns{invoice.Columns[0]}))
+
+	return pqt.NewDatabase(pqt.WithExtension("pgcrypto")).
+		AddSchema(pqt.NewSchema("auth").AddTable(user)).
+		AddSchema(pqt.NewSchema("billing").AddTable(invoice)).
+		AddSchema(pqt.NewSchema("").AddTable(note))
+}
+
+func TestMarshalDatabase(t *testing.T) {
+	generate := func(t *testing.T, d *pqt.Database) (string, string) {
+		t.Helper()
+
+		sqlCode, err := (&pqtsql.Generator{Version: 9.5}).GenerateDatabase(d)
+		if err != nil {
+			t.Fatalf("unexpected sql generator error: %s", err.Error())
+		}
+		goCode, err := (&pqtgogen.Generator{Version: 9.5, Pkg: "example", Components: pqtgogen.ComponentAll}).GenerateDatabase(d)
+		if err != nil {
+			t.Fatalf("unexpected go generator error: %s", err.Error())
+		}
+		return string(sqlCode), string(goCode)
+	}
+	expectedSQL, expectedGo := generate(t, database())
+
+	for _, f := range []pqtfile.Format{pqtfile.FormatJSON, pqtfile.FormatYAML} {
+		t.Run(string(f), func(t *testing.T) {
+			data, err := pqtfile.MarshalDatabase(database(), f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			d, err := pqtfile.UnmarshalDatabase(data, f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			gotSQL, gotGo := generate(t, d)
+			testutil.AssertGoCode(t, expectedSQL, gotSQL)
+			testutil.AssertGoCode(t, expectedGo, gotGo)
+
+			for _, ref := range []string{"auth.user.id", "billing.invoice"} {
+				if !strings.Contains(string(data), ref) {
+					t.Errorf("reference %s should be qualified by the schema name", ref)
+				}
+			}
+
+			again, err := pqtfile.MarshalDatabase(d, f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			testutil.AssertGoCode(t, string(data), string(again))
+		})
+	}
+
+	if _, err := pqtfile.Marshal(database().Schemas[1], pqtfile.FormatJSON); err == nil {
+		t.Error("expected error, schema references tables of other schemas")
+	}
+}
+
+func TestUnmarshal_columnOrder(t *testing.T) {
+	data := []byte(`version: 1
+declaredColumnOrder: true
+tables:
+  - name: user
+    columns:
+      - name: name
+        type: {base: TEXT}
+      - name: id
+        type: {base: BIGINT}
+  - name: group
+    declaredColumnOrder: false
+    columns:
+      - name: name
+        type: {base: TEXT}
+      - name: id
+        type: {base: BIGINT}
+`)
+
+	s, err := pqtfile.Unmarshal(data, pqtfile.FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	for i, expected := range []string{"name,id", "id,name"} {
+		if got := s.Tables[i].Columns.String(); got != expected {
+			t.Errorf("table %s has wrong column order, expected %s but got %s", s.Tables[i].Name, expected, got)
+		}
+	}
+
+	again, err := pqtfile.Marshal(s, pqtfile.FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if n := strings.Count(string(again), "declaredColumnOrder"); n != 2 {
+		t.Errorf("column order should be written for the schema and the table that sets its own, got:\n%s", again)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"schema.json", "schema.yaml", "schema.yml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := pqtfile.Save(path, schema()); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			s, err := pqtfile.Load(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if len(s.Tables) != 6 {
+				t.Fatalf("wrong number of tables, expected 6 but got %d", len(s.Tables))
+			}
+			for _, tbl := range s.Tables {
+				if tbl.Schema != s {
+					t.Errorf("table %s does not reference its schema", tbl.Name)
+				}
+				for _, c := range tbl.Columns {
+					if c.Table != tbl {
+						t.Errorf("column %s.%s does not reference its table", tbl.Name, c.Name)
+					}
+				}
+				for _, r := range tbl.OwnedRelationships {
+					if r.OwnerTable == nil || r.InversedTable == nil {
+						t.Errorf("relationship of table %s is missing owner or inversed table", tbl.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_errors(t *testing.T) {
+	cases := map[string]string{
+		"unknown-field.json":     `{"version": 1, "tables": [{"name": "user", "unknown": true}]}`,
+		"unknown-version.json":   `{"version": 2}`,
+		"missing-table.yaml":     "version: 1\ntables:\n  - name: user\n    constraints:\n      - type: fkey\n        columns: [id]\n",
+		"missing-reference.yaml": "version: 1\ntables:\n  - name: user\n    columns:\n      - name: id\n        type: {base: BIGINT}\n        reference: group.id\n",
+		"unknown-extension.txt":  `{"version": 1}`,
+		"unknown-match.yaml":     "version: 1\ntables:\n  - name: user\n    columns:\n      - name: id\n        type: {base: BIGINT}\n        match: PARTIAL\n",
+	}
+
+	dir := t.TempDir()
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if _, err := pqtfile.Load(path); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
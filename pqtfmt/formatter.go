@@ -2,8 +2,8 @@ package pqtfmt
 
 import (
 	"go/types"
-	"reflect"
 	"strings"
+	"unicode"
 
 	"github.com/huandu/xstrings"
 	"github.com/piotrkowalczuk/pqt"
@@ -75,12 +75,51 @@ func Type(t pqt.Type, m int32) string {
 		return generateTypeBuiltin(tt, m)
 	case pqt.BaseType:
 		return generateTypeBase(tt, m)
+	case pqt.ArrayType:
+		return generateTypeArray(tt, m)
+	case pqt.DomainType:
+		return Type(tt.Base, m)
 	case pqtgo.CustomType:
 		return generateCustomType(tt, m)
+	case pqt.EnumeratedType:
+		name := EnumeratedType(tt)
+		return chooseType(name, "Null"+name, "Null"+name, m)
+	case pqt.CompositeType:
+		name := CompositeType(tt)
+		return chooseType(name, "*"+name, "*"+name, m)
 	}
 	return ""
 }
 
+// EnumeratedType returns name of the Go type generated for given enumerated type.
+// Schema prefix, if present, is omitted.
+func EnumeratedType(t pqt.EnumeratedType) string {
+	return userDefinedType(t.String())
+}
+
+// CompositeType returns name of the Go struct generated for given composite type.
+// Schema prefix, if present, is omitted.
+func CompositeType(t pqt.CompositeType) string {
+	return userDefinedType(t.String())
+}
+
+func userDefinedType(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return Public(name)
+}
+
+// EnumeratedValue returns name of the Go constant generated for given value of the enumerated type.
+func EnumeratedValue(t pqt.EnumeratedType, value string) string {
+	return EnumeratedType(t) + Public(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(value)))
+}
+
 func snake(s string, private bool, acronyms map[string]string) string {
 	var parts []string
 	parts1 := strings.Split(s, "_")
@@ -197,7 +236,7 @@ func generateTypeBase(t pqt.Type, m int32) string {
 		return chooseType("int16", "*int16", "*int16", m)
 	case pqt.TypeSerialBig():
 		return chooseType("int64", "sql.NullInt64", "sql.NullInt64", m)
-	case pqt.TypeTimestamp(), pqt.TypeTimestampTZ(), pqt.TypeDate():
+	case pqt.TypeTimestamp(), pqt.TypeTimestampTZ(), pqt.TypeDate(), pqt.TypeTime(), pqt.TypeTimeTZ():
 		return chooseType("time.Time", "pq.NullTime", "pq.NullTime", m)
 	case pqt.TypeReal():
 		return chooseType("float32", "*float32", "*float32", m)
@@ -207,6 +246,22 @@ func generateTypeBase(t pqt.Type, m int32) string {
 		return "[]byte"
 	case pqt.TypeUUID():
 		return chooseType("string", "sql.NullString", "sql.NullString", m)
+	case pqt.TypeInterval(), pqt.TypeInet(), pqt.TypeCIDR(), pqt.TypeMacAddr(), pqt.TypeTSVector(), pqt.TypeMoney(), pqt.TypeXML(), pqt.TypeCIText():
+		return chooseType("string", "sql.NullString", "sql.NullString", m)
+	case pqt.TypeLine(), pqt.TypeLineSegment(), pqt.TypeBox(), pqt.TypePath(), pqt.TypePolygon(), pqt.TypeCircle():
+		return chooseType("string", "sql.NullString", "sql.NullString", m)
+	case pqt.TypeHstore():
+		return "Hstore"
+	case pqt.TypePoint():
+		return chooseType("Point", "NullPoint", "NullPoint", m)
+	case pqt.TypeInt4Range(), pqt.TypeInt8Range():
+		return chooseType("Int64Range", "NullInt64Range", "NullInt64Range", m)
+	case pqt.TypeNumRange():
+		return chooseType("Float64Range", "NullFloat64Range", "NullFloat64Range", m)
+	case pqt.TypeTSRange(), pqt.TypeTSTZRange():
+		return chooseType("TimeRange", "NullTimeRange", "NullTimeRange", m)
+	case pqt.TypeDateRange():
+		return chooseType("DateRange", "NullDateRange", "NullDateRange", m)
 	default:
 		gt := t.String()
 		switch {
@@ -220,12 +275,40 @@ func generateTypeBase(t pqt.Type, m int32) string {
 			return chooseType("float64", "sql.NullFloat64", "sql.NullFloat64", m)
 		case strings.HasPrefix(gt, "VARCHAR"), strings.HasPrefix(gt, "CHARACTER"):
 			return chooseType("string", "sql.NullString", "sql.NullString", m)
+		case gt == "BIT", strings.HasPrefix(gt, "BIT("), strings.HasPrefix(gt, "VARBIT"):
+			return chooseType("string", "sql.NullString", "sql.NullString", m)
 		default:
 			return "interface{}"
 		}
 	}
 }
 
+// generateTypeArray maps array type to a slice type that is chosen based on the element type.
+// Multidimensional arrays are not supported by the driver, so they are represented as text.
+func generateTypeArray(t pqt.ArrayType, m int32) string {
+	if t.Dimensions() > 1 {
+		return chooseType("string", "sql.NullString", "sql.NullString", m)
+	}
+	switch t.Elem {
+	case pqt.TypeTime(), pqt.TypeTimeTZ(), pqt.TypeJSON(), pqt.TypeJSONB():
+		return chooseType("pq.StringArray", "NullStringArray", "NullStringArray", m)
+	}
+	switch Type(t.Elem, pqtgo.ModeMandatory) {
+	case "int", "int16", "int32", "int64":
+		return chooseType("pq.Int64Array", "NullInt64Array", "NullInt64Array", m)
+	case "float32", "float64":
+		return chooseType("pq.Float64Array", "NullFloat64Array", "NullFloat64Array", m)
+	case "bool":
+		return chooseType("pq.BoolArray", "NullBoolArray", "NullBoolArray", m)
+	case "[]byte":
+		return chooseType("pq.ByteaArray", "NullByteaArray", "NullByteaArray", m)
+	case "time.Time":
+		return chooseType("TimeArray", "NullTimeArray", "NullTimeArray", m)
+	default:
+		return chooseType("pq.StringArray", "NullStringArray", "NullStringArray", m)
+	}
+}
+
 func chooseType(tm, to, tc string, m int32) string {
 	switch m {
 	case pqtgo.ModeCriteria:
@@ -242,16 +325,16 @@ func chooseType(tm, to, tc string, m int32) string {
 }
 
 func generateCustomType(t pqtgo.CustomType, m int32) string {
-	goType := func(tp reflect.Type) string {
-		if tp == nil {
+	goType := func(gt *pqtgo.GoType) string {
+		if gt == nil {
 			return "<nil>"
 		}
-		return tp.String()
+		return gt.Name
 	}
 	return chooseType(
-		goType(t.TypeOf(pqtgo.ModeMandatory)),
-		goType(t.TypeOf(pqtgo.ModeOptional)),
-		goType(t.TypeOf(pqtgo.ModeCriteria)),
+		goType(t.GoTypeOf(pqtgo.ModeMandatory)),
+		goType(t.GoTypeOf(pqtgo.ModeOptional)),
+		goType(t.GoTypeOf(pqtgo.ModeCriteria)),
 		m,
 	)
 }
@@ -1,12 +1,14 @@
 package pqtgogen
 
 import (
+	"fmt"
 	"go/format"
 	"io"
 
 	"github.com/piotrkowalczuk/pqt"
 	"github.com/piotrkowalczuk/pqt/internal/gogen"
 	"github.com/piotrkowalczuk/pqt/internal/print"
+	"github.com/piotrkowalczuk/pqt/pqtfmt"
 )
 
 // Component bits represents single component that can be generated by the generator.
@@ -27,10 +29,13 @@ const (
 	ComponentDelete
 	// ComponentHelpers represents all helpers.
 	ComponentHelpers
+	// ComponentPartitions represents CreatePartitions method of a repository of a table partitioned by range of time.
+	// It is not a part of ComponentAll, the method runs DDL statements and has to be requested explicitly.
+	ComponentPartitions
 
 	// ComponentRepository is a bit mask that group all repository methods.
 	ComponentRepository = ComponentInsert | ComponentFind | ComponentUpdate | ComponentUpsert | ComponentCount | ComponentDelete
-	// ComponentAll is a bit mask that groups all components.
+	// ComponentAll is a bit mask that groups all components, except ComponentPartitions.
 	ComponentAll = ComponentRepository | ComponentHelpers
 )
 
@@ -75,7 +80,106 @@ func (g *Generator) GenerateTo(s *pqt.Schema, w io.Writer) error {
 	return err
 }
 
+// GenerateDatabase generates formatted Go code for all schemas of given database into a single package.
+// Names of tables and views of schemas other than the default one are prefixed with the schema name,
+// so objects of different schemas do not collide.
+func (g *Generator) GenerateDatabase(d *pqt.Database) ([]byte, error) {
+	if err := g.generateDatabase(d); err != nil {
+		return nil, err
+	}
+
+	return format.Source(g.p.Bytes())
+}
+
+// GenerateDatabaseTo works like GenerateDatabase but it writes into io Writer instead.
+func (g *Generator) GenerateDatabaseTo(d *pqt.Database, w io.Writer) error {
+	if err := g.generateDatabase(d); err != nil {
+		return err
+	}
+
+	buf, err := format.Source(g.p.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
 func (g *Generator) generate(s *pqt.Schema) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	return g.generateSchema(s)
+}
+
+func (g *Generator) generateDatabase(d *pqt.Database) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if err := collisions(d); err != nil {
+		return err
+	}
+
+	// Objects are gathered without reassigning, so they keep references to their own schemas.
+	all := &pqt.Schema{}
+	for _, s := range d.Schemas {
+		all.Tables = append(all.Tables, s.Tables...)
+		all.Views = append(all.Views, s.Views...)
+		all.Functions = append(all.Functions, s.Functions...)
+		all.Types = append(all.Types, s.Types...)
+	}
+	return g.generateSchema(all)
+}
+
+// collisions returns an error if objects of different schemas would be generated under the same name.
+// Relations and types are checked separately, generated identifiers of a relation always have a suffix.
+func collisions(d *pqt.Database) error {
+	relations, types := make(map[string]string), make(map[string]string)
+	check := func(names map[string]string, name, object string) error {
+		if other, ok := names[name]; ok {
+			return fmt.Errorf("%s and %s are both generated as %s", other, object, name)
+		}
+		names[name] = object
+		return nil
+	}
+	for _, s := range d.Schemas {
+		for _, t := range s.Tables {
+			if err := check(relations, pqtfmt.Public(gogen.QualifiedName(s, t.Name)), "table "+t.QualifiedName()); err != nil {
+				return err
+			}
+		}
+		for _, v := range s.Views {
+			if err := check(relations, pqtfmt.Public(gogen.QualifiedName(s, v.Name)), "view "+v.QualifiedName()); err != nil {
+				return err
+			}
+		}
+		for _, t := range s.Types {
+			var name string
+			switch tt := t.(type) {
+			case pqt.EnumeratedType:
+				name = pqtfmt.EnumeratedType(tt)
+			case pqt.CompositeType:
+				name = pqtfmt.CompositeType(tt)
+			default:
+				continue
+			}
+			if err := check(types, name, "type "+or(s.Name, pqt.DefaultSchema)+"."+t.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func or(s1, s2 string) string {
+	if s1 == "" {
+		return s2
+	}
+	return s1
+}
+
+func (g *Generator) generateSchema(s *pqt.Schema) error {
 	g.g = &gogen.Generator{
 		Version: g.Version,
 	}
@@ -102,7 +206,25 @@ func (g *Generator) generate(s *pqt.Schema) error {
 		g.g.JoinClause()
 		g.g.NewLine()
 	}
+	var composite bool
+	for _, t := range s.Types {
+		switch tt := t.(type) {
+		case pqt.EnumeratedType:
+			g.g.Enum(tt)
+			g.g.NewLine()
+		case pqt.CompositeType:
+			g.g.Composite(tt)
+			g.g.NewLine()
+			composite = true
+		}
+	}
+	if composite {
+		g.g.CompositeHelpers()
+		g.g.NewLine()
+	}
 	for _, t := range s.Tables {
+		t = inheritingTable(t)
+
 		g.g.Constraints(t)
 		g.g.NewLine()
 		g.g.Columns(t)
@@ -138,6 +260,8 @@ func (g *Generator) generate(s *pqt.Schema) error {
 			g.g.NewLine()
 		}
 		if g.Components&ComponentRepository != 0 {
+			g.g.PrimaryKey(t)
+			g.g.NewLine()
 			g.g.Repository(t)
 			g.g.NewLine()
 			g.g.RepositoryMethodTx(t)
@@ -213,6 +337,12 @@ func (g *Generator) generate(s *pqt.Schema) error {
 				g.g.RepositoryMethodDeleteOneByPrimaryKey(t)
 				g.g.NewLine()
 			}
+			if g.Components&ComponentPartitions != 0 {
+				g.g.RepositoryMethodPrivateCreatePartitions(t)
+				g.g.NewLine()
+				g.g.RepositoryMethodCreatePartitions(t)
+				g.g.NewLine()
+			}
 			g.g.RepositoryTx(t)
 			g.g.NewLine()
 			g.g.RepositoryTxMethodCommitMethod(t)
@@ -249,11 +379,139 @@ func (g *Generator) generate(s *pqt.Schema) error {
 				g.g.RepositoryTxMethodDeleteOneByPrimaryKey(t)
 				g.g.NewLine()
 			}
+			if g.Components&ComponentPartitions != 0 {
+				g.g.RepositoryTxMethodCreatePartitions(t)
+				g.g.NewLine()
+			}
 		}
 	}
-	g.g.Statics()
+	for _, v := range s.Views {
+		g.generateView(v)
+	}
+	g.g.Statics(s)
 	g.g.PluginsStatics(s)
 	g.g.NewLine()
 
 	return g.p.Err
 }
+
+// generateView generates read-only counterpart of the table code for given view.
+func (g *Generator) generateView(v *pqt.View) {
+	t := viewTable(v)
+
+	g.g.Columns(t)
+	g.g.NewLine()
+	g.g.Entity(t)
+	g.g.NewLine()
+	g.g.EntityProp(t)
+	g.g.NewLine()
+	g.g.EntityProps(t)
+	g.g.NewLine()
+	if g.Components&ComponentHelpers != 0 {
+		g.g.ScanRows(t)
+		g.g.NewLine()
+	}
+	if g.Components&ComponentFind != 0 || g.Components&ComponentCount != 0 {
+		g.g.Iterator(t)
+		g.g.NewLine()
+		g.g.Criteria(t)
+		g.g.NewLine()
+		g.g.Operand(t)
+		g.g.NewLine()
+		g.g.FindExpr(t)
+		g.g.NewLine()
+		g.g.Join(t)
+		g.g.NewLine()
+	}
+	if g.Components&ComponentCount != 0 {
+		g.g.CountExpr(t)
+		g.g.NewLine()
+	}
+	if g.Components&ComponentRepository == 0 {
+		return
+	}
+	g.g.Repository(t)
+	g.g.NewLine()
+	g.g.RepositoryMethodTx(t)
+	g.g.NewLine()
+	g.g.RepositoryMethodBeginTx(t)
+	g.g.NewLine()
+	g.g.RepositoryMethodRunInTransaction(t)
+	g.g.NewLine()
+	if g.Components&ComponentFind != 0 {
+		g.g.WhereClause(t)
+		g.g.NewLine()
+		g.g.RepositoryMethodFindQuery(t)
+		g.g.NewLine()
+		g.g.RepositoryMethodPrivateFind(t)
+		g.g.NewLine()
+		g.g.RepositoryMethodFind(t)
+		g.g.NewLine()
+		g.g.RepositoryMethodPrivateFindIter(t)
+		g.g.NewLine()
+		g.g.RepositoryMethodFindIter(t)
+		g.g.NewLine()
+	}
+	if g.Components&ComponentCount != 0 {
+		g.g.RepositoryMethodPrivateCount(t)
+		g.g.NewLine()
+		g.g.RepositoryMethodCount(t)
+		g.g.NewLine()
+	}
+	g.g.RepositoryMethodPrivateRefresh(v)
+	g.g.NewLine()
+	g.g.RepositoryMethodRefresh(v)
+	g.g.NewLine()
+	g.g.RepositoryTx(t)
+	g.g.NewLine()
+	g.g.RepositoryTxMethodCommitMethod(t)
+	g.g.NewLine()
+	g.g.RepositoryTxMethodRollbackMethod(t)
+	g.g.NewLine()
+	if g.Components&ComponentFind != 0 {
+		g.g.RepositoryTxMethodFind(t)
+		g.g.NewLine()
+		g.g.RepositoryTxMethodFindIter(t)
+		g.g.NewLine()
+	}
+	if g.Components&ComponentCount != 0 {
+		g.g.RepositoryTxMethodCount(t)
+		g.g.NewLine()
+	}
+	g.g.RepositoryTxMethodRefresh(v)
+	g.g.NewLine()
+}
+
+// viewTable returns table that has the same name and columns as the view.
+// It allows to reuse generators written for tables.
+func viewTable(v *pqt.View) *pqt.Table {
+	t := pqt.NewTable(v.Name)
+	t.Schema = v.Schema
+	for _, c := range v.Columns {
+		cc := *c
+		cc.Table = t
+		t.Columns = append(t.Columns, &cc)
+	}
+	return t
+}
+
+// inheritingTable returns copy of the table that contains inherited columns next to its own columns.
+// Constraints are not inherited, so inherited columns are stripped of primary key, unique and index flags.
+func inheritingTable(t *pqt.Table) *pqt.Table {
+	inherited := t.InheritedColumns()
+	if len(inherited) == 0 {
+		return t
+	}
+
+	tt := *t
+	tt.Columns = make(pqt.Columns, 0, len(inherited)+len(t.Columns))
+	for _, c := range inherited {
+		cc := *c
+		cc.Table = &tt
+		cc.PrimaryKey, cc.Unique, cc.Index = false, false, false
+		tt.Columns = append(tt.Columns, &cc)
+	}
+	tt.Columns = append(tt.Columns, t.Columns...)
+	tt.Columns.Sort(tt.ColumnOrder)
+	return &tt
+}
@@ -2,7 +2,12 @@ package pqtgogen_test
 
 import (
 	"bytes"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/piotrkowalczuk/pqt/internal/testutil"
@@ -31,12 +36,13 @@ func TestGenerator(t *testing.T) {
 						pqt.WithCheck("name <> 'something'"),
 					))
 				post := pqt.NewTable("post").
+					AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
 					AddColumn(pqt.NewColumn("body", pqt.TypeBytea()))
 				comment := pqt.NewTable("comment").
 					AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(userID))).
 					AddRelationship(pqt.ManyToOne(post, pqt.WithOwnerName("komentarz"), pqt.WithInversedName("wpis")))
 
-				return pqt.NewSchema("example").AddTable(user).AddTable(comment)
+				return pqt.NewSchema("example").AddTable(user).AddTable(post).AddTable(comment)
 			},
 			expected: expectedSimple,
 		},
@@ -82,7 +88,6 @@ func normalize(t *testing.T, in []byte) string {
 var expectedSimple = `package example
 import(
 "github.com/m4rw3r/uuid"
-"github.com/m4rw3r/uuid"
 )
 
 	// LogFunc represents function that can be passed into repository to log query result.
@@ -172,7 +177,7 @@ TableUserConstraintNameCheck = "example.user_name_check"
 )
 
 const (
-TableUser = "example.user"
+	TableUser           = "example.\"user\""
 TableUserColumnID = "id"
 TableUserColumnName = "name"
 )
@@ -1226,92 +1231,97 @@ func (r UserRepositoryBaseTx) Rollback() error {
 		}
 
 const (
-TableCommentConstraintUserIDForeignKey = "example.comment_user_id_fkey"
+	TablePostConstraintPrimaryKey = "example.post_id_pkey"
 )
 
 const (
-TableComment = "example.comment"
-TableCommentColumnUserID = "user_id"
+	TablePost           = "example.post"
+	TablePostColumnBody = "body"
+	TablePostColumnID   = "id"
 )
 
-var TableCommentColumns = []string{
-TableCommentColumnUserID,
+var TablePostColumns = []string{
+	TablePostColumnBody,
+	TablePostColumnID,
 }
 
-// CommentEntity ...
-type CommentEntity struct{
-// UserID ...
-UserID sql.NullInt64
-// User ...
-User *UserEntity
-// Wpis ...
-Wpis *PostEntity}
+// PostEntity ...
+type PostEntity struct {
+	// Body ...
+	Body []byte
+	// ID ...
+	ID int64
+}
 
-		func (e *CommentEntity) Prop(cn string) (interface{}, bool) {
-		switch cn {
+func (e *PostEntity) Prop(cn string) (interface{}, bool) {
+	switch cn {
 
-			case TableCommentColumnUserID:
-				return &e.UserID, true
+	case TablePostColumnBody:
+		return &e.Body, true
+	case TablePostColumnID:
+		return &e.ID, true
 	default:
 		return nil, false
 	}
 }
 
-		func (e *CommentEntity) Props(cns ...string) ([]interface{}, error) {
-		if len(cns) == 0 {
-			cns = TableCommentColumns
-		}
-		res := make([]interface{}, 0, len(cns))
-		for _, cn := range cns {
-			if prop, ok := e.Prop(cn); ok {
-				res = append(res, prop)
-			} else {
-				return nil, fmt.Errorf("unexpected column provided: %s", cn)
-			}
-		}
-		return res, nil
+func (e *PostEntity) Props(cns ...string) ([]interface{}, error) {
+	if len(cns) == 0 {
+		cns = TablePostColumns
+	}
+	res := make([]interface{}, 0, len(cns))
+	for _, cn := range cns {
+		if prop, ok := e.Prop(cn); ok {
+			res = append(res, prop)
+		} else {
+			return nil, fmt.Errorf("unexpected column provided: %s", cn)
 		}
+	}
+	return res, nil
+}
 
-		// ScanCommentRows helps to scan rows straight to the slice of entities.
-		func ScanCommentRows(rows Rows) (entities []*CommentEntity, err error) {
-		for rows.Next() {
-			var ent CommentEntity
-			err = rows.Scan(
-			&ent.UserID,
-)
-			if err != nil {
-				return
-			}
-
-			entities = append(entities, &ent)
-		}
-		if err = rows.Err(); err != nil {
+// ScanPostRows helps to scan rows straight to the slice of entities.
+func ScanPostRows(rows Rows) (entities []*PostEntity, err error) {
+	for rows.Next() {
+		var ent PostEntity
+		err = rows.Scan(
+			&ent.Body,
+			&ent.ID,
+		)
+		if err != nil {
 			return
 		}
 
+		entities = append(entities, &ent)
+	}
+	if err = rows.Err(); err != nil {
 		return
 	}
 
-// CommentIterator is not thread safe.
-type CommentIterator struct {
+	return
+}
+
+// PostIterator is not thread safe.
+type PostIterator struct {
 	rows Rows
 	cols []string
-	expr *CommentFindExpr
+	expr *PostFindExpr
 }
-func (i *CommentIterator) Next() bool {
+
+func (i *PostIterator) Next() bool {
 	return i.rows.Next()
 }
 
-func (i *CommentIterator) Close() error {
+func (i *PostIterator) Close() error {
 	return i.rows.Close()
 }
 
-func (i *CommentIterator) Err() error {
+func (i *PostIterator) Err() error {
 	return i.rows.Err()
 }
 
 // Columns is wrapper around sql.Rows.Columns method, that also cache output inside iterator.
-func (i *CommentIterator) Columns() ([]string, error) {
+func (i *PostIterator) Columns() ([]string, error) {
 	if i.cols == nil {
 		cols, err := i.rows.Columns()
 		if err != nil {
@@ -1322,13 +1332,13 @@ func (i *CommentIterator) Columns() ([]string, error) {
 	return i.cols, nil
 }
 
-// Ent is wrapper around Comment method that makes iterator more generic.
-func (i *CommentIterator) Ent() (interface{}, error) {
-	return i.Comment()
+// Ent is wrapper around Post method that makes iterator more generic.
+func (i *PostIterator) Ent() (interface{}, error) {
+	return i.Post()
 }
 
-func (i *CommentIterator) Comment() (*CommentEntity, error) {
-	var ent CommentEntity
+func (i *PostIterator) Post() (*PostEntity, error) {
+	var ent PostEntity
 	cols, err := i.Columns()
 	if err != nil {
 		return nil, err
@@ -1338,41 +1348,27 @@ func (i *CommentIterator) Comment() (*CommentEntity, error) {
 	if err != nil {
 		return nil, err
 	}
-		var prop []interface{}
-			if i.expr.JoinUser != nil && i.expr.JoinUser.Kind.Actionable() && i.expr.JoinUser.Fetch {
-				ent.User = &UserEntity{}
-				if prop, err = ent.User.Props(); err != nil {
-					return nil, err
-				}
-				props = append(props, prop...)
-			}
-			if i.expr.JoinWpis != nil && i.expr.JoinWpis.Kind.Actionable() && i.expr.JoinWpis.Fetch {
-				ent.Wpis = &PostEntity{}
-				if prop, err = ent.Wpis.Props(); err != nil {
-					return nil, err
-				}
-				props = append(props, prop...)
-			}
 	if err := i.rows.Scan(props...); err != nil {
 		return nil, err
 	}
 	return &ent, nil
 }
 
-type CommentCriteria struct {
-UserID sql.NullInt64
-	operator string
-	child, sibling, parent *CommentCriteria
+type PostCriteria struct {
+	Body                   []byte
+	ID                     sql.NullInt64
+	operator               string
+	child, sibling, parent *PostCriteria
 }
 
-func CommentOperand(operator string, operands ...*CommentCriteria) *CommentCriteria {
+func PostOperand(operator string, operands ...*PostCriteria) *PostCriteria {
 	if len(operands) == 0 {
-		return &CommentCriteria{operator: operator}
+		return &PostCriteria{operator: operator}
 	}
 
-	parent := &CommentCriteria{
+	parent := &PostCriteria{
 		operator: operator,
-		child: operands[0],
+		child:    operands[0],
 	}
 
 	for i := 0; i < len(operands); i++ {
@@ -1385,56 +1381,50 @@ func CommentOperand(operator string, operands ...*CommentCriteria) *CommentCrite
 	return parent
 }
 
-func CommentOr(operands ...*CommentCriteria) *CommentCriteria {
-	return CommentOperand("OR", operands...)
+func PostOr(operands ...*PostCriteria) *PostCriteria {
+	return PostOperand("OR", operands...)
 }
 
-func CommentAnd(operands ...*CommentCriteria) *CommentCriteria {
-	return CommentOperand("AND", operands...)
+func PostAnd(operands ...*PostCriteria) *PostCriteria {
+	return PostOperand("AND", operands...)
 }
 
-type CommentFindExpr struct {
-Where *CommentCriteria
-Offset, Limit int64
-Columns []string
-OrderBy []RowOrder
-JoinUser *UserJoin
-JoinWpis *PostJoin
+type PostFindExpr struct {
+	Where         *PostCriteria
+	Offset, Limit int64
+	Columns       []string
+	OrderBy       []RowOrder
 }
 
-type CommentJoin struct {
-On, Where *CommentCriteria
-Fetch bool
-Kind JoinType
-JoinUser *UserJoin
-JoinWpis *PostJoin
+type PostJoin struct {
+	On, Where *PostCriteria
+	Fetch     bool
+	Kind      JoinType
 }
 
-type CommentCountExpr struct {
-Where *CommentCriteria
-JoinUser *UserJoin
-JoinWpis *PostJoin
+type PostCountExpr struct {
+	Where *PostCriteria
 }
 
-type CommentPatch struct {
-UserID sql.NullInt64
+type PostPatch struct {
+	Body []byte
 }
 
-type CommentRepositoryBase struct {
-	Table string
+type PostRepositoryBase struct {
+	Table   string
 	Columns []string
-	DB *sql.DB
-	Log LogFunc
+	DB      *sql.DB
+	Log     LogFunc
 }
 
-		func (r *CommentRepositoryBase) Tx(tx *sql.Tx) (*CommentRepositoryBaseTx, error) {
-	return &CommentRepositoryBaseTx{
+func (r *PostRepositoryBase) Tx(tx *sql.Tx) (*PostRepositoryBaseTx, error) {
+	return &PostRepositoryBaseTx{
 		base: r,
-		tx: tx,
+		tx:   tx,
 	}, nil
 }
 
-		func (r *CommentRepositoryBase) BeginTx(ctx context.Context) (*CommentRepositoryBaseTx, error) {
+func (r *PostRepositoryBase) BeginTx(ctx context.Context) (*PostRepositoryBaseTx, error) {
 	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -1442,7 +1432,7 @@ type CommentRepositoryBase struct {
 	return r.Tx(tx)
 }
 
-func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx *CommentRepositoryBaseTx) error, attempts int) (err error) {
+func (r PostRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx *PostRepositoryBaseTx) error, attempts int) (err error) {
 	return RunInTransaction(ctx, r.DB, func(tx *sql.Tx) error {
 		rtx, err := r.Tx(tx)
 		if err != nil {
@@ -1452,86 +1442,87 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 	}, attempts)
 }
 
-		func (r *CommentRepositoryBase) InsertQuery(e *CommentEntity, read bool) (string, []interface{}, error) {
-		insert := NewComposer(1)
-		columns := bytes.NewBuffer(nil)
-		buf := bytes.NewBufferString("INSERT INTO ")
-		buf.WriteString(r.Table)
-	
-					if e.UserID.Valid {
-			if columns.Len() > 0 {
-				if _, err := columns.WriteString(", "); err != nil {
-					return "", nil, err
-				}
-			}
-			if _, err := columns.WriteString(TableCommentColumnUserID); err != nil {
+func (r *PostRepositoryBase) InsertQuery(e *PostEntity, read bool) (string, []interface{}, error) {
+	insert := NewComposer(2)
+	columns := bytes.NewBuffer(nil)
+	buf := bytes.NewBufferString("INSERT INTO ")
+	buf.WriteString(r.Table)
+
+	if e.Body != nil {
+		if columns.Len() > 0 {
+			if _, err := columns.WriteString(", "); err != nil {
 				return "", nil, err
 			}
-			if insert.Dirty {
-				if _, err := insert.WriteString(", "); err != nil {
-					return "", nil, err
-				}
-			}
-			if err := insert.WritePlaceholder(); err != nil {
+		}
+		if _, err := columns.WriteString(TablePostColumnBody); err != nil {
+			return "", nil, err
+		}
+		if insert.Dirty {
+			if _, err := insert.WriteString(", "); err != nil {
 				return "", nil, err
 			}
-			insert.Add(e.UserID)
-			insert.Dirty=true
 		}
-
-		if columns.Len() > 0 {
-			buf.WriteString(" (")
-			buf.ReadFrom(columns)
-			buf.WriteString(") VALUES (")
-			buf.ReadFrom(insert)
-			buf.WriteString(") ")
-			if read {
-				buf.WriteString("RETURNING ")
-				if len(r.Columns) > 0 {
-					buf.WriteString(strings.Join(r.Columns, ", "))
-				} else {
-		buf.WriteString("user_id")
-	}
-			}
+		if err := insert.WritePlaceholder(); err != nil {
+			return "", nil, err
 		}
-		return buf.String(), insert.Args(), nil
+		insert.Add(e.Body)
+		insert.Dirty = true
 	}
 
-		func (r *CommentRepositoryBase) insert(ctx context.Context, tx *sql.Tx, e *CommentEntity) (*CommentEntity, error) {
-			query, args, err := r.InsertQuery(e, true)
-			if err != nil {
-				return nil, err
-			}
-
-			var row *sql.Row
-			if tx == nil {
-				row = r.DB.QueryRowContext(ctx, query, args...)
-			} else {
-				row = tx.QueryRowContext(ctx, query, args...)
-			}
-			err = row.Scan(
-&e.UserID,
-)
-		if r.Log != nil {
-			if tx == nil {
-				r.Log(err, TableComment, "insert", query, args...)
+	if columns.Len() > 0 {
+		buf.WriteString(" (")
+		buf.ReadFrom(columns)
+		buf.WriteString(") VALUES (")
+		buf.ReadFrom(insert)
+		buf.WriteString(") ")
+		if read {
+			buf.WriteString("RETURNING ")
+			if len(r.Columns) > 0 {
+				buf.WriteString(strings.Join(r.Columns, ", "))
 			} else {
-				r.Log(err, TableComment, "insert tx", query, args...)
+				buf.WriteString("body, id")
 			}
 		}
-		if err != nil {
-			return nil, err
-		}
-		return e, nil
 	}
+	return buf.String(), insert.Args(), nil
+}
 
-		func (r *CommentRepositoryBase) Insert(ctx context.Context, e *CommentEntity) (*CommentEntity, error) {
-			return r.insert(ctx, nil, e)
+func (r *PostRepositoryBase) insert(ctx context.Context, tx *sql.Tx, e *PostEntity) (*PostEntity, error) {
+	query, args, err := r.InsertQuery(e, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var row *sql.Row
+	if tx == nil {
+		row = r.DB.QueryRowContext(ctx, query, args...)
+	} else {
+		row = tx.QueryRowContext(ctx, query, args...)
+	}
+	err = row.Scan(
+		&e.Body,
+		&e.ID,
+	)
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "insert", query, args...)
+		} else {
+			r.Log(err, TablePost, "insert tx", query, args...)
 		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
 
-		func CommentCriteriaWhereClause(comp *Composer, c *CommentCriteria, id int) (error) {
+func (r *PostRepositoryBase) Insert(ctx context.Context, e *PostEntity) (*PostEntity, error) {
+	return r.insert(ctx, nil, e)
+}
+
+func PostCriteriaWhereClause(comp *Composer, c *PostCriteria, id int) error {
 	if c.child == nil {
-		return _CommentCriteriaWhereClause(comp, c, id)
+		return _PostCriteriaWhereClause(comp, c, id)
 	}
 	node := c
 	sibling := false
@@ -1546,7 +1537,7 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 			} else {
 				comp.Dirty = false
 				comp.WriteString("(")
-				if err := _CommentCriteriaWhereClause(comp, node, id); err != nil {
+				if err := _PostCriteriaWhereClause(comp, node, id); err != nil {
 					return err
 				}
 				comp.WriteString(")")
@@ -1572,58 +1563,1068 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 		break
 	}
 	return nil
-	}
+}
 
-		func _CommentCriteriaWhereClause(comp *Composer, c *CommentCriteria, id int) (error) {
-				if c.UserID.Valid {if comp.Dirty {
-				comp.WriteString(" AND ")
-			}
-				if err := comp.WriteAlias(id); err != nil {
-					return err
-				}
-				if _, err := comp.WriteString(TableCommentColumnUserID); err != nil {
-					return err
-				}
-			if _, err := comp.WriteString("="); err != nil {
-				return err
-			}
-			if err := comp.WritePlaceholder(); err != nil {
-				return err
-			}
-			comp.Add(c.UserID)
-			comp.Dirty=true
-		}
-	return nil
+func _PostCriteriaWhereClause(comp *Composer, c *PostCriteria, id int) error {
+	if c.Body != nil {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
 		}
-
-		func (r *CommentRepositoryBase) FindQuery(fe *CommentFindExpr) (string, []interface{}, error) {
-		comp := NewComposer(1)
-		buf := bytes.NewBufferString("SELECT ")
-		if len(fe.Columns) == 0 {
-		buf.WriteString("t0.user_id")
-		} else {
-			buf.WriteString(strings.Join(fe.Columns, ", "))
+		if err := comp.WriteAlias(id); err != nil {
+			return err
 		}
-			if fe.JoinUser != nil && fe.JoinUser.Kind.Actionable() && fe.JoinUser.Fetch {
-		buf.WriteString(", t1.id, t1.name")
+		if _, err := comp.WriteString(TablePostColumnBody); err != nil {
+			return err
 		}
-			if fe.JoinWpis != nil && fe.JoinWpis.Kind.Actionable() && fe.JoinWpis.Fetch {
-		buf.WriteString(", t2.body")
+		if _, err := comp.WriteString("="); err != nil {
+			return err
 		}
-		buf.WriteString(" FROM ")
-		buf.WriteString(r.Table)
-		buf.WriteString(" AS t0")
-			if fe.JoinUser != nil && fe.JoinUser.Kind.Actionable() {
-			joinClause(comp, fe.JoinUser.Kind, "example.user AS t1 ON t0.user_id=t1.id")
-		if fe.JoinUser.On != nil {
-			comp.Dirty = true
-			if err := UserCriteriaWhereClause(comp, fe.JoinUser.On, 1); err != nil {
-				return "", nil, err
-			}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.Body)
+		comp.Dirty = true
+	}
+	if c.ID.Valid {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
+		}
+		if err := comp.WriteAlias(id); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(TablePostColumnID); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString("="); err != nil {
+			return err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.ID)
+		comp.Dirty = true
+	}
+	return nil
+}
+
+func (r *PostRepositoryBase) FindQuery(fe *PostFindExpr) (string, []interface{}, error) {
+	comp := NewComposer(2)
+	buf := bytes.NewBufferString("SELECT ")
+	if len(fe.Columns) == 0 {
+		buf.WriteString("t0.body, t0.id")
+	} else {
+		buf.WriteString(strings.Join(fe.Columns, ", "))
+	}
+	buf.WriteString(" FROM ")
+	buf.WriteString(r.Table)
+	buf.WriteString(" AS t0")
+	if comp.Dirty {
+		buf.ReadFrom(comp)
+		comp.Dirty = false
+	}
+	if fe.Where != nil {
+		if err := PostCriteriaWhereClause(comp, fe.Where, 0); err != nil {
+			return "", nil, err
+		}
+	}
+	if comp.Dirty {
+		if _, err := buf.WriteString(" WHERE "); err != nil {
+			return "", nil, err
+		}
+		buf.ReadFrom(comp)
+	}
+
+	if len(fe.OrderBy) > 0 {
+		i := 0
+		for _, order := range fe.OrderBy {
+			for _, columnName := range TablePostColumns {
+				if order.Name == columnName {
+					if i == 0 {
+						comp.WriteString(" ORDER BY ")
+					}
+					if i > 0 {
+						if _, err := comp.WriteString(", "); err != nil {
+							return "", nil, err
+						}
+					}
+					if _, err := comp.WriteString(order.Name); err != nil {
+						return "", nil, err
+					}
+					if order.Descending {
+						if _, err := comp.WriteString(" DESC"); err != nil {
+							return "", nil, err
+						}
+					}
+					i++
+					break
+				}
+			}
+		}
+	}
+	if fe.Offset > 0 {
+		if _, err := comp.WriteString(" OFFSET "); err != nil {
+			return "", nil, err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		if _, err := comp.WriteString(" "); err != nil {
+			return "", nil, err
+		}
+		comp.Add(fe.Offset)
+	}
+	if fe.Limit > 0 {
+		if _, err := comp.WriteString(" LIMIT "); err != nil {
+			return "", nil, err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		if _, err := comp.WriteString(" "); err != nil {
+			return "", nil, err
+		}
+		comp.Add(fe.Limit)
+	}
+
+	buf.ReadFrom(comp)
+
+	return buf.String(), comp.Args(), nil
+}
+
+func (r *PostRepositoryBase) find(ctx context.Context, tx *sql.Tx, fe *PostFindExpr) ([]*PostEntity, error) {
+	query, args, err := r.FindQuery(fe)
+	if err != nil {
+		return nil, err
+	}
+	var rows *sql.Rows
+	if tx == nil {
+		rows, err = r.DB.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = tx.QueryContext(ctx, query, args...)
+	}
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "find", query, args...)
+		} else {
+			r.Log(err, TablePost, "find tx", query, args...)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var (
+		entities []*PostEntity
+		props    []interface{}
+	)
+	for rows.Next() {
+		var ent PostEntity
+		if props, err = ent.Props(); err != nil {
+			return nil, err
+		}
+		err = rows.Scan(props...)
+		if err != nil {
+			return nil, err
+		}
+
+		entities = append(entities, &ent)
+	}
+	err = rows.Err()
+	if r.Log != nil {
+		r.Log(err, TablePost, "find", query, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *PostRepositoryBase) Find(ctx context.Context, fe *PostFindExpr) ([]*PostEntity, error) {
+	return r.find(ctx, nil, fe)
+}
+
+func (r *PostRepositoryBase) findIter(ctx context.Context, tx *sql.Tx, fe *PostFindExpr) (*PostIterator, error) {
+	query, args, err := r.FindQuery(fe)
+	if err != nil {
+		return nil, err
+	}
+	var rows *sql.Rows
+	if tx == nil {
+		rows, err = r.DB.QueryContext(ctx, query, args...)
+	} else {
+		rows, err = tx.QueryContext(ctx, query, args...)
+	}
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "find iter", query, args...)
+		} else {
+			r.Log(err, TablePost, "find iter tx", query, args...)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &PostIterator{
+		rows: rows,
+		expr: fe,
+		cols: fe.Columns,
+	}, nil
+}
+
+func (r *PostRepositoryBase) FindIter(ctx context.Context, fe *PostFindExpr) (*PostIterator, error) {
+	return r.findIter(ctx, nil, fe)
+}
+
+func (r *PostRepositoryBase) findOneByID(ctx context.Context, tx *sql.Tx, pk int64) (*PostEntity, error) {
+	find := NewComposer(2)
+	find.WriteString("SELECT ")
+	if len(r.Columns) == 0 {
+		find.WriteString("body, id")
+	} else {
+		find.WriteString(strings.Join(r.Columns, ", "))
+	}
+	find.WriteString(" FROM ")
+	find.WriteString(TablePost)
+	find.WriteString(" WHERE ")
+	find.WriteString(TablePostColumnID)
+	find.WriteString("=")
+	find.WritePlaceholder()
+	find.Add(pk)
+	var (
+		ent PostEntity
+	)
+	props, err := ent.Props(r.Columns...)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		err = r.DB.QueryRowContext(ctx, find.String(), find.Args()...).Scan(props...)
+	} else {
+		err = tx.QueryRowContext(ctx, find.String(), find.Args()...).Scan(props...)
+	}
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "find by primary key", find.String(), find.Args()...)
+		} else {
+			r.Log(err, TablePost, "find by primary key tx", find.String(), find.Args()...)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (r *PostRepositoryBase) FindOneByID(ctx context.Context, pk int64) (*PostEntity, error) {
+	return r.findOneByID(ctx, nil, pk)
+}
+
+func (r *PostRepositoryBase) UpdateOneByIDQuery(pk int64, p *PostPatch) (string, []interface{}, error) {
+	buf := bytes.NewBufferString("UPDATE ")
+	buf.WriteString(r.Table)
+	update := NewComposer(2)
+	if p.Body != nil {
+		if update.Dirty {
+			if _, err := update.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if _, err := update.WriteString(TablePostColumnBody); err != nil {
+			return "", nil, err
+		}
+		if _, err := update.WriteString("="); err != nil {
+			return "", nil, err
+		}
+		if err := update.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		update.Add(p.Body)
+		update.Dirty = true
+
+	}
+	if !update.Dirty {
+		return "", nil, errors.New("Post update failure, nothing to update")
+	}
+	buf.WriteString(" SET ")
+	buf.ReadFrom(update)
+	buf.WriteString(" WHERE ")
+
+	update.WriteString(TablePostColumnID)
+	update.WriteString("=")
+	update.WritePlaceholder()
+	update.Add(pk)
+
+	buf.ReadFrom(update)
+	buf.WriteString(" RETURNING ")
+	if len(r.Columns) > 0 {
+		buf.WriteString(strings.Join(r.Columns, ", "))
+	} else {
+		buf.WriteString("body, id")
+	}
+	return buf.String(), update.Args(), nil
+}
+
+func (r *PostRepositoryBase) updateOneByID(ctx context.Context, tx *sql.Tx, pk int64, p *PostPatch) (*PostEntity, error) {
+	query, args, err := r.UpdateOneByIDQuery(pk, p)
+	if err != nil {
+		return nil, err
+	}
+	var ent PostEntity
+	props, err := ent.Props(r.Columns...)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		err = r.DB.QueryRowContext(ctx, query, args...).Scan(props...)
+	} else {
+		err = tx.QueryRowContext(ctx, query, args...).Scan(props...)
+	}
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "update by primary key", query, args...)
+		} else {
+			r.Log(err, TablePost, "update by primary key tx", query, args...)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (r *PostRepositoryBase) UpdateOneByID(ctx context.Context, pk int64, p *PostPatch) (*PostEntity, error) {
+	return r.updateOneByID(ctx, nil, pk, p)
+}
+
+func (r *PostRepositoryBase) FindOneByIDAndUpdate(ctx context.Context, pk int64, p *PostPatch) (before, after *PostEntity, err error) {
+	find := NewComposer(2)
+	find.WriteString("SELECT ")
+	if len(r.Columns) == 0 {
+		find.WriteString("body, id")
+	} else {
+		find.WriteString(strings.Join(r.Columns, ", "))
+	}
+	find.WriteString(" FROM ")
+	find.WriteString(TablePost)
+	find.WriteString(" WHERE ")
+	find.WriteString(TablePostColumnID)
+	find.WriteString("=")
+	find.WritePlaceholder()
+	find.Add(pk)
+	find.WriteString(" FOR UPDATE")
+	query, args, err := r.UpdateOneByIDQuery(pk, p)
+	if err != nil {
+		return
+	}
+	var (
+		oldEnt, newEnt PostEntity
+	)
+	oldProps, err := oldEnt.Props(r.Columns...)
+	if err != nil {
+		return
+	}
+	newProps, err := newEnt.Props(r.Columns...)
+	if err != nil {
+		return
+	}
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return
+	}
+	err = tx.QueryRowContext(ctx, find.String(), find.Args()...).Scan(oldProps...)
+	if r.Log != nil {
+		r.Log(err, TablePost, "find by primary key", find.String(), find.Args()...)
+	}
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+	err = tx.QueryRowContext(ctx, query, args...).Scan(newProps...)
+	if r.Log != nil {
+		r.Log(err, TablePost, "update by primary key", query, args...)
+	}
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+	return &oldEnt, &newEnt, nil
+}
+
+func (r *PostRepositoryBase) UpsertQuery(e *PostEntity, p *PostPatch, inf ...string) (string, []interface{}, error) {
+	upsert := NewComposer(4)
+	columns := bytes.NewBuffer(nil)
+	buf := bytes.NewBufferString("INSERT INTO ")
+	buf.WriteString(r.Table)
+
+	if e.Body != nil {
+		if columns.Len() > 0 {
+			if _, err := columns.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if _, err := columns.WriteString(TablePostColumnBody); err != nil {
+			return "", nil, err
+		}
+		if upsert.Dirty {
+			if _, err := upsert.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := upsert.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		upsert.Add(e.Body)
+		upsert.Dirty = true
+	}
+
+	if upsert.Dirty {
+		buf.WriteString(" (")
+		buf.ReadFrom(columns)
+		buf.WriteString(") VALUES (")
+		buf.ReadFrom(upsert)
+		buf.WriteString(")")
+	}
+	buf.WriteString(" ON CONFLICT ")
+	if len(inf) > 0 {
+		upsert.Dirty = false
+		if p.Body != nil {
+			if upsert.Dirty {
+				if _, err := upsert.WriteString(", "); err != nil {
+					return "", nil, err
+				}
+			}
+			if _, err := upsert.WriteString(TablePostColumnBody); err != nil {
+				return "", nil, err
+			}
+			if _, err := upsert.WriteString("="); err != nil {
+				return "", nil, err
+			}
+			if err := upsert.WritePlaceholder(); err != nil {
+				return "", nil, err
+			}
+			upsert.Add(p.Body)
+			upsert.Dirty = true
+
+		}
+	}
+	if len(inf) > 0 && upsert.Dirty {
+		buf.WriteString("(")
+		for j, i := range inf {
+			if j != 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(i)
+		}
+		buf.WriteString(")")
+		buf.WriteString(" DO UPDATE SET ")
+		buf.ReadFrom(upsert)
+	} else {
+		buf.WriteString(" DO NOTHING ")
+	}
+	if upsert.Dirty {
+		buf.WriteString(" RETURNING ")
+		if len(r.Columns) > 0 {
+			buf.WriteString(strings.Join(r.Columns, ", "))
+		} else {
+			buf.WriteString("body, id")
+		}
+	}
+	return buf.String(), upsert.Args(), nil
+}
+
+func (r *PostRepositoryBase) upsert(ctx context.Context, tx *sql.Tx, e *PostEntity, p *PostPatch, inf ...string) (*PostEntity, error) {
+	query, args, err := r.UpsertQuery(e, p, inf...)
+	if err != nil {
+		return nil, err
+	}
+
+	var row *sql.Row
+	if tx == nil {
+		row = r.DB.QueryRowContext(ctx, query, args...)
+	} else {
+		row = tx.QueryRowContext(ctx, query, args...)
+	}
+	err = row.Scan(
+		&e.Body,
+		&e.ID,
+	)
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "upsert", query, args...)
+		} else {
+			r.Log(err, TablePost, "upsert tx", query, args...)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (r *PostRepositoryBase) Upsert(ctx context.Context, e *PostEntity, p *PostPatch, inf ...string) (*PostEntity, error) {
+	return r.upsert(ctx, nil, e, p, inf...)
+}
+
+func (r *PostRepositoryBase) count(ctx context.Context, tx *sql.Tx, exp *PostCountExpr) (int64, error) {
+	query, args, err := r.FindQuery(&PostFindExpr{
+		Where:   exp.Where,
+		Columns: []string{"COUNT(*)"},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if tx == nil {
+		err = r.DB.QueryRowContext(ctx, query, args...).Scan(&count)
+	} else {
+		err = tx.QueryRowContext(ctx, query, args...).Scan(&count)
+	}
+	if r.Log != nil {
+		if tx == nil {
+			r.Log(err, TablePost, "count", query, args...)
+		} else {
+			r.Log(err, TablePost, "count tx", query, args...)
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *PostRepositoryBase) Count(ctx context.Context, exp *PostCountExpr) (int64, error) {
+	return r.count(ctx, nil, exp)
+}
+
+func (r *PostRepositoryBase) deleteOneByID(ctx context.Context, tx *sql.Tx, pk int64) (int64, error) {
+	find := NewComposer(2)
+	find.WriteString("DELETE FROM ")
+	find.WriteString(TablePost)
+	find.WriteString(" WHERE ")
+	find.WriteString(TablePostColumnID)
+	find.WriteString("=")
+	find.WritePlaceholder()
+	find.Add(pk)
+	var (
+		err error
+		res sql.Result
+	)
+	if tx == nil {
+		res, err = r.DB.ExecContext(ctx, find.String(), find.Args()...)
+	} else {
+		res, err = tx.ExecContext(ctx, find.String(), find.Args()...)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *PostRepositoryBase) DeleteOneByID(ctx context.Context, pk int64) (int64, error) {
+	return r.deleteOneByID(ctx, nil, pk)
+}
+
+type PostRepositoryBaseTx struct {
+	base *PostRepositoryBase
+	tx   *sql.Tx
+}
+
+func (r PostRepositoryBaseTx) Commit() error {
+	return r.tx.Commit()
+}
+
+func (r PostRepositoryBaseTx) Rollback() error {
+	return r.tx.Rollback()
+}
+
+func (r *PostRepositoryBaseTx) Insert(ctx context.Context, e *PostEntity) (*PostEntity, error) {
+	return r.base.insert(ctx, r.tx, e)
+}
+
+func (r *PostRepositoryBaseTx) Find(ctx context.Context, fe *PostFindExpr) ([]*PostEntity, error) {
+	return r.base.find(ctx, r.tx, fe)
+}
+
+func (r *PostRepositoryBaseTx) FindIter(ctx context.Context, fe *PostFindExpr) (*PostIterator, error) {
+	return r.base.findIter(ctx, r.tx, fe)
+}
+
+func (r *PostRepositoryBaseTx) FindOneByID(ctx context.Context, pk int64) (*PostEntity, error) {
+	return r.base.findOneByID(ctx, r.tx, pk)
+}
+
+func (r *PostRepositoryBaseTx) UpdateOneByID(ctx context.Context, pk int64, p *PostPatch) (*PostEntity, error) {
+	return r.base.updateOneByID(ctx, r.tx, pk, p)
+}
+
+func (r *PostRepositoryBaseTx) Upsert(ctx context.Context, e *PostEntity, p *PostPatch, inf ...string) (*PostEntity, error) {
+	return r.base.upsert(ctx, r.tx, e, p, inf...)
+}
+
+func (r *PostRepositoryBaseTx) Count(ctx context.Context, exp *PostCountExpr) (int64, error) {
+	return r.base.count(ctx, r.tx, exp)
+}
+
+func (r *PostRepositoryBaseTx) DeleteOneByID(ctx context.Context, pk int64) (int64, error) {
+	return r.base.deleteOneByID(ctx, r.tx, pk)
+}
+
+const (
+TableCommentConstraintUserIDForeignKey = "example.comment_user_id_fkey"
+	TableCommentConstraintPostIDForeignKey = "example.comment_post_id_fkey"
+)
+
+const (
+TableComment = "example.comment"
+	TableCommentColumnPostID = "post_id"
+TableCommentColumnUserID = "user_id"
+)
+
+var TableCommentColumns = []string{
+	TableCommentColumnPostID,
+TableCommentColumnUserID,
+}
+
+// CommentEntity ...
+type CommentEntity struct{
+	// PostID ...
+	PostID sql.NullInt64
+// UserID ...
+UserID sql.NullInt64
+// User ...
+User *UserEntity
+// Wpis ...
+Wpis *PostEntity}
+
+		func (e *CommentEntity) Prop(cn string) (interface{}, bool) {
+		switch cn {
+
+	case TableCommentColumnPostID:
+		return &e.PostID, true
+			case TableCommentColumnUserID:
+				return &e.UserID, true
+	default:
+		return nil, false
+	}
+}
+
+		func (e *CommentEntity) Props(cns ...string) ([]interface{}, error) {
+		if len(cns) == 0 {
+			cns = TableCommentColumns
+		}
+		res := make([]interface{}, 0, len(cns))
+		for _, cn := range cns {
+			if prop, ok := e.Prop(cn); ok {
+				res = append(res, prop)
+			} else {
+				return nil, fmt.Errorf("unexpected column provided: %s", cn)
+			}
+		}
+		return res, nil
+		}
+
+		// ScanCommentRows helps to scan rows straight to the slice of entities.
+		func ScanCommentRows(rows Rows) (entities []*CommentEntity, err error) {
+		for rows.Next() {
+			var ent CommentEntity
+			err = rows.Scan(
+			&ent.PostID,
+			&ent.UserID,
+)
+			if err != nil {
+				return
+			}
+
+			entities = append(entities, &ent)
+		}
+		if err = rows.Err(); err != nil {
+			return
+		}
+
+		return
+	}
+
+// CommentIterator is not thread safe.
+type CommentIterator struct {
+	rows Rows
+	cols []string
+	expr *CommentFindExpr
+}
+func (i *CommentIterator) Next() bool {
+	return i.rows.Next()
+}
+
+func (i *CommentIterator) Close() error {
+	return i.rows.Close()
+}
+
+func (i *CommentIterator) Err() error {
+	return i.rows.Err()
+}
+
+// Columns is wrapper around sql.Rows.Columns method, that also cache output inside iterator.
+func (i *CommentIterator) Columns() ([]string, error) {
+	if i.cols == nil {
+		cols, err := i.rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		i.cols = cols
+	}
+	return i.cols, nil
+}
+
+// Ent is wrapper around Comment method that makes iterator more generic.
+func (i *CommentIterator) Ent() (interface{}, error) {
+	return i.Comment()
+}
+
+func (i *CommentIterator) Comment() (*CommentEntity, error) {
+	var ent CommentEntity
+	cols, err := i.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := ent.Props(cols...)
+	if err != nil {
+		return nil, err
+	}
+		var prop []interface{}
+			if i.expr.JoinUser != nil && i.expr.JoinUser.Kind.Actionable() && i.expr.JoinUser.Fetch {
+				ent.User = &UserEntity{}
+				if prop, err = ent.User.Props(); err != nil {
+					return nil, err
+				}
+				props = append(props, prop...)
+			}
+			if i.expr.JoinWpis != nil && i.expr.JoinWpis.Kind.Actionable() && i.expr.JoinWpis.Fetch {
+				ent.Wpis = &PostEntity{}
+				if prop, err = ent.Wpis.Props(); err != nil {
+					return nil, err
+				}
+				props = append(props, prop...)
+			}
+	if err := i.rows.Scan(props...); err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
+
+type CommentCriteria struct {
+	PostID                 sql.NullInt64
+UserID sql.NullInt64
+	operator string
+	child, sibling, parent *CommentCriteria
+}
+
+func CommentOperand(operator string, operands ...*CommentCriteria) *CommentCriteria {
+	if len(operands) == 0 {
+		return &CommentCriteria{operator: operator}
+	}
+
+	parent := &CommentCriteria{
+		operator: operator,
+		child: operands[0],
+	}
+
+	for i := 0; i < len(operands); i++ {
+		if i < len(operands)-1 {
+			operands[i].sibling = operands[i+1]
+		}
+		operands[i].parent = parent
+	}
+
+	return parent
+}
+
+func CommentOr(operands ...*CommentCriteria) *CommentCriteria {
+	return CommentOperand("OR", operands...)
+}
+
+func CommentAnd(operands ...*CommentCriteria) *CommentCriteria {
+	return CommentOperand("AND", operands...)
+}
+
+type CommentFindExpr struct {
+Where *CommentCriteria
+Offset, Limit int64
+Columns []string
+OrderBy []RowOrder
+JoinUser *UserJoin
+JoinWpis *PostJoin
+}
+
+type CommentJoin struct {
+On, Where *CommentCriteria
+Fetch bool
+Kind JoinType
+JoinUser *UserJoin
+JoinWpis *PostJoin
+}
+
+type CommentCountExpr struct {
+Where *CommentCriteria
+JoinUser *UserJoin
+JoinWpis *PostJoin
+}
+
+type CommentPatch struct {
+	PostID sql.NullInt64
+UserID sql.NullInt64
+}
+
+type CommentRepositoryBase struct {
+	Table string
+	Columns []string
+	DB *sql.DB
+	Log LogFunc
+}
+
+		func (r *CommentRepositoryBase) Tx(tx *sql.Tx) (*CommentRepositoryBaseTx, error) {
+	return &CommentRepositoryBaseTx{
+		base: r,
+		tx: tx,
+	}, nil
+}
+
+		func (r *CommentRepositoryBase) BeginTx(ctx context.Context) (*CommentRepositoryBaseTx, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.Tx(tx)
+}
+
+func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx *CommentRepositoryBaseTx) error, attempts int) (err error) {
+	return RunInTransaction(ctx, r.DB, func(tx *sql.Tx) error {
+		rtx, err := r.Tx(tx)
+		if err != nil {
+			return err
+		}
+		return fn(rtx)
+	}, attempts)
+}
+
+		func (r *CommentRepositoryBase) InsertQuery(e *CommentEntity, read bool) (string, []interface{}, error) {
+	insert := NewComposer(2)
+		columns := bytes.NewBuffer(nil)
+		buf := bytes.NewBufferString("INSERT INTO ")
+		buf.WriteString(r.Table)
+	
+	if e.PostID.Valid {
+		if columns.Len() > 0 {
+			if _, err := columns.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if _, err := columns.WriteString(TableCommentColumnPostID); err != nil {
+			return "", nil, err
+		}
+		if insert.Dirty {
+			if _, err := insert.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := insert.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		insert.Add(e.PostID)
+		insert.Dirty = true
+	}
+
+					if e.UserID.Valid {
+			if columns.Len() > 0 {
+				if _, err := columns.WriteString(", "); err != nil {
+					return "", nil, err
+				}
+			}
+			if _, err := columns.WriteString(TableCommentColumnUserID); err != nil {
+				return "", nil, err
+			}
+			if insert.Dirty {
+				if _, err := insert.WriteString(", "); err != nil {
+					return "", nil, err
+				}
+			}
+			if err := insert.WritePlaceholder(); err != nil {
+				return "", nil, err
+			}
+			insert.Add(e.UserID)
+			insert.Dirty=true
+		}
+
+		if columns.Len() > 0 {
+			buf.WriteString(" (")
+			buf.ReadFrom(columns)
+			buf.WriteString(") VALUES (")
+			buf.ReadFrom(insert)
+			buf.WriteString(") ")
+			if read {
+				buf.WriteString("RETURNING ")
+				if len(r.Columns) > 0 {
+					buf.WriteString(strings.Join(r.Columns, ", "))
+				} else {
+				buf.WriteString("post_id, user_id")
+	}
+			}
+		}
+		return buf.String(), insert.Args(), nil
+	}
+
+		func (r *CommentRepositoryBase) insert(ctx context.Context, tx *sql.Tx, e *CommentEntity) (*CommentEntity, error) {
+			query, args, err := r.InsertQuery(e, true)
+			if err != nil {
+				return nil, err
+			}
+
+			var row *sql.Row
+			if tx == nil {
+				row = r.DB.QueryRowContext(ctx, query, args...)
+			} else {
+				row = tx.QueryRowContext(ctx, query, args...)
+			}
+			err = row.Scan(
+		&e.PostID,
+&e.UserID,
+)
+		if r.Log != nil {
+			if tx == nil {
+				r.Log(err, TableComment, "insert", query, args...)
+			} else {
+				r.Log(err, TableComment, "insert tx", query, args...)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+
+		func (r *CommentRepositoryBase) Insert(ctx context.Context, e *CommentEntity) (*CommentEntity, error) {
+			return r.insert(ctx, nil, e)
+		}
+
+		func CommentCriteriaWhereClause(comp *Composer, c *CommentCriteria, id int) (error) {
+	if c.child == nil {
+		return _CommentCriteriaWhereClause(comp, c, id)
+	}
+	node := c
+	sibling := false
+	for {
+		if !sibling {
+			if node.child != nil {
+				if node.parent != nil {
+					comp.WriteString("(")
+				}
+				node = node.child
+				continue
+			} else {
+				comp.Dirty = false
+				comp.WriteString("(")
+				if err := _CommentCriteriaWhereClause(comp, node, id); err != nil {
+					return err
+				}
+				comp.WriteString(")")
+			}
+		}
+		if node.sibling != nil {
+			sibling = false
+			comp.WriteString(" ")
+			comp.WriteString(node.parent.operator)
+			comp.WriteString(" ")
+			node = node.sibling
+			continue
+		}
+		if node.parent != nil {
+			sibling = true
+			if node.parent.parent != nil {
+				comp.WriteString(")")
+			}
+			node = node.parent
+			continue
+		}
+
+		break
+	}
+	return nil
+	}
+
+		func _CommentCriteriaWhereClause(comp *Composer, c *CommentCriteria, id int) (error) {
+	if c.PostID.Valid {
+		if comp.Dirty {
+			comp.WriteString(" AND ")
+		}
+		if err := comp.WriteAlias(id); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString(TableCommentColumnPostID); err != nil {
+			return err
+		}
+		if _, err := comp.WriteString("="); err != nil {
+			return err
+		}
+		if err := comp.WritePlaceholder(); err != nil {
+			return err
+		}
+		comp.Add(c.PostID)
+		comp.Dirty = true
+	}
+	if c.UserID.Valid {
+		if comp.Dirty {
+				comp.WriteString(" AND ")
+			}
+				if err := comp.WriteAlias(id); err != nil {
+					return err
+				}
+				if _, err := comp.WriteString(TableCommentColumnUserID); err != nil {
+					return err
+				}
+			if _, err := comp.WriteString("="); err != nil {
+				return err
+			}
+			if err := comp.WritePlaceholder(); err != nil {
+				return err
+			}
+			comp.Add(c.UserID)
+			comp.Dirty=true
+		}
+	return nil
+		}
+
+		func (r *CommentRepositoryBase) FindQuery(fe *CommentFindExpr) (string, []interface{}, error) {
+	comp := NewComposer(2)
+		buf := bytes.NewBufferString("SELECT ")
+		if len(fe.Columns) == 0 {
+		buf.WriteString("t0.post_id, t0.user_id")
+		} else {
+			buf.WriteString(strings.Join(fe.Columns, ", "))
+		}
+			if fe.JoinUser != nil && fe.JoinUser.Kind.Actionable() && fe.JoinUser.Fetch {
+		buf.WriteString(", t1.id, t1.name")
+		}
+			if fe.JoinWpis != nil && fe.JoinWpis.Kind.Actionable() && fe.JoinWpis.Fetch {
+		buf.WriteString(", t2.body, t2.id")
+		}
+		buf.WriteString(" FROM ")
+		buf.WriteString(r.Table)
+		buf.WriteString(" AS t0")
+			if fe.JoinUser != nil && fe.JoinUser.Kind.Actionable() {
+		joinClause(comp, fe.JoinUser.Kind, "example.\"user\" AS t1 ON t0.user_id=t1.id")
+		if fe.JoinUser.On != nil {
+			comp.Dirty = true
+			if err := UserCriteriaWhereClause(comp, fe.JoinUser.On, 1); err != nil {
+				return "", nil, err
+			}
 		}
 		}
 			if fe.JoinWpis != nil && fe.JoinWpis.Kind.Actionable() {
-			joinClause(comp, fe.JoinWpis.Kind, "post AS t2 ON ")
+		joinClause(comp, fe.JoinWpis.Kind, "example.post AS t2 ON t0.post_id=t2.id")
 		if fe.JoinWpis.On != nil {
 			comp.Dirty = true
 			if err := PostCriteriaWhereClause(comp, fe.JoinWpis.On, 2); err != nil {
@@ -1825,11 +2826,32 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 
 
 		func (r *CommentRepositoryBase) UpsertQuery(e *CommentEntity, p *CommentPatch, inf ...string) (string, []interface{}, error) {
-		upsert := NewComposer(2)
+	upsert := NewComposer(4)
 		columns := bytes.NewBuffer(nil)
 		buf := bytes.NewBufferString("INSERT INTO ")
 		buf.WriteString(r.Table)
 	
+	if e.PostID.Valid {
+		if columns.Len() > 0 {
+			if _, err := columns.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if _, err := columns.WriteString(TableCommentColumnPostID); err != nil {
+			return "", nil, err
+		}
+		if upsert.Dirty {
+			if _, err := upsert.WriteString(", "); err != nil {
+				return "", nil, err
+			}
+		}
+		if err := upsert.WritePlaceholder(); err != nil {
+			return "", nil, err
+		}
+		upsert.Add(e.PostID)
+		upsert.Dirty = true
+	}
+
 					if e.UserID.Valid {
 			if columns.Len() > 0 {
 				if _, err := columns.WriteString(", "); err != nil {
@@ -1861,6 +2883,25 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 		buf.WriteString(" ON CONFLICT ")
 		if len(inf) > 0 {
 		upsert.Dirty=false
+		if p.PostID.Valid {
+			if upsert.Dirty {
+				if _, err := upsert.WriteString(", "); err != nil {
+					return "", nil, err
+				}
+			}
+			if _, err := upsert.WriteString(TableCommentColumnPostID); err != nil {
+				return "", nil, err
+			}
+			if _, err := upsert.WriteString("="); err != nil {
+				return "", nil, err
+			}
+			if err := upsert.WritePlaceholder(); err != nil {
+				return "", nil, err
+			}
+			upsert.Add(p.PostID)
+			upsert.Dirty = true
+
+		}
 			if p.UserID.Valid {
 		if upsert.Dirty {
 			if _, err := upsert.WriteString(", "); err != nil {
@@ -1900,7 +2941,7 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 			if len(r.Columns) > 0 {
 				buf.WriteString(strings.Join(r.Columns, ", "))
 			} else {
-		buf.WriteString("user_id")
+			buf.WriteString("post_id, user_id")
 	}
 		}
 		return buf.String(), upsert.Args(), nil
@@ -1919,6 +2960,7 @@ func (r CommentRepositoryBase) RunInTransaction(ctx context.Context, fn func(rtx
 				row = tx.QueryRowContext(ctx, query, args...)
 			}
 			err = row.Scan(
+		&e.PostID,
 &e.UserID,
 	)
 		if r.Log != nil {
@@ -2135,6 +3177,56 @@ func (n *NullByteaArray) Scan(value interface{}) error {
 	return n.ByteaArray.Scan(value)
 }
 
+// TimeArray represents a one-dimensional array of the PostgreSQL timestamp, timestamptz and date types.
+type TimeArray []time.Time
+
+// Scan satisfy sql.Scanner interface.
+func (a *TimeArray) Scan(src interface{}) error {
+	var tmp pq.StringArray
+	if err := tmp.Scan(src); err != nil {
+		return err
+	}
+	if tmp == nil {
+		*a = nil
+		return nil
+	}
+	res := make(TimeArray, 0, len(tmp))
+	for _, s := range tmp {
+		v, err := pq.ParseTimestamp(nil, s)
+		if err != nil {
+			return err
+		}
+		res = append(res, v)
+	}
+	*a = res
+	return nil
+}
+
+// Value satisfy driver.Valuer interface.
+func (a TimeArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	tmp := make(pq.StringArray, 0, len(a))
+	for _, v := range a {
+		tmp = append(tmp, v.Format(time.RFC3339Nano))
+	}
+	return tmp.Value()
+}
+
+type NullTimeArray struct {
+	TimeArray
+	Valid bool
+}
+
+func (n *NullTimeArray) Scan(value interface{}) error {
+	if value == nil {
+		n.TimeArray, n.Valid = nil, false
+		return nil
+	}
+	n.Valid = true
+	return n.TimeArray.Scan(value)
+}
 
 const (
 	jsonArraySeparator     = ","
@@ -2333,7 +3425,6 @@ func (a JSONArrayFloat64) Value() (driver.Value, error) {
 	return buffer.Bytes(), nil
 }
 
-
 var (
 	// Space is a shorthand composition option that holds space.
 	Space = &CompositionOpts{
@@ -2458,3 +3549,277 @@ func (c *Composer) Args() []interface{} {
 	return c.args
 }
 `
+
+func TestGenerator_view(t *testing.T) {
+	s := pqt.NewSchema("example").
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey()))).
+		AddView(pqt.NewView("user_report", "SELECT id FROM example.user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithNotNull()))).
+		AddView(pqt.NewView("user_stats", "SELECT COUNT(*) FROM example.user", pqt.WithMaterialized()).
+			AddColumn(pqt.NewColumn("total", pqt.TypeIntegerBig(), pqt.WithNotNull())))
+
+	methods := generatedMethods(t, s, pqtgogen.ComponentAll)
+	for _, m := range []string{
+		"UserReportRepositoryBase.Find",
+		"UserReportRepositoryBase.FindIter",
+		"UserReportRepositoryBase.Count",
+		"UserReportRepositoryBaseTx.Find",
+		"UserStatsRepositoryBase.Refresh",
+		"UserStatsRepositoryBaseTx.Refresh",
+	} {
+		if !methods[m] {
+			t.Errorf("missing method %s", m)
+		}
+	}
+	for _, m := range []string{
+		"UserReportRepositoryBase.Insert",
+		"UserReportRepositoryBase.Upsert",
+		"UserReportRepositoryBase.Refresh",
+		"UserStatsRepositoryBase.Insert",
+		"UserStatsRepositoryBaseTx.Insert",
+	} {
+		if methods[m] {
+			t.Errorf("unexpected method %s", m)
+		}
+	}
+}
+
+func TestGenerator_partition(t *testing.T) {
+	createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+	s := pqt.NewSchema("example").
+		AddTable(pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByRange, createdAt)).
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig())).
+			AddColumn(createdAt).
+			AddPartition(pqt.PartitionDefault("event_default")))
+
+	methods := generatedMethods(t, s, pqtgogen.ComponentAll|pqtgogen.ComponentPartitions)
+	for _, m := range []string{
+		"EventRepositoryBase.Insert",
+		"EventRepositoryBase.Find",
+		"EventRepositoryBase.CreatePartitions",
+		"EventRepositoryBaseTx.CreatePartitions",
+	} {
+		if !methods[m] {
+			t.Errorf("missing method %s", m)
+		}
+	}
+	if methods := generatedMethods(t, s, pqtgogen.ComponentAll); methods["EventRepositoryBase.CreatePartitions"] {
+		t.Error("partitions helper should be generated only if requested")
+	}
+}
+
+// generatedMethods returns methods of the generated code in format <receiver>.<method>.
+func generatedMethods(t *testing.T, s *pqt.Schema, components pqtgogen.Component) map[string]bool {
+	t.Helper()
+
+	buf, err := (&pqtgogen.Generator{Version: 9.5, Pkg: "example", Components: components}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", buf, 0)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err.Error())
+	}
+	methods := make(map[string]bool)
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil {
+			continue
+		}
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		methods[recv.(*ast.Ident).Name+"."+fn.Name.Name] = true
+	}
+	return methods
+}
+
+func TestGenerator_inherits(t *testing.T) {
+	entry := pqt.NewTable("entry").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull()))
+	audit := pqt.NewTable("audit", pqt.WithInherits(entry)).
+		AddColumn(pqt.NewColumn("action", pqt.TypeText()))
+	s := pqt.NewSchema("example").AddTable(entry).AddTable(audit)
+
+	fields := structFields(t, s)
+	expected := map[string]string{
+		"AuditEntity":   "Action CreatedAt ID",
+		"AuditPatch":    "Action CreatedAt ID",
+		"AuditFindExpr": "Where Offset Limit Columns OrderBy",
+		"EntryEntity":   "CreatedAt ID",
+		"EntryFindExpr": "Where Offset Limit Columns OrderBy Only",
+	}
+	for name, exp := range expected {
+		if got := strings.Join(fields[name], " "); got != exp {
+			t.Errorf("%s: wrong fields, expected %s but got %s", name, exp, got)
+		}
+	}
+
+	methods := generatedMethods(t, s, pqtgogen.ComponentAll)
+	if !methods["EntryRepositoryBase.FindOneByID"] {
+		t.Error("parent table should keep its primary key methods")
+	}
+	if methods["AuditRepositoryBase.FindOneByID"] {
+		t.Error("primary key is not inherited, child table should not have primary key methods")
+	}
+}
+
+func TestGenerator_columnOrder(t *testing.T) {
+	entry := pqt.NewTable("entry", pqt.WithColumnOrder(pqt.ColumnOrderDeclared)).
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull()))
+	audit := pqt.NewTable("audit", pqt.WithInherits(entry), pqt.WithColumnOrder(pqt.ColumnOrderDeclared)).
+		AddColumn(pqt.NewColumn("action", pqt.TypeText())).
+		AddColumn(pqt.NewColumn("actor", pqt.TypeText(), pqt.WithPosition(-1)))
+	s := pqt.NewSchema("example").AddTable(entry).AddTable(audit)
+
+	fields := structFields(t, s)
+	expected := map[string]string{
+		"AuditEntity": "Actor ID CreatedAt Action",
+		"AuditPatch":  "Actor ID CreatedAt Action",
+		"EntryEntity": "ID CreatedAt",
+	}
+	for name, exp := range expected {
+		if got := strings.Join(fields[name], " "); got != exp {
+			t.Errorf("%s: wrong fields, expected %s but got %s", name, exp, got)
+		}
+	}
+
+	code, err := (&pqtgogen.Generator{Version: 9.5, Pkg: "example", Components: pqtgogen.ComponentAll}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !strings.Contains(string(code), "TableAuditColumnActor,\n\tTableAuditColumnID,\n\tTableAuditColumnCreatedAt,\n\tTableAuditColumnAction,\n}") {
+		t.Error("columns should be listed in declared order")
+	}
+}
+
+// structFields returns field names of structs generated for given schema, by struct name.
+func structFields(t *testing.T, s *pqt.Schema) map[string][]string {
+	t.Helper()
+
+	buf, err := (&pqtgogen.Generator{Version: 9.5, Pkg: "example", Components: pqtgogen.ComponentAll}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", buf, 0)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err.Error())
+	}
+	fields := make(map[string][]string)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				for _, name := range field.Names {
+					fields[ts.Name.Name] = append(fields[ts.Name.Name], name.Name)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+func TestGenerator_GenerateDatabase(t *testing.T) {
+	database := func() *pqt.Database {
+		authUserID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+		authUser := pqt.NewTable("user").AddColumn(authUserID)
+		user := pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey()))
+		invoice := pqt.NewTable("invoice").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(authUserID), pqt.WithNotNull()))
+
+		return pqt.NewDatabase().
+			AddSchema(pqt.NewSchema("auth").AddTable(authUser)).
+			AddSchema(pqt.NewSchema("billing").AddTable(invoice)).
+			AddSchema(pqt.NewSchema("").AddTable(user))
+	}
+
+	buf, err := (&pqtgogen.Generator{Version: 9.5, Pkg: "example", Components: pqtgogen.ComponentAll}).GenerateDatabase(database())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", buf, 0)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err.Error())
+	}
+	types := make(map[string]bool)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			types[spec.(*ast.TypeSpec).Name.Name] = true
+		}
+	}
+	for _, name := range []string{"AuthUserEntity", "UserEntity", "BillingInvoiceEntity", "AuthUserJoin", "BillingInvoiceRepositoryBase"} {
+		if !types[name] {
+			t.Errorf("missing type %s", name)
+		}
+	}
+	for _, frag := range []string{
+		`TableAuthUser\s+= "auth.\\"user\\""`,
+		`TableUser\s+= "\\"user\\""`,
+		`TableBillingInvoice\s+= "billing.invoice"`,
+		`JoinUser\s+\*AuthUserJoin`,
+	} {
+		if !regexp.MustCompile(frag).Match(buf) {
+			t.Errorf("missing fragment %s", frag)
+		}
+	}
+
+	collision := database()
+	collision.Schemas[2].AddTable(pqt.NewTable("auth_user").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())))
+	if _, err := (&pqtgogen.Generator{Pkg: "example"}).GenerateDatabase(collision); err == nil {
+		t.Error("expected collision error")
+	} else if !strings.Contains(err.Error(), "table auth.user and table auth_user are both generated as AuthUser") {
+		t.Errorf("wrong error: %s", err.Error())
+	}
+}
+
+func TestGenerator_compositePrimaryKey(t *testing.T) {
+	userID := pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithNotNull())
+	roleID := pqt.NewColumn("role_id", pqt.TypeIntegerBig(), pqt.WithNotNull())
+	userRole := pqt.NewTable("user_role").
+		AddColumn(userID).
+		AddColumn(roleID).
+		AddColumn(pqt.NewColumn("granted_at", pqt.TypeTimestampTZ()))
+	userRole.AddConstraint(pqt.PrimaryKey(userRole, userID, roleID))
+	s := pqt.NewSchema("example").AddTable(userRole)
+
+	methods := generatedMethods(t, s, pqtgogen.ComponentAll)
+	for _, m := range []string{
+		"UserRoleRepositoryBase.FindOneByPrimaryKey",
+		"UserRoleRepositoryBase.UpdateOneByPrimaryKey",
+		"UserRoleRepositoryBase.DeleteOneByPrimaryKey",
+		"UserRoleRepositoryBaseTx.FindOneByPrimaryKey",
+		"UserRoleRepositoryBaseTx.UpdateOneByPrimaryKey",
+		"UserRoleRepositoryBaseTx.DeleteOneByPrimaryKey",
+	} {
+		if !methods[m] {
+			t.Errorf("missing method %s", m)
+		}
+	}
+	for _, m := range []string{
+		"UserRoleRepositoryBase.FindOneByUserID",
+		"UserRoleRepositoryBase.FindOneByRoleID",
+	} {
+		if methods[m] {
+			t.Errorf("unexpected method %s", m)
+		}
+	}
+}
@@ -72,15 +72,30 @@ func (bt BuiltinType) Fingerprint() string {
 	return fmt.Sprintf("gobuiltin: %v", bt)
 }
 
+// GoType describes Go type that CustomType maps to in given mode.
+// It holds everything generator needs to know about the type, so custom type can be declared without access to its value.
+type GoType struct {
+	// Name is a type name as it appears in Go code, e.g. "time.Time" or "*model.Status".
+	Name string
+	// PkgPath is an import path of the package that defines the type.
+	// It is empty for predeclared and unnamed types (like pointers and slices).
+	PkgPath string
+	// Kind is a kind of the type.
+	Kind reflect.Kind
+	// Nullable is true if type is a struct that has boolean Valid field, like sql.NullString.
+	Nullable bool
+}
+
 // CustomType allows to create custom types from already existing types.
 type CustomType struct {
 	mandatory, optional, criteria                   interface{}
 	mandatoryTypeOf, optionalTypeOf, criteriaTypeOf reflect.Type
+	mandatoryGoType, optionalGoType, criteriaGoType GoType
 }
 
 // String implements Stringer interface.
 func (ct CustomType) String() string {
-	return fmt.Sprintf("%s/%s/%s", ct.mandatoryTypeOf.String(), ct.optionalTypeOf.String(), ct.criteriaTypeOf.String())
+	return fmt.Sprintf("%s/%s/%s", ct.mandatoryGoType.Name, ct.optionalGoType.Name, ct.criteriaGoType.Name)
 }
 
 // Fingerprint implements Type interface.
@@ -108,9 +123,47 @@ func TypeCustom(m, o, c interface{}) CustomType {
 		mandatoryTypeOf: mandatoryTypeOf,
 		optionalTypeOf:  optionalTypeOf,
 		criteriaTypeOf:  criteriaTypeOf,
+		mandatoryGoType: goTypeOf(mandatoryTypeOf),
+		optionalGoType:  goTypeOf(optionalTypeOf),
+		criteriaGoType:  goTypeOf(criteriaTypeOf),
 	}
 }
 
+// TypeCustomDeclared allocates new CustomType using given type descriptions for each context: mandatory, optional and criteria.
+// Unlike TypeCustom it does not require access to the values, which makes it usable for schemas that are not defined in Go.
+// Nil means that type is not available in given context.
+func TypeCustomDeclared(m, o, c *GoType) CustomType {
+	var ct CustomType
+	if m != nil {
+		ct.mandatoryGoType = *m
+	}
+	if o != nil {
+		ct.optionalGoType = *o
+	}
+	if c != nil {
+		ct.criteriaGoType = *c
+	}
+	return ct
+}
+
+func goTypeOf(t reflect.Type) GoType {
+	if t == nil {
+		return GoType{}
+	}
+
+	gt := GoType{
+		Name:    t.String(),
+		PkgPath: t.PkgPath(),
+		Kind:    t.Kind(),
+	}
+	if t.Kind() == reflect.Struct {
+		if field, ok := t.FieldByName("Valid"); ok && field.Type.Kind() == reflect.Bool {
+			gt.Nullable = true
+		}
+	}
+	return gt
+}
+
 // ValueOf returns type for given mode.
 func (ct CustomType) ValueOf(m int32) interface{} {
 	switch m {
@@ -126,6 +179,7 @@ func (ct CustomType) ValueOf(m int32) interface{} {
 }
 
 // TypeOf returns Go type of underlying pqt Type for given mode.
+// It returns nil if type was declared using TypeCustomDeclared.
 func (ct CustomType) TypeOf(m int32) reflect.Type {
 	switch m {
 	case ModeMandatory:
@@ -138,3 +192,20 @@ func (ct CustomType) TypeOf(m int32) reflect.Type {
 		return nil
 	}
 }
+
+// GoTypeOf returns description of the Go type for given mode or nil if there is none.
+func (ct CustomType) GoTypeOf(m int32) *GoType {
+	var gt GoType
+	switch m {
+	case ModeMandatory:
+		gt = ct.mandatoryGoType
+	case ModeOptional:
+		gt = ct.optionalGoType
+	case ModeCriteria:
+		gt = ct.criteriaGoType
+	}
+	if gt.Name == "" {
+		return nil
+	}
+	return &gt
+}
@@ -0,0 +1,108 @@
+package pqtsql
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+// generateComments writes COMMENT ON statements for the table, its columns and constraints.
+func generateComments(buf *bytes.Buffer, t *pqt.Table) {
+	if t.Comment != "" {
+		tableCommentQuery(buf, t)
+	}
+	for _, c := range t.Columns {
+		if !c.IsDynamic && c.Comment != "" {
+			columnCommentQuery(buf, c)
+		}
+	}
+	for _, c := range t.Constraints {
+		if c.Comment != "" {
+			constraintCommentQuery(buf, c)
+		}
+	}
+}
+
+// alterCommentsQuery writes COMMENT ON statements for comments that changed.
+// Constraints that were recreated lose their comments, so they are commented again.
+// It returns true if anything was written.
+func alterCommentsQuery(buf *bytes.Buffer, old, cur *pqt.Table, recreated map[string]bool) bool {
+	var dirty bool
+	if old.Comment != cur.Comment {
+		tableCommentQuery(buf, cur)
+		dirty = true
+	}
+
+	oldColumns := make(map[string]*pqt.Column, len(old.Columns))
+	for _, c := range old.Columns {
+		oldColumns[c.Name] = c
+	}
+	for _, c := range cur.Columns {
+		if c.IsDynamic {
+			continue
+		}
+		if oc, ok := oldColumns[c.Name]; (ok && oc.Comment != c.Comment) || (!ok && c.Comment != "") {
+			columnCommentQuery(buf, c)
+			dirty = true
+		}
+	}
+
+	oldConstraints := make(map[string]*pqt.Constraint, len(old.Constraints))
+	for _, c := range old.Constraints {
+		oldConstraints[c.Name()] = c
+	}
+	for _, c := range cur.Constraints {
+		oc, ok := oldConstraints[c.Name()]
+		switch {
+		case recreated[c.Name()] || !ok:
+			if c.Comment == "" {
+				continue
+			}
+		case oc.Comment == c.Comment:
+			continue
+		}
+		constraintCommentQuery(buf, c)
+		dirty = true
+	}
+
+	return dirty
+}
+
+func tableCommentQuery(buf *bytes.Buffer, t *pqt.Table) {
+	fmt.Fprintf(buf, "COMMENT ON TABLE %s IS %s;\n", t.FullName(), commentValue(t.Comment))
+}
+
+func columnCommentQuery(buf *bytes.Buffer, c *pqt.Column) {
+	fmt.Fprintf(buf, "COMMENT ON COLUMN %s.%s IS %s;\n", c.Table.FullName(), c.QuotedName(), commentValue(c.Comment))
+}
+
+func constraintCommentQuery(buf *bytes.Buffer, c *pqt.Constraint) {
+	switch c.Type {
+	case pqt.ConstraintTypeIndex, pqt.ConstraintTypeUniqueIndex:
+		fmt.Fprintf(buf, "COMMENT ON INDEX %s", indexName(c))
+	default:
+		fmt.Fprintf(buf, "COMMENT ON CONSTRAINT %s ON %s", c.QuotedName(), c.PrimaryTable.FullName())
+	}
+	fmt.Fprintf(buf, " IS %s;\n", commentValue(c.Comment))
+}
+
+// indexName returns name of the index qualified by the schema of its table, indexes always live there.
+func indexName(c *pqt.Constraint) string {
+	if s := c.PrimaryTable.Schema; s != nil && s.Name != "" {
+		return s.QuotedName() + "." + c.QuotedName()
+	}
+	return c.QuotedName()
+}
+
+func functionCommentQuery(buf *bytes.Buffer, s *pqt.Schema, f *pqt.Function) {
+	fmt.Fprintf(buf, "COMMENT ON FUNCTION %s IS %s;\n", functionSignature(s, f), commentValue(f.Comment))
+}
+
+// commentValue returns quoted comment or NULL that removes the comment if it is empty.
+func commentValue(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return literal(s)
+}
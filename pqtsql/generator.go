@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/piotrkowalczuk/pqt"
 )
@@ -36,40 +37,156 @@ func (g *Generator) GenerateTo(s *pqt.Schema, w io.Writer) error {
 	return err
 }
 
+// GenerateDatabase generates code based on given database.
+// Extensions go first, then objects of all schemas are created kind by kind,
+// so types and functions of any schema are available to tables, and tables can reference tables declared earlier.
+func (g *Generator) GenerateDatabase(d *pqt.Database) ([]byte, error) {
+	code, err := g.generateDatabase(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return code.Bytes(), nil
+}
+
+// GenerateDatabaseTo works like GenerateDatabase, but writes directly into io.Writer.
+func (g *Generator) GenerateDatabaseTo(d *pqt.Database, w io.Writer) error {
+	code, err := g.generateDatabase(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = code.WriteTo(w)
+	return err
+}
+
 func (g *Generator) generate(s *pqt.Schema) (*bytes.Buffer, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	code := bytes.NewBufferString("-- sql schema beginning\n")
+	code.WriteString("-- do not modify, generated by pqt\n\n")
+	if err := g.generateSchemas(code, []*pqt.Schema{s}); err != nil {
+		return nil, err
+	}
+	code.WriteString("-- sql schema end\n")
+	return code, nil
+}
+
+func (g *Generator) generateDatabase(d *pqt.Database) (*bytes.Buffer, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
 	code := bytes.NewBufferString("-- sql schema beginning\n")
 	code.WriteString("-- do not modify, generated by pqt\n\n")
-	if s.Name != "" {
-		fmt.Fprint(code, "CREATE SCHEMA ")
-		if s.IfNotExists {
-			fmt.Fprint(code, "IF NOT EXISTS ")
+	for _, e := range d.Extensions {
+		generateCreateExtension(code, nil, e)
+	}
+	if len(d.Extensions) > 0 {
+		fmt.Fprintln(code, "")
+	}
+	if err := g.generateSchemas(code, d.Schemas); err != nil {
+		return nil, err
+	}
+	code.WriteString("-- sql schema end\n")
+	return code, nil
+}
+
+func (g *Generator) generateSchemas(code *bytes.Buffer, schemas []*pqt.Schema) error {
+	for _, s := range schemas {
+		if s.Name != "" {
+			fmt.Fprint(code, "CREATE SCHEMA ")
+			if s.IfNotExists {
+				fmt.Fprint(code, "IF NOT EXISTS ")
+			}
+			fmt.Fprintf(code, "%s; \n\n", s.QuotedName())
 		}
-		fmt.Fprintf(code, "%s; \n\n", s.Name)
 	}
-	for _, f := range s.Functions {
-		if err := g.generateCreateFunction(code, f); err != nil {
-			return nil, err
+	// Extensions go right after schemas, so they can be installed into them, and before types that can depend on them.
+	var extensions bool
+	for _, s := range schemas {
+		for _, e := range s.Extensions {
+			generateCreateExtension(code, s, e)
+			extensions = true
 		}
 	}
-	for _, t := range s.Tables {
-		if err := g.generateCreateTable(code, t); err != nil {
-			return nil, err
+	if extensions {
+		fmt.Fprintln(code, "")
+	}
+	for _, s := range schemas {
+		for _, t := range s.Types {
+			generateCreateType(code, s, t)
 		}
-		for _, cnstr := range t.Constraints {
-			switch cnstr.Type {
-			case pqt.ConstraintTypeIndex:
-				indexConstraintQuery(code, cnstr, g.Version)
-			case pqt.ConstraintTypeUniqueIndex:
-				uniqueIndexConstraintQuery(code, cnstr, g.Version)
+	}
+	for _, s := range schemas {
+		for _, f := range s.Functions {
+			// Built-in functions already exist, like in migrations they are only referenced.
+			if f.BuiltIn {
+				continue
+			}
+			if err := g.generateCreateFunction(code, s, f); err != nil {
+				return err
 			}
 		}
-		fmt.Fprintln(code, "")
 	}
-	code.WriteString("-- sql schema end\n")
-	return code, nil
+	for _, s := range schemas {
+		for _, t := range s.Tables {
+			if err := g.generateCreateTable(code, t); err != nil {
+				return err
+			}
+			for _, p := range t.Partitions {
+				generateCreatePartition(code, p)
+			}
+			g.generateIndexes(code, t)
+			generateComments(code, t)
+			fmt.Fprintln(code, "")
+		}
+	}
+	for _, s := range schemas {
+		for _, v := range s.Views {
+			generateCreateView(code, v)
+		}
+	}
+	// Triggers go last, once functions they execute and relations they are attached to exist.
+	for _, s := range schemas {
+		for _, t := range s.Tables {
+			g.generateCreateTriggers(code, t.Triggers)
+		}
+		for _, v := range s.Views {
+			g.generateCreateTriggers(code, v.Triggers)
+		}
+	}
+	// Row level security and privileges go at the very end, policies can refer to any object.
+	for _, s := range schemas {
+		dirty := grantsQuery(code, schemaObject(s), nil, s.Grants)
+		for _, f := range s.Functions {
+			if grantsQuery(code, functionObject(s, f), nil, f.Grants) {
+				dirty = true
+			}
+		}
+		if dirty {
+			fmt.Fprintln(code, "")
+		}
+		for _, t := range s.Tables {
+			if tableSecurityQuery(code, nil, t) {
+				fmt.Fprintln(code, "")
+			}
+		}
+	}
+	return nil
 }
 
-func (g *Generator) generateCreateFunction(buf *bytes.Buffer, f *pqt.Function) error {
+func generateCreateExtension(buf *bytes.Buffer, s *pqt.Schema, e *pqt.Extension) {
+	fmt.Fprintf(buf, "CREATE EXTENSION IF NOT EXISTS %s", s.Quote(e.Name))
+	if e.Schema != "" {
+		fmt.Fprintf(buf, " SCHEMA %s", s.Quote(e.Schema))
+	}
+	buf.WriteString(";\n")
+}
+
+func (g *Generator) generateCreateFunction(buf *bytes.Buffer, s *pqt.Schema, f *pqt.Function) error {
 	if f == nil {
 		return nil
 	}
@@ -78,21 +195,56 @@ func (g *Generator) generateCreateFunction(buf *bytes.Buffer, f *pqt.Function) e
 	}
 
 	buf.WriteString("CREATE OR REPLACE FUNCTION ")
-	buf.WriteString(f.Name)
+	buf.WriteString(functionName(s, f))
 	buf.WriteString("(")
 	for i, arg := range f.Args {
 		if i != 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(arg.Name)
+		buf.WriteString(s.Quote(arg.Name))
 		buf.WriteString(" ")
-		buf.WriteString(arg.Type.String())
+		buf.WriteString(typeName(s, arg.Type))
 	}
 	buf.WriteString(") RETURNS ")
-	buf.WriteString(f.Type.String())
-	buf.WriteString("\n	AS '")
-	buf.WriteString(f.Body)
-	buf.WriteString("'\n	LANGUAGE SQL")
+	switch {
+	case len(f.ReturnsTable) > 0:
+		buf.WriteString("TABLE (")
+		for i, arg := range f.ReturnsTable {
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(s.Quote(arg.Name))
+			buf.WriteString(" ")
+			buf.WriteString(typeName(s, arg.Type))
+		}
+		buf.WriteString(")")
+	case f.SetOf:
+		buf.WriteString("SETOF ")
+		buf.WriteString(typeName(s, f.Type))
+	default:
+		buf.WriteString(typeName(s, f.Type))
+	}
+	buf.WriteString("\n	AS ")
+	if isSQL(f) {
+		buf.WriteString("'")
+		buf.WriteString(f.Body)
+		buf.WriteString("'")
+	} else {
+		// Procedural code is full of quotes, so it is dollar quoted instead.
+		tag := "$$"
+		if strings.Contains(f.Body, tag) {
+			tag = "$function$"
+		}
+		buf.WriteString(tag)
+		buf.WriteString(f.Body)
+		buf.WriteString(tag)
+	}
+	buf.WriteString("\n	LANGUAGE ")
+	if isSQL(f) {
+		buf.WriteString(pqt.FunctionLanguageSQL)
+	} else {
+		buf.WriteString(f.Language)
+	}
 	switch f.Behaviour {
 	case pqt.FunctionBehaviourVolatile:
 		buf.WriteString("\n	VOLATILE")
@@ -101,11 +253,73 @@ func (g *Generator) generateCreateFunction(buf *bytes.Buffer, f *pqt.Function) e
 	case pqt.FunctionBehaviourStable:
 		buf.WriteString("\n	STABLE")
 	}
-	buf.WriteString(";\n\n")
+	if f.SecurityDefiner {
+		buf.WriteString("\n	SECURITY DEFINER")
+	}
+	buf.WriteString(";\n")
+	if f.Comment != "" {
+		functionCommentQuery(buf, s, f)
+	}
+	buf.WriteString("\n")
 
 	return nil
 }
 
+// functionDefinition returns rendered CREATE FUNCTION statement.
+func (g *Generator) functionDefinition(s *pqt.Schema, f *pqt.Function) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := g.generateCreateFunction(buf, s, f); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func dropFunctionQuery(buf *bytes.Buffer, s *pqt.Schema, f *pqt.Function) {
+	fmt.Fprintf(buf, "DROP FUNCTION %s;\n", functionSignature(s, f))
+}
+
+// functionSignature returns function name followed by types of its arguments, it identifies the function.
+func functionSignature(s *pqt.Schema, f *pqt.Function) string {
+	args := make([]string, 0, len(f.Args))
+	for _, arg := range f.Args {
+		args = append(args, typeName(s, arg.Type))
+	}
+	return fmt.Sprintf("%s(%s)", functionName(s, f), strings.Join(args, ", "))
+}
+
+// functionName returns name of the function qualified by the schema that declares it.
+// Built-in functions are resolved through the search path, so their names are left as they are.
+func functionName(s *pqt.Schema, f *pqt.Function) string {
+	if f.BuiltIn {
+		return f.Name
+	}
+	s = functionSchema(s, f)
+	if s == nil || s.Name == "" {
+		return s.Quote(f.Name)
+	}
+	return s.QuotedName() + "." + s.Quote(f.Name)
+}
+
+// functionSchema returns schema that declares given function.
+// If the schema is a part of a database, the function can be declared by any other schema of the database.
+func functionSchema(s *pqt.Schema, f *pqt.Function) *pqt.Schema {
+	if s == nil || s.Database == nil {
+		return s
+	}
+	for _, ds := range s.Database.Schemas {
+		for _, df := range ds.Functions {
+			if df == f {
+				return ds
+			}
+		}
+	}
+	return s
+}
+
+func isSQL(f *pqt.Function) bool {
+	return f.Language == "" || strings.EqualFold(f.Language, pqt.FunctionLanguageSQL)
+}
+
 func (g *Generator) generateCreateTable(buf *bytes.Buffer, t *pqt.Table) error {
 	if t == nil {
 		return nil
@@ -114,7 +328,7 @@ func (g *Generator) generateCreateTable(buf *bytes.Buffer, t *pqt.Table) error {
 	if t.Name == "" {
 		return errors.New("missing table name")
 	}
-	if len(t.Columns) == 0 {
+	if len(t.Columns) == 0 && len(t.Inherits) == 0 {
 		return fmt.Errorf("table %s has no columns", t.Name)
 	}
 
@@ -126,25 +340,10 @@ func (g *Generator) generateCreateTable(buf *bytes.Buffer, t *pqt.Table) error {
 	if t.IfNotExists {
 		buf.WriteString("IF NOT EXISTS ")
 	}
-	if t.Schema != nil {
-		buf.WriteString(t.Schema.Name)
-		buf.WriteRune('.')
-		buf.WriteString(t.Name)
-	} else {
-		buf.WriteString(t.Name)
-	}
+	buf.WriteString(t.FullName())
 	buf.WriteString(" (\n")
 
-	constraints := t.Constraints
-	for _, r := range t.OwnedRelationships {
-		// If ...
-		if len(r.OwnerColumns) == 1 {
-			continue
-		}
-		if r.OwnerForeignKey != nil {
-			constraints = append(constraints, r.OwnerForeignKey)
-		}
-	}
+	constraints := tableConstraints(t)
 	nbOfConstraints := constraints.CountOf(
 		pqt.ConstraintTypePrimaryKey,
 		pqt.ConstraintTypeCheck,
@@ -157,20 +356,7 @@ func (g *Generator) generateCreateTable(buf *bytes.Buffer, t *pqt.Table) error {
 			continue
 		}
 		buf.WriteRune('	')
-		buf.WriteString(c.Name)
-		buf.WriteRune(' ')
-		buf.WriteString(c.Type.String())
-		if c.Collate != "" {
-			buf.WriteRune(' ')
-			buf.WriteString(c.Collate)
-		}
-		if d, ok := c.DefaultOn(pqt.EventInsert); ok {
-			buf.WriteString(" DEFAULT ")
-			buf.WriteString(d)
-		}
-		if c.NotNull {
-			buf.WriteString(" NOT NULL")
-		}
+		generateColumn(buf, c)
 
 		if i < len(t.Columns)-1 || nbOfConstraints > 0 {
 			buf.WriteRune(',')
@@ -199,11 +385,69 @@ func (g *Generator) generateCreateTable(buf *bytes.Buffer, t *pqt.Table) error {
 		i++
 	}
 
-	buf.WriteString(");\n")
+	buf.WriteString(")")
+	if len(t.Inherits) > 0 {
+		fmt.Fprintf(buf, " INHERITS (%s)", inheritsList(t))
+	}
+	if t.PartitionBy != "" {
+		fmt.Fprintf(buf, " PARTITION BY %s (%s)", t.PartitionBy, pqt.JoinQuotedColumns(t.PartitionKey, ", "))
+	}
+	buf.WriteString(";\n")
 
 	return nil
 }
 
+func (g *Generator) generateIndexes(buf *bytes.Buffer, t *pqt.Table) {
+	for _, cnstr := range t.Constraints {
+		switch cnstr.Type {
+		case pqt.ConstraintTypeIndex:
+			indexConstraintQuery(buf, cnstr, g.Version)
+		case pqt.ConstraintTypeUniqueIndex:
+			uniqueIndexConstraintQuery(buf, cnstr, g.Version)
+		}
+	}
+}
+
+// generateColumn writes column definition as it appears in CREATE TABLE or ALTER TABLE ... ADD COLUMN statement.
+func generateColumn(buf *bytes.Buffer, c *pqt.Column) {
+	buf.WriteString(c.QuotedName())
+	buf.WriteRune(' ')
+	buf.WriteString(columnType(c))
+	if c.Collate != "" {
+		buf.WriteString(" COLLATE ")
+		buf.WriteString(c.Collate)
+	}
+	if d, ok := c.DefaultOn(pqt.EventInsert); ok {
+		buf.WriteString(" DEFAULT ")
+		buf.WriteString(d)
+	}
+	if c.Identity != "" {
+		fmt.Fprintf(buf, " GENERATED %s AS IDENTITY", c.Identity)
+	}
+	if c.Generated != "" {
+		fmt.Fprintf(buf, " GENERATED ALWAYS AS (%s) STORED", c.Generated)
+	}
+	if c.NotNull {
+		buf.WriteString(" NOT NULL")
+	}
+}
+
+// tableConstraints returns constraints of the table including multi column foreign keys of owned relationships.
+func tableConstraints(t *pqt.Table) pqt.Constraints {
+	constraints := make(pqt.Constraints, 0, len(t.Constraints))
+	constraints = append(constraints, t.Constraints...)
+	for _, r := range t.OwnedRelationships {
+		// Single column relationships are already represented by a constraint.
+		if len(r.OwnerColumns) == 1 {
+			continue
+		}
+		if r.OwnerForeignKey != nil {
+			constraints = append(constraints, r.OwnerForeignKey)
+		}
+	}
+	return constraints
+}
+
 func (g *Generator) generateConstraint(buf *bytes.Buffer, c *pqt.Constraint) error {
 	switch c.Type {
 	case pqt.ConstraintTypeUnique:
@@ -214,6 +458,8 @@ func (g *Generator) generateConstraint(buf *bytes.Buffer, c *pqt.Constraint) err
 		return foreignKeyConstraintQuery(buf, c)
 	case pqt.ConstraintTypeCheck:
 		checkConstraintQuery(buf, c)
+	case pqt.ConstraintTypeExclusion:
+		return exclusionConstraintQuery(buf, c)
 	case pqt.ConstraintTypeIndex:
 	case pqt.ConstraintTypeUniqueIndex:
 	default:
@@ -223,12 +469,24 @@ func (g *Generator) generateConstraint(buf *bytes.Buffer, c *pqt.Constraint) err
 	return nil
 }
 
+// deferrableClause writes DEFERRABLE clause of unique, primary key, foreign key and exclusion constraints.
+func deferrableClause(buf *bytes.Buffer, c *pqt.Constraint) {
+	switch {
+	case c.DeferrableInitiallyDeferred:
+		buf.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	case c.DeferrableInitiallyImmediate:
+		buf.WriteString(" DEFERRABLE INITIALLY IMMEDIATE")
+	}
+}
+
 func uniqueConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) {
-	fmt.Fprintf(buf, `CONSTRAINT "%s" UNIQUE (%s)`, c.Name(), pqt.JoinColumns(c.PrimaryColumns, ", "))
+	fmt.Fprintf(buf, `CONSTRAINT %s UNIQUE (%s)`, c.QuotedName(), pqt.JoinQuotedColumns(c.PrimaryColumns, ", "))
+	deferrableClause(buf, c)
 }
 
 func primaryKeyConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) {
-	fmt.Fprintf(buf, `CONSTRAINT "%s" PRIMARY KEY (%s)`, c.Name(), pqt.JoinColumns(c.PrimaryColumns, ", "))
+	fmt.Fprintf(buf, `CONSTRAINT %s PRIMARY KEY (%s)`, c.QuotedName(), pqt.JoinQuotedColumns(c.PrimaryColumns, ", "))
+	deferrableClause(buf, c)
 }
 
 func foreignKeyConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) error {
@@ -241,13 +499,20 @@ func foreignKeyConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) error {
 		return errors.New("foreiqn key constraint missing reference table")
 	}
 
-	fmt.Fprintf(buf, `CONSTRAINT "%s" FOREIGN KEY (%s) REFERENCES %s (%s)`,
-		c.Name(),
-		pqt.JoinColumns(c.PrimaryColumns, ", "),
+	fmt.Fprintf(buf, `CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)`,
+		c.QuotedName(),
+		pqt.JoinQuotedColumns(c.PrimaryColumns, ", "),
 		c.Table.FullName(),
-		pqt.JoinColumns(c.Columns, ", "),
+		pqt.JoinQuotedColumns(c.Columns, ", "),
 	)
 
+	switch c.Match {
+	case pqt.MatchFull:
+		buf.WriteString(" MATCH FULL")
+	case pqt.MatchSimple:
+		buf.WriteString(" MATCH SIMPLE")
+	}
+
 	switch c.OnDelete {
 	case pqt.Cascade:
 		buf.WriteString(" ON DELETE CASCADE")
@@ -269,29 +534,81 @@ func foreignKeyConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) error {
 	case pqt.SetDefault:
 		buf.WriteString(" ON UPDATE SET DEFAULT")
 	}
+	deferrableClause(buf, c)
 
 	return nil
 }
 
 func checkConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) {
-	fmt.Fprintf(buf, `CONSTRAINT "%s" CHECK (%s)`, c.Name(), c.Check)
+	fmt.Fprintf(buf, `CONSTRAINT %s CHECK (%s)`, c.QuotedName(), c.Check)
+	if c.NoInherit {
+		buf.WriteString(" NO INHERIT")
+	}
 }
 
-func indexConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint, ver float64) {
-	// TODO: change code so IF NOT EXISTS is optional
-	if ver >= 9.5 {
-		fmt.Fprintf(buf, `CREATE INDEX IF NOT EXISTS "%s" ON %s (%s);`, c.Name(), c.PrimaryTable.FullName(), c.PrimaryColumns.String())
-	} else {
-		fmt.Fprintf(buf, `CREATE INDEX "%s" ON %s (%s);`, c.Name(), c.PrimaryTable.FullName(), c.PrimaryColumns.String())
+// inheritsList returns comma separated list of tables that given table inherits from.
+func inheritsList(t *pqt.Table) string {
+	names := make([]string, 0, len(t.Inherits))
+	for _, p := range t.Inherits {
+		names = append(names, p.FullName())
+	}
+	return strings.Join(names, ", ")
+}
+
+func exclusionConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) error {
+	if len(c.Exclude) == 0 {
+		return errors.New("exclusion constraint require at least one element")
+	}
+
+	fmt.Fprintf(buf, `CONSTRAINT %s EXCLUDE`, c.QuotedName())
+	if c.Method != "" {
+		fmt.Fprintf(buf, " USING %s", c.Method)
+	}
+	buf.WriteString(" (")
+	for i, e := range c.Exclude {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if e.Column != nil {
+			buf.WriteString(e.Column.QuotedName())
+		} else {
+			fmt.Fprintf(buf, "(%s)", e.Expression)
+		}
+		fmt.Fprintf(buf, " WITH %s", e.Operator)
+	}
+	buf.WriteString(")")
+	if c.Where != "" {
+		fmt.Fprintf(buf, " WHERE (%s)", c.Where)
 	}
-	fmt.Fprintln(buf, "")
+	deferrableClause(buf, c)
+
+	return nil
+}
+
+func indexConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint, ver float64) {
+	createIndexQuery(buf, c, "CREATE INDEX", ver)
 }
 
 func uniqueIndexConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint, ver float64) {
+	createIndexQuery(buf, c, "CREATE UNIQUE INDEX", ver)
+}
+
+func createIndexQuery(buf *bytes.Buffer, c *pqt.Constraint, create string, ver float64) {
+	buf.WriteString(create)
+	if c.Concurrently {
+		buf.WriteString(" CONCURRENTLY")
+	}
+	// TODO: change code so IF NOT EXISTS is optional
 	if ver >= 9.5 {
-		fmt.Fprintf(buf, `CREATE UNIQUE INDEX IF NOT EXISTS "%s" ON %s (%s)`, c.Name(), c.PrimaryTable.FullName(), c.PrimaryColumns.String())
-	} else {
-		fmt.Fprintf(buf, `CREATE UNIQUE INDEX "%s" ON %s (%s)`, c.Name(), c.PrimaryTable.FullName(), c.PrimaryColumns.String())
+		buf.WriteString(" IF NOT EXISTS")
+	}
+	fmt.Fprintf(buf, " %s ON %s", c.QuotedName(), c.PrimaryTable.FullName())
+	if c.Method != "" {
+		fmt.Fprintf(buf, " USING %s", c.Method)
+	}
+	fmt.Fprintf(buf, " (%s)", indexElements(c))
+	if len(c.Include) > 0 {
+		fmt.Fprintf(buf, " INCLUDE (%s)", pqt.JoinQuotedColumns(c.Include, ","))
 	}
 	if c.Where != "" {
 		fmt.Fprintf(buf, " WHERE %s", c.Where)
@@ -299,3 +616,33 @@ func uniqueIndexConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint, ver float6
 
 	fmt.Fprint(buf, ";\n")
 }
+
+func indexElements(c *pqt.Constraint) string {
+	if len(c.Elements) == 0 {
+		return pqt.JoinQuotedColumns(c.PrimaryColumns, ",")
+	}
+
+	elements := make([]string, 0, len(c.Elements))
+	for _, e := range c.Elements {
+		el := e.Expression
+		if e.Column != nil {
+			el = e.Column.QuotedName()
+		} else {
+			el = "(" + el + ")"
+		}
+		if e.OpClass != "" {
+			el += " " + e.OpClass
+		}
+		if e.Descending {
+			el += " DESC"
+		}
+		switch e.Nulls {
+		case pqt.NullsFirst:
+			el += " NULLS FIRST"
+		case pqt.NullsLast:
+			el += " NULLS LAST"
+		}
+		elements = append(elements, el)
+	}
+	return strings.Join(elements, ", ")
+}
@@ -8,8 +8,12 @@ import (
 )
 
 func TestGenerator_Generate(t *testing.T) {
+	relatedID := &pqt.Column{Name: "id", Type: pqt.TypeSerial()}
+	related := pqt.NewTable("related_table").AddColumn(relatedID)
+
 	success := []struct {
 		expected string
+		related  []*pqt.Table
 		given    *pqt.Table
 		version  float64
 	}{
@@ -17,12 +21,12 @@ func TestGenerator_Generate(t *testing.T) {
 			expected: `-- sql schema beginning
 -- do not modify, generated by pqt
 
-CREATE TEMPORARY TABLE schema.user (
+CREATE TEMPORARY TABLE schema."user" (
 	created_at TIMESTAMPTZ,
 	password TEXT,
 	username TEXT NOT NULL
 );
-CREATE INDEX IF NOT EXISTS "schema.user_username_idx" ON schema.user (username);
+CREATE INDEX IF NOT EXISTS "schema.user_username_idx" ON schema."user" (username);
 
 -- sql schema end
 `,
@@ -39,6 +43,10 @@ CREATE INDEX IF NOT EXISTS "schema.user_username_idx" ON schema.user (username);
 			expected: `-- sql schema beginning
 -- do not modify, generated by pqt
 
+CREATE TABLE related_table (
+	id SERIAL
+);
+
 CREATE TABLE IF NOT EXISTS table_name (
 	created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
 	created_by INTEGER NOT NULL,
@@ -66,20 +74,17 @@ CREATE UNIQUE INDEX "public.table_name_one_two_V1tbrhqs_uidx" ON table_name (one
 
 -- sql schema end
 `,
+			related: []*pqt.Table{related},
 			given: func() *pqt.Table {
-				id := pqt.Column{Name: "id", Type: pqt.TypeSerial()}
 				startAt := &pqt.Column{Name: "start_at", Type: pqt.TypeTimestampTZ(), NotNull: true}
 				endAt := &pqt.Column{Name: "end_at", Type: pqt.TypeTimestampTZ(), NotNull: true}
 
 				one := pqt.NewColumn("one", pqt.TypeIntegerBig())
 				two := pqt.NewColumn("two", pqt.TypeIntegerBig())
 
-				_ = pqt.NewTable("related_table").
-					AddColumn(&id)
-
 				return pqt.NewTable("table_name", pqt.WithTableIfNotExists()).
 					AddColumn(&pqt.Column{Name: "id", Type: pqt.TypeSerial(), PrimaryKey: true}).
-					AddColumn(&pqt.Column{Name: "rel_id", Type: pqt.TypeInteger(), Reference: &id}).
+					AddColumn(&pqt.Column{Name: "rel_id", Type: pqt.TypeInteger(), Reference: relatedID}).
 					AddColumn(&pqt.Column{Name: "name", Type: pqt.TypeText(), Unique: true}).
 					AddColumn(&pqt.Column{Name: "enabled", Type: pqt.TypeBool()}).
 					AddColumn(&pqt.Column{Name: "price", Type: pqt.TypeDecimal(10, 1)}).
@@ -104,7 +109,7 @@ CREATE UNIQUE INDEX "public.table_name_one_two_V1tbrhqs_uidx" ON table_name (one
 			Version: data.version,
 		}
 		q, err := g.Generate(&pqt.Schema{
-			Tables: []*pqt.Table{data.given},
+			Tables: append(data.related, data.given),
 		})
 
 		if err != nil {
@@ -117,3 +122,977 @@ CREATE UNIQUE INDEX "public.table_name_one_two_V1tbrhqs_uidx" ON table_name (one
 		}
 	}
 }
+
+func TestGenerator_Generate_enumeratedType(t *testing.T) {
+	mood := pqt.TypeEnumerated("mood", "happy", "sad", "it's")
+	s := pqt.NewSchema("example").
+		AddType(mood).
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("mood", mood, pqt.WithNotNull())))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TYPE example.mood AS ENUM ('happy', 'sad', 'it''s');
+
+CREATE TABLE example."user" (
+	id BIGSERIAL,
+	mood example.mood NOT NULL,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_compositeType(t *testing.T) {
+	mood := pqt.TypeEnumerated("mood", "happy", "sad")
+	address := pqt.TypeComposite("address",
+		&pqt.Attribute{Name: "street", Type: pqt.TypeText(), Collate: "C"},
+		&pqt.Attribute{Name: "number", Type: pqt.TypeInteger()},
+		&pqt.Attribute{Name: "mood", Type: mood},
+	)
+	s := pqt.NewSchema("example").
+		AddType(mood).
+		AddType(address).
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("address", address)))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TYPE example.mood AS ENUM ('happy', 'sad');
+
+CREATE TYPE example.address AS (
+	street TEXT COLLATE C,
+	number INTEGER,
+	mood example.mood
+);
+
+CREATE TABLE example."user" (
+	address example.address,
+	id BIGSERIAL,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_domainType(t *testing.T) {
+	email := pqt.TypeDomain("email", pqt.TypeCIText(), pqt.WithDomainCheck("VALUE ~ '^[^@]+@[^@]+$'"))
+	amount := pqt.TypeDomain("amount", pqt.TypeNumeric(10, 2),
+		pqt.WithDomainDefault("0"),
+		pqt.WithDomainNotNull(),
+		pqt.WithDomainCheck("VALUE >= 0"),
+		pqt.WithDomainCheck("VALUE < 1000000"),
+	)
+	s := pqt.NewSchema("example", pqt.WithSchemaExtension("citext"), pqt.WithSchemaExtension("uuid-ossp", pqt.WithExtensionSchema("example"))).
+		AddType(email).
+		AddType(amount).
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("balance", amount)).
+			AddColumn(pqt.NewColumn("email", email, pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("id", pqt.TypeUUID(), pqt.WithPrimaryKey(), pqt.WithDefault("example.uuid_generate_v4()"))))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE EXTENSION IF NOT EXISTS citext;
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp" SCHEMA example;
+
+CREATE DOMAIN example.email AS CITEXT CHECK (VALUE ~ '^[^@]+@[^@]+$');
+
+CREATE DOMAIN example.amount AS NUMERIC(10,2) DEFAULT 0 NOT NULL CHECK (VALUE >= 0) CHECK (VALUE < 1000000);
+
+CREATE TABLE example."user" (
+	balance example.amount,
+	email example.email NOT NULL,
+	id UUID DEFAULT example.uuid_generate_v4(),
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_arrayType(t *testing.T) {
+	mood := pqt.TypeEnumerated("mood", "happy", "sad")
+	s := pqt.NewSchema("example").
+		AddType(mood).
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("logins", pqt.TypeArray(pqt.TypeTimestampTZ()))).
+			AddColumn(pqt.NewColumn("matrix", pqt.TypeArray(pqt.TypeNumeric(10, 2), 3, 3))).
+			AddColumn(pqt.NewColumn("moods", pqt.TypeArray(mood), pqt.WithNotNull())))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TYPE example.mood AS ENUM ('happy', 'sad');
+
+CREATE TABLE example."user" (
+	id BIGSERIAL,
+	logins TIMESTAMPTZ[],
+	matrix NUMERIC(10,2)[3][3],
+	moods example.mood[] NOT NULL,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_view(t *testing.T) {
+	s := pqt.NewSchema("example").
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("active", pqt.TypeBool(), pqt.WithNotNull()))).
+		AddView(pqt.NewView("active_user", "SELECT id FROM example.user WHERE active;").
+			AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig()))).
+		AddView(pqt.NewView("user_stats", "SELECT active, COUNT(*) FROM example.user GROUP BY active", pqt.WithMaterialized()).
+			AddColumn(pqt.NewColumn("active", pqt.TypeBool())).
+			AddColumn(pqt.NewColumn("total", pqt.TypeIntegerBig())))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example."user" (
+	active BOOL NOT NULL,
+	id BIGSERIAL,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+CREATE VIEW example.active_user (id) AS
+SELECT id FROM example.user WHERE active;
+
+CREATE MATERIALIZED VIEW example.user_stats (active, total) AS
+SELECT active, COUNT(*) FROM example.user GROUP BY active;
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_trigger(t *testing.T) {
+	touch := &pqt.Function{
+		Name:     "touch",
+		Type:     pqt.TypeTrigger(),
+		Language: pqt.FunctionLanguagePLpgSQL,
+		Body: `
+BEGIN
+	NEW.updated_at := now();
+	RETURN NEW;
+END;
+`,
+	}
+	audit := &pqt.Function{
+		Name:            "audit",
+		Type:            pqt.TypeTrigger(),
+		Language:        pqt.FunctionLanguagePLpgSQL,
+		Body:            "BEGIN INSERT INTO example.log (msg) VALUES (TG_ARGV[0]); RETURN NULL; END;",
+		SecurityDefiner: true,
+	}
+	active := &pqt.Function{
+		Name:      "active_ids",
+		Type:      pqt.TypeIntegerBig(),
+		SetOf:     true,
+		Body:      "SELECT id FROM example.user WHERE active",
+		Behaviour: pqt.FunctionBehaviourStable,
+	}
+	names := &pqt.Function{
+		Name: "names",
+		ReturnsTable: []*pqt.FunctionArg{
+			{Name: "id", Type: pqt.TypeIntegerBig()},
+			{Name: "name", Type: pqt.TypeText()},
+		},
+		Body:      "SELECT id, name FROM example.user",
+		Behaviour: pqt.FunctionBehaviourStable,
+	}
+	name := pqt.NewColumn("name", pqt.TypeText())
+	user := pqt.NewTable("user").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(name).
+		AddColumn(pqt.NewColumn("updated_at", pqt.TypeTimestampTZ()))
+	user.
+		AddTrigger(pqt.NewTrigger("user_touch", pqt.TriggerTimingBefore, touch,
+			pqt.WithTriggerEvents(pqt.EventInsert, pqt.EventUpdate),
+			pqt.WithUpdateOf(name),
+			pqt.WithForEachRow(),
+			pqt.WithWhen("NEW.name IS NOT NULL"),
+		)).
+		AddTrigger(pqt.NewTrigger("user_audit", pqt.TriggerTimingAfter, audit,
+			pqt.WithTriggerEvents(pqt.EventDelete, pqt.EventTruncate),
+			pqt.WithTriggerArgs("user's removed"),
+		))
+	s := pqt.NewSchema("example").
+		AddFunction(touch).
+		AddFunction(audit).
+		AddFunction(active).
+		AddFunction(names).
+		AddTable(user)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE OR REPLACE FUNCTION example.touch() RETURNS TRIGGER
+	AS $$
+BEGIN
+	NEW.updated_at := now();
+	RETURN NEW;
+END;
+$$
+	LANGUAGE plpgsql
+	VOLATILE;
+
+CREATE OR REPLACE FUNCTION example.audit() RETURNS TRIGGER
+	AS $$BEGIN INSERT INTO example.log (msg) VALUES (TG_ARGV[0]); RETURN NULL; END;$$
+	LANGUAGE plpgsql
+	VOLATILE
+	SECURITY DEFINER;
+
+CREATE OR REPLACE FUNCTION example.active_ids() RETURNS SETOF BIGINT
+	AS 'SELECT id FROM example.user WHERE active'
+	LANGUAGE SQL
+	STABLE;
+
+CREATE OR REPLACE FUNCTION example.names() RETURNS TABLE (id BIGINT, name TEXT)
+	AS 'SELECT id, name FROM example.user'
+	LANGUAGE SQL
+	STABLE;
+
+CREATE TABLE example."user" (
+	id BIGSERIAL,
+	name TEXT,
+	updated_at TIMESTAMPTZ,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+CREATE TRIGGER user_touch BEFORE INSERT OR UPDATE OF name ON example."user"
+	FOR EACH ROW
+	WHEN (NEW.name IS NOT NULL)
+	EXECUTE FUNCTION example.touch();
+
+CREATE TRIGGER user_audit AFTER DELETE OR TRUNCATE ON example."user"
+	FOR EACH STATEMENT
+	EXECUTE FUNCTION example.audit('user''s removed');
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 11}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_exclusion(t *testing.T) {
+	room := pqt.NewColumn("room", pqt.TypeInteger(), pqt.WithNotNull())
+	reservation := pqt.NewTable("reservation").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(room).
+		AddColumn(pqt.NewColumn("starts_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())).
+		AddColumn(pqt.NewColumn("ends_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())).
+		AddColumn(pqt.NewColumn("cancelled", pqt.TypeBool(), pqt.WithNotNull()))
+	reservation.AddExclusion("gist", "NOT cancelled",
+		pqt.ExcludeColumn(room, "="),
+		pqt.ExcludeExpression("tstzrange(starts_at, ends_at)", "&&"),
+	)
+	s := pqt.NewSchema("example").AddTable(reservation)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example.reservation (
+	cancelled BOOL NOT NULL,
+	ends_at TIMESTAMPTZ NOT NULL,
+	id BIGSERIAL,
+	room INTEGER NOT NULL,
+	starts_at TIMESTAMPTZ NOT NULL,
+
+	CONSTRAINT "example.reservation_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "example.reservation_room_EdpdpQ+h_excl" EXCLUDE USING gist (room WITH =, (tstzrange(starts_at, ends_at)) WITH &&) WHERE (NOT cancelled)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_deferrable(t *testing.T) {
+	position := pqt.NewColumn("position", pqt.TypeInteger(), pqt.WithNotNull())
+	item := pqt.NewTable("item").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(position)
+	item.AddRelationship(pqt.ManyToOne(pqt.SelfReference(), pqt.WithColumnName("parent_id")),
+		pqt.WithOnDelete(pqt.Cascade),
+		pqt.WithReferenceMatch(pqt.MatchFull),
+		pqt.WithReferenceInitiallyDeferred(),
+	)
+	item.AddConstraint(pqt.UniqueOn(item, pqt.Columns{position}, pqt.WithDeferrable()))
+	s := pqt.NewSchema("example").AddTable(item)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example.item (
+	id BIGSERIAL,
+	parent_id BIGINT,
+	position INTEGER NOT NULL,
+
+	CONSTRAINT "example.item_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "example.item_parent_id_fkey" FOREIGN KEY (parent_id) REFERENCES example.item (id) MATCH FULL ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED,
+	CONSTRAINT "example.item_position_key" UNIQUE (position) DEFERRABLE INITIALLY IMMEDIATE
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_quoting(t *testing.T) {
+	id := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	order := pqt.NewColumn("order", pqt.TypeInteger(), pqt.WithNotNull())
+	firstName := pqt.NewColumn("FirstName", pqt.TypeText(), pqt.WithIndex())
+	user := pqt.NewTable("user").
+		AddColumn(id).
+		AddColumn(order).
+		AddColumn(firstName)
+	user.AddConstraint(pqt.UniqueOn(user, pqt.Columns{order}))
+	user.AddConstraint(pqt.IndexOn(user, []*pqt.IndexElement{pqt.IndexColumn(order)}, pqt.WithConstraintName("user_by_order"), pqt.WithConstraintComment("ordering")))
+
+	cases := map[string]struct {
+		policy   pqt.QuotingPolicy
+		expected string
+	}{
+		"when-needed": {
+			policy: pqt.QuoteWhenNeeded,
+			expected: `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA "Example"; 
+
+CREATE EXTENSION IF NOT EXISTS citext SCHEMA "Example";
+
+CREATE TABLE "Example"."user" (
+	"FirstName" TEXT,
+	id BIGSERIAL,
+	"order" INTEGER NOT NULL,
+
+	CONSTRAINT "Example.user_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "Example.user_order_key" UNIQUE ("order")
+);
+CREATE INDEX IF NOT EXISTS "Example.user_FirstName_idx" ON "Example"."user" ("FirstName");
+CREATE INDEX IF NOT EXISTS user_by_order ON "Example"."user" ("order");
+COMMENT ON INDEX "Example".user_by_order IS 'ordering';
+
+-- sql schema end
+`,
+		},
+		"always": {
+			policy: pqt.QuoteAlways,
+			expected: `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA "Example"; 
+
+CREATE EXTENSION IF NOT EXISTS "citext" SCHEMA "Example";
+
+CREATE TABLE "Example"."user" (
+	"FirstName" TEXT,
+	"id" BIGSERIAL,
+	"order" INTEGER NOT NULL,
+
+	CONSTRAINT "Example.user_id_pkey" PRIMARY KEY ("id"),
+	CONSTRAINT "Example.user_order_key" UNIQUE ("order")
+);
+CREATE INDEX IF NOT EXISTS "Example.user_FirstName_idx" ON "Example"."user" ("FirstName");
+CREATE INDEX IF NOT EXISTS "user_by_order" ON "Example"."user" ("order");
+COMMENT ON INDEX "Example"."user_by_order" IS 'ordering';
+
+-- sql schema end
+`,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			s := pqt.NewSchema("Example", pqt.WithQuotingPolicy(c.policy), pqt.WithSchemaExtension("citext", pqt.WithExtensionSchema("Example"))).AddTable(user)
+
+			got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if string(got) != c.expected {
+				t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_constraintName(t *testing.T) {
+	name := pqt.NewColumn("organization_name_in_the_billing_system", pqt.TypeText(), pqt.WithNotNull())
+	email := pqt.NewColumn("email", pqt.TypeText(), pqt.WithNotNull())
+	subscription := pqt.NewTable("subscription").
+		AddColumn(name).
+		AddColumn(email)
+	subscription.AddConstraint(pqt.UniqueOn(subscription, pqt.Columns{email, name}))
+	subscription.AddConstraint(pqt.IndexOn(subscription, []*pqt.IndexElement{pqt.IndexColumn(email)}, pqt.WithConstraintName("subscription_by_email")))
+	s := pqt.NewSchema("example").AddTable(subscription)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example.subscription (
+	email TEXT NOT NULL,
+	organization_name_in_the_billing_system TEXT NOT NULL,
+
+	CONSTRAINT "example.subscription_email_organization_name_in_th_PD5rHi3Q_key" UNIQUE (email, organization_name_in_the_billing_system)
+);
+CREATE INDEX IF NOT EXISTS subscription_by_email ON example.subscription (email);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_index(t *testing.T) {
+	email := pqt.NewColumn("email", pqt.TypeText(), pqt.WithNotNull())
+	name := pqt.NewColumn("name", pqt.TypeText())
+	attrs := pqt.NewColumn("attrs", pqt.TypeJSONB())
+	createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ())
+	user := pqt.NewTable("user").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(email).
+		AddColumn(name).
+		AddColumn(attrs).
+		AddColumn(createdAt)
+	user.
+		AddConstraint(pqt.IndexOn(user, []*pqt.IndexElement{pqt.IndexExpression("lower(email)")}, pqt.WithUniqueIndex(""))).
+		AddConstraint(pqt.IndexOn(user, []*pqt.IndexElement{pqt.IndexColumn(attrs, pqt.WithOpClass("jsonb_path_ops"))}, pqt.WithIndexMethod("gin"))).
+		AddConstraint(pqt.IndexOn(user, []*pqt.IndexElement{
+			pqt.IndexColumn(createdAt, pqt.WithDescending(), pqt.WithNulls(pqt.NullsLast)),
+		}, pqt.WithInclude(name), pqt.WithWhere("name IS NOT NULL"), pqt.WithConcurrently()))
+	s := pqt.NewSchema("example").AddTable(user)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example."user" (
+	attrs JSONB,
+	created_at TIMESTAMPTZ,
+	email TEXT NOT NULL,
+	id BIGSERIAL,
+	name TEXT,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "example.user_CWSTXh6z_uidx" ON example."user" ((lower(email)));
+CREATE INDEX IF NOT EXISTS "example.user_attrs_idx" ON example."user" USING gin (attrs jsonb_path_ops);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS "example.user_created_at_TLc0GlP7_idx" ON example."user" (created_at DESC NULLS LAST) INCLUDE (name) WHERE name IS NOT NULL;
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 11}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_partition(t *testing.T) {
+	id := pqt.NewColumn("id", pqt.TypeSerialBig())
+	createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+	event := pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByRange, createdAt)).
+		AddColumn(id).
+		AddColumn(createdAt).
+		AddColumn(pqt.NewColumn("kind", pqt.TypeText(), pqt.WithNotNull())).
+		AddPartition(pqt.PartitionRange("event_2020_01", "'2020-01-01'", "'2020-02-01'")).
+		AddPartition(pqt.PartitionDefault("event_default"))
+	event.AddConstraint(pqt.PrimaryKey(event, id, createdAt))
+	event.AddIndex(createdAt)
+
+	region := pqt.NewColumn("region", pqt.TypeText(), pqt.WithNotNull())
+	user := pqt.NewTable("user", pqt.WithTableIfNotExists(), pqt.WithPartitionBy(pqt.PartitionByList, region)).
+		AddColumn(region).
+		AddPartition(pqt.PartitionList("user_eu", "'de'", "'pl'"))
+
+	bucket := pqt.NewColumn("bucket", pqt.TypeInteger(), pqt.WithNotNull())
+	log := pqt.NewTable("log", pqt.WithPartitionBy(pqt.PartitionByHash, bucket)).
+		AddColumn(bucket).
+		AddPartition(pqt.PartitionHash("log_0", 2, 0)).
+		AddPartition(pqt.PartitionHash("log_1", 2, 1))
+
+	s := pqt.NewSchema("example").AddTable(event).AddTable(user).AddTable(log)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example.event (
+	created_at TIMESTAMPTZ NOT NULL,
+	id BIGSERIAL,
+	kind TEXT NOT NULL,
+
+	CONSTRAINT "example.event_id_created_at_pkey" PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+CREATE TABLE example.event_2020_01 PARTITION OF example.event FOR VALUES FROM ('2020-01-01') TO ('2020-02-01');
+CREATE TABLE example.event_default PARTITION OF example.event DEFAULT;
+CREATE INDEX IF NOT EXISTS "example.event_created_at_idx" ON example.event (created_at);
+
+CREATE TABLE IF NOT EXISTS example."user" (
+	region TEXT NOT NULL
+) PARTITION BY LIST (region);
+CREATE TABLE IF NOT EXISTS example.user_eu PARTITION OF example."user" FOR VALUES IN ('de', 'pl');
+
+CREATE TABLE example.log (
+	bucket INTEGER NOT NULL
+) PARTITION BY HASH (bucket);
+CREATE TABLE example.log_0 PARTITION OF example.log FOR VALUES WITH (MODULUS 2, REMAINDER 0);
+CREATE TABLE example.log_1 PARTITION OF example.log FOR VALUES WITH (MODULUS 2, REMAINDER 1);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 11}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_inherits(t *testing.T) {
+	entry := pqt.NewTable("entry").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull(), pqt.WithCheck("created_at > '2000-01-01'"), pqt.WithNoInherit()))
+	tag := pqt.NewTable("tag").
+		AddColumn(pqt.NewColumn("tags", pqt.TypeTextArray(0)))
+	audit := pqt.NewTable("audit", pqt.WithInherits(entry, tag)).
+		AddColumn(pqt.NewColumn("action", pqt.TypeText(), pqt.WithNotNull()))
+	archive := pqt.NewTable("archive", pqt.WithInherits(audit))
+
+	s := pqt.NewSchema("example").AddTable(entry).AddTable(tag).AddTable(audit).AddTable(archive)
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example.entry (
+	created_at TIMESTAMPTZ NOT NULL,
+	id BIGSERIAL,
+
+	CONSTRAINT "example.entry_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "example.entry_created_at_check" CHECK (created_at > '2000-01-01') NO INHERIT
+);
+
+CREATE TABLE example.tag (
+	tags TEXT[]
+);
+
+CREATE TABLE example.audit (
+	action TEXT NOT NULL
+) INHERITS (example.entry, example.tag);
+
+CREATE TABLE example.archive (
+) INHERITS (example.audit);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_comment(t *testing.T) {
+	title := pqt.NewColumn("title", pqt.TypeText(), pqt.WithNotNull(), pqt.WithComment("is a headline shown on the front page"))
+	news := pqt.NewTable("news", pqt.WithTableComment("is an article published on the site")).
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(title).
+		AddColumn(pqt.NewColumn("lead", pqt.TypeText(), pqt.WithComment("it's optional")))
+	check := pqt.Check(news, "title <> ''", title)
+	check.Comment = "prevents empty headlines"
+	news.AddConstraint(check).
+		AddConstraint(pqt.IndexOn(news, []*pqt.IndexElement{pqt.IndexColumn(title)}, pqt.WithConstraintComment("speeds up search")))
+
+	s := pqt.NewSchema("example").
+		AddTable(news).
+		AddFunction(&pqt.Function{
+			Name:    "multiply",
+			Type:    pqt.TypeIntegerBig(),
+			Body:    "SELECT x * y",
+			Args:    []*pqt.FunctionArg{{Name: "x", Type: pqt.TypeIntegerBig()}, {Name: "y", Type: pqt.TypeIntegerBig()}},
+			Comment: "multiplies two numbers",
+		})
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE OR REPLACE FUNCTION example.multiply(x BIGINT, y BIGINT) RETURNS BIGINT
+	AS 'SELECT x * y'
+	LANGUAGE SQL
+	VOLATILE;
+COMMENT ON FUNCTION example.multiply(BIGINT, BIGINT) IS 'multiplies two numbers';
+
+CREATE TABLE example.news (
+	id BIGSERIAL,
+	lead TEXT,
+	title TEXT NOT NULL,
+
+	CONSTRAINT "example.news_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "example.news_title_check" CHECK (title <> '')
+);
+CREATE INDEX IF NOT EXISTS "example.news_title_idx" ON example.news (title);
+COMMENT ON TABLE example.news IS 'is an article published on the site';
+COMMENT ON COLUMN example.news.lead IS 'it''s optional';
+COMMENT ON COLUMN example.news.title IS 'is a headline shown on the front page';
+COMMENT ON CONSTRAINT "example.news_title_check" ON example.news IS 'prevents empty headlines';
+COMMENT ON INDEX example."example.news_title_idx" IS 'speeds up search';
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_generated(t *testing.T) {
+	s := pqt.NewSchema("example").
+		AddTable(pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityAlways), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("number", pqt.TypeInteger(), pqt.WithIdentity(pqt.IdentityByDefault))).
+			AddColumn(pqt.NewColumn("first_name", pqt.TypeText(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("last_name", pqt.TypeText(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("full_name", pqt.TypeText(), pqt.WithGenerated("first_name || ' ' || last_name"))))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example."user" (
+	first_name TEXT NOT NULL,
+	full_name TEXT GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED,
+	id BIGINT GENERATED ALWAYS AS IDENTITY NOT NULL,
+	last_name TEXT NOT NULL,
+	number INTEGER GENERATED BY DEFAULT AS IDENTITY NOT NULL,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 12}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_columnOrder(t *testing.T) {
+	s := pqt.NewSchema("example").
+		AddTable(pqt.NewTable("user", pqt.WithColumnOrder(pqt.ColumnOrderDeclared)).
+			AddColumn(pqt.NewColumn("first_name", pqt.TypeText(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("last_name", pqt.TypeText(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey(), pqt.WithPosition(-1))))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE TABLE example."user" (
+	id BIGSERIAL,
+	first_name TEXT NOT NULL,
+	last_name TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+
+	CONSTRAINT "example.user_id_pkey" PRIMARY KEY (id)
+);
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_Generate_security(t *testing.T) {
+	tenant := pqt.NewColumn("tenant", pqt.TypeText(), pqt.WithNotNull())
+	email := pqt.NewColumn("email", pqt.TypeText())
+	account := pqt.NewTable("account", pqt.WithForceRowLevelSecurity()).
+		AddColumn(tenant).
+		AddColumn(email).
+		AddPolicy(pqt.NewPolicy("account_tenant",
+			pqt.WithPolicyRoles("app"),
+			pqt.WithUsing("tenant = current_setting('app.tenant')"),
+			pqt.WithPolicyCheck("tenant = current_setting('app.tenant')"),
+		)).
+		AddPolicy(pqt.NewPolicy("account_no_delete", pqt.WithPolicyCommand(pqt.PolicyCommandDelete), pqt.WithRestrictive(), pqt.WithUsing("false"))).
+		AddGrant(pqt.NewGrant("app", pqt.PrivilegeSelect, pqt.PrivilegeInsert, pqt.PrivilegeDelete)).
+		AddGrant(pqt.NewGrant("support", pqt.PrivilegeSelect, pqt.PrivilegeUpdate).OnColumns(email).WithGrantOption())
+
+	s := pqt.NewSchema("example").
+		AddGrant(pqt.NewGrant("app", pqt.PrivilegeUsage)).
+		AddTable(account).
+		AddFunction(&pqt.Function{
+			Name:   "tenant",
+			Type:   pqt.TypeText(),
+			Body:   "SELECT current_user::text",
+			Grants: []*pqt.Grant{pqt.NewGrant("PUBLIC", pqt.PrivilegeExecute)},
+		})
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE SCHEMA example; 
+
+CREATE OR REPLACE FUNCTION example.tenant() RETURNS TEXT
+	AS 'SELECT current_user::text'
+	LANGUAGE SQL
+	VOLATILE;
+
+CREATE TABLE example.account (
+	email TEXT,
+	tenant TEXT NOT NULL
+);
+
+GRANT USAGE ON SCHEMA example TO app;
+GRANT EXECUTE ON FUNCTION example.tenant() TO PUBLIC;
+
+ALTER TABLE example.account ENABLE ROW LEVEL SECURITY;
+ALTER TABLE example.account FORCE ROW LEVEL SECURITY;
+CREATE POLICY account_tenant ON example.account TO app USING (tenant = current_setting('app.tenant')) WITH CHECK (tenant = current_setting('app.tenant'));
+CREATE POLICY account_no_delete ON example.account AS RESTRICTIVE FOR DELETE USING (false);
+GRANT SELECT, INSERT, DELETE ON TABLE example.account TO app;
+GRANT SELECT (email), UPDATE (email) ON TABLE example.account TO support WITH GRANT OPTION;
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).Generate(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateDatabase(t *testing.T) {
+	status := pqt.TypeEnumerated("status", "active", "banned")
+	userID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	user := pqt.NewTable("user").
+		AddColumn(userID).
+		AddColumn(pqt.NewColumn("status", status, pqt.WithNotNull()))
+	invoice := pqt.NewTable("invoice").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(userID), pqt.WithNotNull())).
+		AddColumn(pqt.NewColumn("user_status", status))
+	track := &pqt.Function{
+		Name:     "Track",
+		Type:     pqt.TypeTrigger(),
+		Language: pqt.FunctionLanguagePLpgSQL,
+		Body:     "BEGIN RETURN NEW; END;",
+	}
+	invoice.AddTrigger(pqt.NewTrigger("invoice_track", pqt.TriggerTimingAfter, track, pqt.WithTriggerEvents(pqt.EventInsert)))
+	check := &pqt.Function{
+		Name: "is_active",
+		Type: pqt.TypeBool(),
+		Args: []*pqt.FunctionArg{{Name: "order", Type: status}},
+		Body: "SELECT \"order\" IS NOT NULL",
+	}
+	post := pqt.NewTable("post").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+		AddColumn(pqt.NewColumn("author_id", pqt.TypeIntegerBig(), pqt.WithReference(userID)))
+
+	d := pqt.NewDatabase(
+		pqt.WithExtension("pgcrypto"),
+		pqt.WithExtension("uuid-ossp", pqt.WithExtensionSchema("auth")),
+	).
+		AddSchema(pqt.NewSchema("auth").AddType(status).AddTable(user)).
+		AddSchema(pqt.NewSchema("billing").AddTable(invoice)).
+		AddSchema(pqt.NewSchema("").AddTable(post)).
+		AddSchema(pqt.NewSchema("audit").AddFunction(track).AddFunction(check))
+
+	expected := `-- sql schema beginning
+-- do not modify, generated by pqt
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp" SCHEMA auth;
+
+CREATE SCHEMA auth; 
+
+CREATE SCHEMA billing; 
+
+CREATE SCHEMA audit; 
+
+CREATE TYPE auth.status AS ENUM ('active', 'banned');
+
+CREATE OR REPLACE FUNCTION audit."Track"() RETURNS TRIGGER
+	AS $$BEGIN RETURN NEW; END;$$
+	LANGUAGE plpgsql
+	VOLATILE;
+
+CREATE OR REPLACE FUNCTION audit.is_active("order" auth.status) RETURNS BOOL
+	AS 'SELECT "order" IS NOT NULL'
+	LANGUAGE SQL
+	VOLATILE;
+
+CREATE TABLE auth."user" (
+	id BIGSERIAL,
+	status auth.status NOT NULL,
+
+	CONSTRAINT "auth.user_id_pkey" PRIMARY KEY (id)
+);
+
+CREATE TABLE billing.invoice (
+	id BIGSERIAL,
+	user_id BIGINT NOT NULL,
+	user_status auth.status,
+
+	CONSTRAINT "billing.invoice_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "billing.invoice_user_id_fkey" FOREIGN KEY (user_id) REFERENCES auth."user" (id)
+);
+
+CREATE TABLE post (
+	author_id BIGINT,
+	id BIGSERIAL,
+
+	CONSTRAINT "public.post_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "public.post_author_id_fkey" FOREIGN KEY (author_id) REFERENCES auth."user" (id)
+);
+
+CREATE TRIGGER invoice_track AFTER INSERT ON billing.invoice
+	FOR EACH STATEMENT
+	EXECUTE PROCEDURE audit."Track"();
+
+-- sql schema end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).GenerateDatabase(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong query, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
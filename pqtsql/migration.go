@@ -0,0 +1,694 @@
+package pqtsql
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+// GenerateMigration generates migration script that transforms schema "from" into schema "to".
+// Tables and columns are matched by name, constraints and indexes by pqt.Constraint Name.
+func (g *Generator) GenerateMigration(from, to *pqt.Schema) ([]byte, error) {
+	code, err := g.migrate(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return code.Bytes(), nil
+}
+
+// GenerateMigrationTo works like GenerateMigration, but writes directly into io.Writer.
+func (g *Generator) GenerateMigrationTo(from, to *pqt.Schema, w io.Writer) error {
+	code, err := g.migrate(from, to)
+	if err != nil {
+		return err
+	}
+
+	_, err = code.WriteTo(w)
+	return err
+}
+
+// migrationConstraint is a constraint together with its rendered definition.
+// Definition is used to detect constraints that kept the name but changed.
+type migrationConstraint struct {
+	constraint *pqt.Constraint
+	definition string
+}
+
+func (g *Generator) migrate(from, to *pqt.Schema) (*bytes.Buffer, error) {
+	if from == nil {
+		from = &pqt.Schema{}
+	}
+	if to == nil {
+		return nil, errors.New("missing target schema")
+	}
+	if err := to.Validate(); err != nil {
+		return nil, err
+	}
+
+	oldTables := tablesByName(from)
+	newTables := tablesByName(to)
+
+	oldConstraints, err := g.constraintsByName(from)
+	if err != nil {
+		return nil, err
+	}
+	newConstraints, err := g.constraintsByName(to)
+	if err != nil {
+		return nil, err
+	}
+
+	code := bytes.NewBufferString("-- sql migration beginning\n")
+	code.WriteString("-- do not modify, generated by pqt\n\n")
+
+	if to.Name != "" && to.Name != from.Name {
+		fmt.Fprint(code, "CREATE SCHEMA ")
+		if to.IfNotExists {
+			fmt.Fprint(code, "IF NOT EXISTS ")
+		}
+		fmt.Fprintf(code, "%s; \n\n", to.QuotedName())
+	}
+
+	// Extensions can be shared by many schemas, so they are only ever created.
+	oldExtensions := make(map[string]bool, len(from.Extensions))
+	for _, e := range from.Extensions {
+		oldExtensions[e.Name] = true
+	}
+	var created bool
+	for _, e := range to.Extensions {
+		if oldExtensions[e.Name] {
+			continue
+		}
+		generateCreateExtension(code, to, e)
+		created = true
+	}
+	if created {
+		fmt.Fprintln(code, "")
+	}
+
+	oldViews := viewsByName(from)
+	newViews := viewsByName(to)
+
+	// Views that disappeared or changed are dropped together with their triggers.
+	droppedViews := make(map[string]bool)
+	for _, v := range from.Views {
+		if cur, ok := newViews[v.QualifiedName()]; ok && viewDefinition(cur) == viewDefinition(v) {
+			continue
+		}
+		droppedViews[v.QualifiedName()] = true
+	}
+
+	oldTriggers := triggersByName(from)
+	newTriggers := triggersByName(to)
+	// unchangedTrigger returns true if trigger exists in both schemas in the same form and its relation is kept.
+	unchangedTrigger := func(name string) bool {
+		old, ok := oldTriggers[name]
+		if !ok {
+			return false
+		}
+		cur, ok := newTriggers[name]
+		if !ok || g.triggerDefinition(old) != g.triggerDefinition(cur) {
+			return false
+		}
+		return !droppedViews[old.RelationName()]
+	}
+
+	var dirty bool
+	for _, name := range sortedTriggerNames(oldTriggers) {
+		tr := oldTriggers[name]
+		if unchangedTrigger(name) || droppedViews[tr.RelationName()] {
+			continue
+		}
+		if _, ok := newTables[tr.RelationName()]; tr.Table != nil && !ok {
+			// The whole table is going to be dropped.
+			continue
+		}
+		dropTriggerQuery(code, tr)
+		dirty = true
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+
+	// Policies depend on columns, so those that disappeared or changed are dropped before columns are altered.
+	dirty = false
+	for _, t := range from.Tables {
+		if cur, ok := newTables[t.QualifiedName()]; ok && dropPoliciesQuery(code, t, cur) {
+			dirty = true
+		}
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+
+	// Views depend on tables, so those that disappeared or changed are dropped before anything else.
+	// Views can depend on each other, so they are dropped in reverse order.
+	dirty = false
+	for i := len(from.Views) - 1; i >= 0; i-- {
+		v := from.Views[i]
+		if !droppedViews[v.QualifiedName()] {
+			continue
+		}
+		dropViewQuery(code, v)
+		dirty = true
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+
+	// Constraints that disappeared or changed are dropped first, so that columns and tables they depend on can be modified.
+	var drop []migrationConstraint
+	for _, name := range sortedConstraintNames(oldConstraints) {
+		old := oldConstraints[name]
+		if _, ok := newTables[old.constraint.PrimaryTable.QualifiedName()]; !ok {
+			// The whole table is going to be dropped.
+			continue
+		}
+		if cur, ok := newConstraints[name]; ok && cur.definition == old.definition {
+			continue
+		}
+		drop = append(drop, old)
+	}
+	sortConstraints(drop, true)
+	for _, mc := range drop {
+		dropConstraintQuery(code, mc.constraint)
+	}
+	if len(drop) > 0 {
+		fmt.Fprintln(code, "")
+	}
+
+	oldTypes := make(map[string]pqt.Type, len(from.Types))
+	for _, t := range from.Types {
+		oldTypes[t.String()] = t
+	}
+	for _, t := range to.Types {
+		old, ok := oldTypes[t.String()]
+		if !ok {
+			generateCreateType(code, to, t)
+			continue
+		}
+		dirty, err := alterTypeQuery(code, to, old, t)
+		if err != nil {
+			return nil, err
+		}
+		if dirty {
+			fmt.Fprintln(code, "")
+		}
+	}
+
+	oldFunctions := make(map[string]*pqt.Function, len(from.Functions))
+	for _, f := range from.Functions {
+		oldFunctions[f.Name] = f
+	}
+	for _, f := range to.Functions {
+		if f.BuiltIn {
+			continue
+		}
+		cur, err := g.functionDefinition(to, f)
+		if err != nil {
+			return nil, err
+		}
+		old, ok := oldFunctions[f.Name]
+		if ok && !old.BuiltIn {
+			def, err := g.functionDefinition(from, old)
+			if err != nil {
+				return nil, err
+			}
+			if def == cur {
+				continue
+			}
+		}
+		if ok && old.Comment != "" && f.Comment == "" {
+			// Replaced function keeps its comment.
+			code.WriteString(strings.TrimSuffix(cur, "\n"))
+			functionCommentQuery(code, to, f)
+			code.WriteString("\n")
+			continue
+		}
+		code.WriteString(cur)
+	}
+
+	for i := len(from.Tables) - 1; i >= 0; i-- {
+		t := from.Tables[i]
+		if _, ok := newTables[t.QualifiedName()]; ok {
+			continue
+		}
+		fmt.Fprintf(code, "DROP TABLE %s;\n\n", t.FullName())
+	}
+
+	for _, t := range to.Tables {
+		if _, ok := oldTables[t.QualifiedName()]; ok {
+			continue
+		}
+		if err := g.generateCreateTable(code, t); err != nil {
+			return nil, err
+		}
+		for _, p := range t.Partitions {
+			generateCreatePartition(code, p)
+		}
+		g.generateIndexes(code, t)
+		generateComments(code, t)
+		fmt.Fprintln(code, "")
+	}
+
+	for _, t := range to.Tables {
+		old, ok := oldTables[t.QualifiedName()]
+		if !ok {
+			continue
+		}
+		if alterTableQuery(code, old, t) {
+			fmt.Fprintln(code, "")
+		}
+	}
+
+	// Partitions of the tables that are kept are compared one by one, the remaining ones follow their parent.
+	oldPartitions := partitionsByName(from)
+	newPartitions := partitionsByName(to)
+	dirty = false
+	for _, t := range from.Tables {
+		if _, ok := newTables[t.QualifiedName()]; !ok {
+			continue
+		}
+		for _, p := range t.Partitions {
+			cur, ok := newPartitions[p.QualifiedName()]
+			switch {
+			case !ok:
+				dropPartitionQuery(code, p)
+			case cur.Parent.QualifiedName() != p.Parent.QualifiedName() || partitionBound(cur) != partitionBound(p):
+				alterPartitionQuery(code, p, cur)
+			default:
+				continue
+			}
+			dirty = true
+		}
+	}
+	for _, t := range to.Tables {
+		if _, ok := oldTables[t.QualifiedName()]; !ok {
+			continue
+		}
+		for _, p := range t.Partitions {
+			if _, ok := oldPartitions[p.QualifiedName()]; ok {
+				continue
+			}
+			generateCreatePartition(code, p)
+			dirty = true
+		}
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+
+	var add []migrationConstraint
+	for _, name := range sortedConstraintNames(newConstraints) {
+		cur := newConstraints[name]
+		if _, ok := oldTables[cur.constraint.PrimaryTable.QualifiedName()]; !ok {
+			// Constraints of the new tables are part of CREATE TABLE statement.
+			continue
+		}
+		if old, ok := oldConstraints[name]; ok && old.definition == cur.definition {
+			continue
+		}
+		add = append(add, cur)
+	}
+	sortConstraints(add, false)
+	for _, mc := range add {
+		switch mc.constraint.Type {
+		case pqt.ConstraintTypeIndex:
+			indexConstraintQuery(code, mc.constraint, g.Version)
+		case pqt.ConstraintTypeUniqueIndex:
+			uniqueIndexConstraintQuery(code, mc.constraint, g.Version)
+		default:
+			fmt.Fprintf(code, "ALTER TABLE %s ADD ", mc.constraint.PrimaryTable.FullName())
+			if err := g.generateConstraint(code, mc.constraint); err != nil {
+				return nil, err
+			}
+			fmt.Fprint(code, ";\n")
+		}
+	}
+	if len(add) > 0 {
+		fmt.Fprintln(code, "")
+	}
+
+	recreated := make(map[string]bool, len(add))
+	for _, mc := range add {
+		recreated[mc.constraint.Name()] = true
+	}
+	dirty = false
+	for _, t := range to.Tables {
+		if old, ok := oldTables[t.QualifiedName()]; ok && alterCommentsQuery(code, old, t, recreated) {
+			dirty = true
+		}
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+
+	for _, v := range to.Views {
+		if old, ok := oldViews[v.QualifiedName()]; ok && viewDefinition(old) == viewDefinition(v) {
+			continue
+		}
+		generateCreateView(code, v)
+	}
+
+	for _, name := range sortedTriggerNames(newTriggers) {
+		if unchangedTrigger(name) {
+			continue
+		}
+		g.generateCreateTrigger(code, newTriggers[name])
+	}
+
+	var oldGrants []*pqt.Grant
+	if from.Name == to.Name {
+		oldGrants = from.Grants
+	}
+	dirty = grantsQuery(code, schemaObject(to), oldGrants, to.Grants)
+	for _, f := range to.Functions {
+		oldGrants = nil
+		if old, ok := oldFunctions[f.Name]; ok {
+			oldGrants = old.Grants
+		}
+		if grantsQuery(code, functionObject(to, f), oldGrants, f.Grants) {
+			dirty = true
+		}
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+	for _, t := range to.Tables {
+		if tableSecurityQuery(code, oldTables[t.QualifiedName()], t) {
+			fmt.Fprintln(code, "")
+		}
+	}
+
+	newFunctions := make(map[string]bool, len(to.Functions))
+	for _, f := range to.Functions {
+		newFunctions[f.Name] = true
+	}
+	dirty = false
+	for i := len(from.Functions) - 1; i >= 0; i-- {
+		f := from.Functions[i]
+		if f.BuiltIn || newFunctions[f.Name] {
+			continue
+		}
+		dropFunctionQuery(code, from, f)
+		dirty = true
+	}
+	if dirty {
+		fmt.Fprintln(code, "")
+	}
+
+	// Types are dropped last, once nothing depends on them.
+	newTypes := make(map[string]bool, len(to.Types))
+	for _, t := range to.Types {
+		newTypes[t.String()] = true
+	}
+	var dropped bool
+	// Types can depend on each other, so they are dropped in reverse order.
+	for i := len(from.Types) - 1; i >= 0; i-- {
+		t := from.Types[i]
+		if !userDefined(t) || newTypes[t.String()] {
+			continue
+		}
+		if _, ok := t.(pqt.DomainType); ok {
+			fmt.Fprintf(code, "DROP DOMAIN %s;\n", typeName(from, t))
+		} else {
+			fmt.Fprintf(code, "DROP TYPE %s;\n", typeName(from, t))
+		}
+		dropped = true
+	}
+	if dropped {
+		fmt.Fprintln(code, "")
+	}
+
+	code.WriteString("-- sql migration end\n")
+	return code, nil
+}
+
+// alterTableQuery writes ALTER TABLE statements that transforms columns of the old table into columns of the new one.
+// It returns true if anything was written.
+func alterTableQuery(buf *bytes.Buffer, old, cur *pqt.Table) bool {
+	var dirty bool
+
+	// Table keeps columns of the parent it no longer inherits from.
+	for _, p := range old.Inherits {
+		if !inherits(cur, p) {
+			fmt.Fprintf(buf, "ALTER TABLE %s NO INHERIT %s;\n", cur.FullName(), p.FullName())
+			dirty = true
+		}
+	}
+
+	oldColumns := make(map[string]*pqt.Column, len(old.Columns))
+	for _, c := range old.Columns {
+		if !c.IsDynamic {
+			oldColumns[c.Name] = c
+		}
+	}
+
+	for _, c := range cur.Columns {
+		if c.IsDynamic {
+			continue
+		}
+		oc, ok := oldColumns[c.Name]
+		if !ok {
+			fmt.Fprintf(buf, "ALTER TABLE %s ADD COLUMN ", cur.FullName())
+			generateColumn(buf, c)
+			buf.WriteString(";\n")
+			dirty = true
+			continue
+		}
+		delete(oldColumns, c.Name)
+
+		// Expression of generated column cannot be altered, column has to be recreated.
+		if c.Generated != "" && oc.Generated != c.Generated {
+			fmt.Fprintf(buf, "ALTER TABLE %s DROP COLUMN %s;\n", cur.FullName(), c.QuotedName())
+			fmt.Fprintf(buf, "ALTER TABLE %s ADD COLUMN ", cur.FullName())
+			generateColumn(buf, c)
+			buf.WriteString(";\n")
+			dirty = true
+			continue
+		}
+		if c.Generated == "" && oc.Generated != "" {
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s DROP EXPRESSION;\n", cur.FullName(), c.QuotedName())
+			dirty = true
+		}
+
+		if oc.Type.String() != c.Type.String() || oc.Collate != c.Collate {
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s TYPE %s", cur.FullName(), c.QuotedName(), columnType(c))
+			if c.Collate != "" {
+				fmt.Fprintf(buf, " COLLATE %s", c.Collate)
+			}
+			buf.WriteString(";\n")
+			dirty = true
+		}
+		if c.Identity == "" && oc.Identity != "" {
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s DROP IDENTITY;\n", cur.FullName(), c.QuotedName())
+			dirty = true
+		}
+		od, odok := oc.DefaultOn(pqt.EventInsert)
+		d, dok := c.DefaultOn(pqt.EventInsert)
+		switch {
+		case dok && (!odok || od != d):
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;\n", cur.FullName(), c.QuotedName(), d)
+			dirty = true
+		case !dok && odok:
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;\n", cur.FullName(), c.QuotedName())
+			dirty = true
+		}
+		if oc.NotNull != c.NotNull {
+			if c.NotNull {
+				fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;\n", cur.FullName(), c.QuotedName())
+			} else {
+				fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;\n", cur.FullName(), c.QuotedName())
+			}
+			dirty = true
+		}
+		// Column has to be not null before it becomes an identity column.
+		switch {
+		case c.Identity != "" && oc.Identity == "":
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY;\n", cur.FullName(), c.QuotedName(), c.Identity)
+			dirty = true
+		case c.Identity != "" && oc.Identity != c.Identity:
+			fmt.Fprintf(buf, "ALTER TABLE %s ALTER COLUMN %s SET GENERATED %s;\n", cur.FullName(), c.QuotedName(), c.Identity)
+			dirty = true
+		}
+	}
+
+	inherited := make(map[string]bool)
+	for _, c := range cur.InheritedColumns() {
+		inherited[c.Name] = true
+	}
+	for _, c := range old.Columns {
+		// Column that is now defined by a parent table stays in place.
+		if _, ok := oldColumns[c.Name]; !ok || inherited[c.Name] {
+			continue
+		}
+		fmt.Fprintf(buf, "ALTER TABLE %s DROP COLUMN %s;\n", cur.FullName(), c.QuotedName())
+		dirty = true
+	}
+	// Columns of the parent that table no longer inherits from stay in the table unless dropped explicitly.
+	kept := make(map[string]bool, len(inherited)+len(cur.Columns))
+	for _, c := range append(cur.InheritedColumns(), cur.Columns...) {
+		kept[c.Name] = true
+	}
+	for _, p := range old.Inherits {
+		if inherits(cur, p) {
+			continue
+		}
+		for _, c := range append(p.InheritedColumns(), p.Columns...) {
+			if c.IsDynamic || kept[c.Name] {
+				continue
+			}
+			kept[c.Name] = true
+			fmt.Fprintf(buf, "ALTER TABLE %s DROP COLUMN %s;\n", cur.FullName(), c.QuotedName())
+			dirty = true
+		}
+	}
+
+	// Table has to contain all columns of the parent before it can inherit from it.
+	existing := make(map[string]bool)
+	for _, c := range append(old.InheritedColumns(), old.Columns...) {
+		existing[c.Name] = true
+	}
+	for _, p := range cur.Inherits {
+		if inherits(old, p) {
+			continue
+		}
+		for _, c := range append(p.InheritedColumns(), p.Columns...) {
+			if existing[c.Name] || c.IsDynamic {
+				continue
+			}
+			existing[c.Name] = true
+			fmt.Fprintf(buf, "ALTER TABLE %s ADD COLUMN ", cur.FullName())
+			generateColumn(buf, c)
+			buf.WriteString(";\n")
+		}
+		fmt.Fprintf(buf, "ALTER TABLE %s INHERIT %s;\n", cur.FullName(), p.FullName())
+		dirty = true
+	}
+
+	return dirty
+}
+
+// inherits returns true if table inherits directly from a table with the same name as given parent.
+func inherits(t, parent *pqt.Table) bool {
+	for _, p := range t.Inherits {
+		if p.QualifiedName() == parent.QualifiedName() {
+			return true
+		}
+	}
+	return false
+}
+
+func dropConstraintQuery(buf *bytes.Buffer, c *pqt.Constraint) {
+	switch c.Type {
+	case pqt.ConstraintTypeIndex, pqt.ConstraintTypeUniqueIndex:
+		buf.WriteString("DROP INDEX ")
+		if c.Concurrently {
+			buf.WriteString("CONCURRENTLY ")
+		}
+		fmt.Fprintf(buf, "%s;", indexName(c))
+	default:
+		fmt.Fprintf(buf, "ALTER TABLE %s DROP CONSTRAINT %s;", c.PrimaryTable.FullName(), c.QuotedName())
+	}
+	fmt.Fprintln(buf, "")
+}
+
+func tablesByName(s *pqt.Schema) map[string]*pqt.Table {
+	tables := make(map[string]*pqt.Table, len(s.Tables))
+	for _, t := range s.Tables {
+		tables[t.QualifiedName()] = t
+	}
+	return tables
+}
+
+// triggersByName maps triggers to their names in format <relation>.<trigger>, trigger names are unique only within relation.
+func triggersByName(s *pqt.Schema) map[string]*pqt.Trigger {
+	triggers := make(map[string]*pqt.Trigger)
+	for _, t := range s.Tables {
+		for _, tr := range t.Triggers {
+			triggers[tr.RelationName()+"."+tr.Name] = tr
+		}
+	}
+	for _, v := range s.Views {
+		for _, tr := range v.Triggers {
+			triggers[tr.RelationName()+"."+tr.Name] = tr
+		}
+	}
+	return triggers
+}
+
+func sortedTriggerNames(triggers map[string]*pqt.Trigger) []string {
+	names := make([]string, 0, len(triggers))
+	for name := range triggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func viewsByName(s *pqt.Schema) map[string]*pqt.View {
+	views := make(map[string]*pqt.View, len(s.Views))
+	for _, v := range s.Views {
+		views[v.QualifiedName()] = v
+	}
+	return views
+}
+
+func (g *Generator) constraintsByName(s *pqt.Schema) (map[string]migrationConstraint, error) {
+	constraints := make(map[string]migrationConstraint)
+	for _, t := range s.Tables {
+		for _, c := range tableConstraints(t) {
+			buf := bytes.NewBuffer(nil)
+			switch c.Type {
+			case pqt.ConstraintTypeIndex:
+				indexConstraintQuery(buf, c, g.Version)
+			case pqt.ConstraintTypeUniqueIndex:
+				uniqueIndexConstraintQuery(buf, c, g.Version)
+			default:
+				if err := g.generateConstraint(buf, c); err != nil {
+					return nil, err
+				}
+			}
+			constraints[c.Name()] = migrationConstraint{
+				constraint: c,
+				definition: buf.String(),
+			}
+		}
+	}
+	return constraints, nil
+}
+
+func sortedConstraintNames(constraints map[string]migrationConstraint) []string {
+	names := make([]string, 0, len(constraints))
+	for name := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortConstraints orders constraints so that dependencies are respected.
+// Foreign keys need to be dropped before and created after keys they reference.
+func sortConstraints(constraints []migrationConstraint, drop bool) {
+	priority := func(c *pqt.Constraint) int {
+		p := 1
+		switch c.Type {
+		case pqt.ConstraintTypePrimaryKey:
+			p = 0
+		case pqt.ConstraintTypeForeignKey:
+			p = 2
+		}
+		if drop {
+			return -p
+		}
+		return p
+	}
+	sort.SliceStable(constraints, func(i, j int) bool {
+		return priority(constraints[i].constraint) < priority(constraints[j].constraint)
+	})
+}
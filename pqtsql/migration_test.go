@@ -0,0 +1,577 @@
+package pqtsql_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+	"github.com/piotrkowalczuk/pqt/pqtsql"
+)
+
+func TestGenerator_GenerateMigration(t *testing.T) {
+	before := func() *pqt.Schema {
+		user := pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("username", pqt.TypeText(), pqt.WithNotNull(), pqt.WithUnique())).
+			AddColumn(pqt.NewColumn("age", pqt.TypeInteger())).
+			AddColumn(pqt.NewColumn("nick", pqt.TypeText(), pqt.WithIndex())).
+			AddColumn(pqt.NewColumn("status", pqt.TypeText(), pqt.WithDefault("'active'")))
+		legacy := pqt.NewTable("legacy").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey()))
+
+		return pqt.NewSchema("").AddTable(user).AddTable(legacy)
+	}
+	after := func() *pqt.Schema {
+		userID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+		user := pqt.NewTable("user").
+			AddColumn(userID).
+			AddColumn(pqt.NewColumn("username", pqt.TypeText(), pqt.WithNotNull(), pqt.WithUnique())).
+			AddColumn(pqt.NewColumn("age", pqt.TypeIntegerBig(), pqt.WithNotNull(), pqt.WithCheck("age > 0"))).
+			AddColumn(pqt.NewColumn("email", pqt.TypeText(), pqt.WithIndex())).
+			AddColumn(pqt.NewColumn("status", pqt.TypeText()))
+		post := pqt.NewTable("post").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(userID)))
+
+		return pqt.NewSchema("").AddTable(user).AddTable(post)
+	}
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+DROP INDEX "public.user_nick_idx";
+
+DROP TABLE legacy;
+
+CREATE TABLE post (
+	id BIGSERIAL,
+	user_id BIGINT,
+
+	CONSTRAINT "public.post_id_pkey" PRIMARY KEY (id),
+	CONSTRAINT "public.post_user_id_fkey" FOREIGN KEY (user_id) REFERENCES "user" (id)
+);
+
+ALTER TABLE "user" ALTER COLUMN age TYPE BIGINT;
+ALTER TABLE "user" ALTER COLUMN age SET NOT NULL;
+ALTER TABLE "user" ADD COLUMN email TEXT;
+ALTER TABLE "user" ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE "user" DROP COLUMN nick;
+
+ALTER TABLE "user" ADD CONSTRAINT "public.user_age_check" CHECK (age > 0);
+CREATE INDEX "public.user_email_idx" ON "user" (email);
+
+-- sql migration end
+`
+
+	g := &pqtsql.Generator{}
+	got, err := g.GenerateMigration(before(), after())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_noChanges(t *testing.T) {
+	schema := func() *pqt.Schema {
+		return pqt.NewSchema("example").AddTable(
+			pqt.NewTable("user").
+				AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+				AddColumn(pqt.NewColumn("username", pqt.TypeText(), pqt.WithNotNull(), pqt.WithUnique())),
+		)
+	}
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+-- sql migration end
+`
+
+	g := &pqtsql.Generator{}
+	got, err := g.GenerateMigration(schema(), schema())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_enumeratedType(t *testing.T) {
+	before := pqt.NewSchema("example").
+		AddType(pqt.TypeEnumerated("mood", "happy", "sad")).
+		AddType(pqt.TypeEnumerated("color", "red", "green"))
+	after := pqt.NewSchema("example").
+		AddType(pqt.TypeEnumerated("mood", "happy", "excited", "sad", "angry")).
+		AddType(pqt.TypeEnumerated("size", "s", "m", "l"))
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+ALTER TYPE example.mood ADD VALUE 'excited' AFTER 'happy';
+ALTER TYPE example.mood ADD VALUE 'angry' AFTER 'sad';
+
+CREATE TYPE example.size AS ENUM ('s', 'm', 'l');
+
+DROP TYPE example.color;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{}).GenerateMigration(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+
+	_, err = (&pqtsql.Generator{}).GenerateMigration(after, before)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != "value excited cannot be removed from enumerated type mood" {
+		t.Errorf("wrong error: %s", err.Error())
+	}
+}
+
+func TestGenerator_GenerateMigration_domainType(t *testing.T) {
+	before := pqt.NewSchema("example", pqt.WithSchemaExtension("citext")).
+		AddType(pqt.TypeDomain("amount", pqt.TypeNumeric(10, 2), pqt.WithDomainDefault("0"), pqt.WithDomainCheck("VALUE >= 0"))).
+		AddType(pqt.TypeDomain("slug", pqt.TypeText()))
+	after := pqt.NewSchema("example", pqt.WithSchemaExtension("citext"), pqt.WithSchemaExtension("pgcrypto")).
+		AddType(pqt.TypeDomain("amount", pqt.TypeNumeric(10, 2), pqt.WithDomainNotNull(), pqt.WithDomainCheck("VALUE >= 0"), pqt.WithDomainCheck("VALUE < 1000"))).
+		AddType(pqt.TypeDomain("email", pqt.TypeCIText(), pqt.WithDomainCheck("VALUE ~ '@'")))
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+ALTER DOMAIN example.amount DROP DEFAULT;
+ALTER DOMAIN example.amount SET NOT NULL;
+ALTER DOMAIN example.amount ADD CHECK (VALUE < 1000);
+
+CREATE DOMAIN example.email AS CITEXT CHECK (VALUE ~ '@');
+
+DROP DOMAIN example.slug;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{}).GenerateMigration(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+
+	_, err = (&pqtsql.Generator{}).GenerateMigration(after, before)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if err.Error() != "check VALUE < 1000 cannot be removed from domain amount" {
+		t.Errorf("wrong error: %s", err.Error())
+	}
+}
+
+func TestGenerator_GenerateMigration_compositeType(t *testing.T) {
+	before := pqt.NewSchema("example").
+		AddType(pqt.TypeComposite("address",
+			&pqt.Attribute{Name: "street", Type: pqt.TypeText()},
+			&pqt.Attribute{Name: "number", Type: pqt.TypeInteger()},
+			&pqt.Attribute{Name: "flat", Type: pqt.TypeInteger()},
+		)).
+		AddType(pqt.TypeComposite("point",
+			&pqt.Attribute{Name: "x", Type: pqt.TypeDoublePrecision()},
+			&pqt.Attribute{Name: "y", Type: pqt.TypeDoublePrecision()},
+		))
+	after := pqt.NewSchema("example").
+		AddType(pqt.TypeComposite("address",
+			&pqt.Attribute{Name: "street", Type: pqt.TypeText(), Collate: "C"},
+			&pqt.Attribute{Name: "number", Type: pqt.TypeText()},
+			&pqt.Attribute{Name: "city", Type: pqt.TypeText()},
+		))
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+ALTER TYPE example.address ALTER ATTRIBUTE street TYPE TEXT COLLATE C;
+ALTER TYPE example.address ALTER ATTRIBUTE number TYPE TEXT;
+ALTER TYPE example.address ADD ATTRIBUTE city TEXT;
+ALTER TYPE example.address DROP ATTRIBUTE flat;
+
+DROP TYPE example.point;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{}).GenerateMigration(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_view(t *testing.T) {
+	before := pqt.NewSchema("example").
+		AddView(pqt.NewView("kept", "SELECT 1").
+			AddColumn(pqt.NewColumn("one", pqt.TypeInteger()))).
+		AddView(pqt.NewView("changed", "SELECT 1").
+			AddColumn(pqt.NewColumn("one", pqt.TypeInteger()))).
+		AddView(pqt.NewView("removed", "SELECT 1", pqt.WithMaterialized()).
+			AddColumn(pqt.NewColumn("one", pqt.TypeInteger())))
+	after := pqt.NewSchema("example").
+		AddView(pqt.NewView("kept", "SELECT 1").
+			AddColumn(pqt.NewColumn("one", pqt.TypeInteger()))).
+		AddView(pqt.NewView("changed", "SELECT 1, 2").
+			AddColumn(pqt.NewColumn("one", pqt.TypeInteger())).
+			AddColumn(pqt.NewColumn("two", pqt.TypeInteger()))).
+		AddView(pqt.NewView("added", "SELECT 1", pqt.WithMaterialized()).
+			AddColumn(pqt.NewColumn("one", pqt.TypeInteger())))
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+DROP MATERIALIZED VIEW example.removed;
+DROP VIEW example.changed;
+
+CREATE VIEW example.changed (one, two) AS
+SELECT 1, 2;
+
+CREATE MATERIALIZED VIEW example.added (one) AS
+SELECT 1;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{}).GenerateMigration(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_trigger(t *testing.T) {
+	schema := func(after bool) *pqt.Schema {
+		body := "BEGIN RETURN NEW; END;"
+		if after {
+			body = "BEGIN NEW.updated_at := now(); RETURN NEW; END;"
+		}
+		touch := &pqt.Function{Name: "touch", Type: pqt.TypeTrigger(), Language: pqt.FunctionLanguagePLpgSQL, Body: body}
+		write := &pqt.Function{Name: "write", Type: pqt.TypeTrigger(), Language: pqt.FunctionLanguagePLpgSQL, Body: "BEGIN RETURN NULL; END;"}
+		user := pqt.NewTable("user").
+			AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+			AddColumn(pqt.NewColumn("updated_at", pqt.TypeTimestampTZ()))
+		query := "SELECT id FROM example.user"
+		if after {
+			query = "SELECT id FROM example.user WHERE updated_at IS NOT NULL"
+		}
+		view := pqt.NewView("user_view", query).
+			AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig())).
+			AddTrigger(pqt.NewTrigger("user_view_write", pqt.TriggerTimingInsteadOf, write,
+				pqt.WithTriggerEvents(pqt.EventInsert),
+				pqt.WithForEachRow(),
+			))
+		s := pqt.NewSchema("example").AddFunction(touch).AddFunction(write)
+		if after {
+			user.AddTrigger(pqt.NewTrigger("user_touch", pqt.TriggerTimingBefore, touch,
+				pqt.WithTriggerEvents(pqt.EventUpdate),
+				pqt.WithForEachRow(),
+				pqt.WithWhen("OLD.* IS DISTINCT FROM NEW.*"),
+			))
+		} else {
+			user.AddTrigger(pqt.NewTrigger("user_touch", pqt.TriggerTimingBefore, touch,
+				pqt.WithTriggerEvents(pqt.EventUpdate),
+				pqt.WithForEachRow(),
+			)).AddTrigger(pqt.NewTrigger("user_cleanup", pqt.TriggerTimingAfter, write,
+				pqt.WithTriggerEvents(pqt.EventDelete),
+			))
+			s.AddFunction(&pqt.Function{
+				Name: "obsolete",
+				Type: pqt.TypeIntegerBig(),
+				Args: []*pqt.FunctionArg{{Name: "x", Type: pqt.TypeIntegerBig()}},
+				Body: "SELECT x",
+			})
+		}
+		return s.AddTable(user).AddView(view)
+	}
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+DROP TRIGGER user_cleanup ON example."user";
+DROP TRIGGER user_touch ON example."user";
+
+DROP VIEW example.user_view;
+
+CREATE OR REPLACE FUNCTION example.touch() RETURNS TRIGGER
+	AS $$BEGIN NEW.updated_at := now(); RETURN NEW; END;$$
+	LANGUAGE plpgsql
+	VOLATILE;
+
+CREATE VIEW example.user_view (id) AS
+SELECT id FROM example.user WHERE updated_at IS NOT NULL;
+
+CREATE TRIGGER user_touch BEFORE UPDATE ON example."user"
+	FOR EACH ROW
+	WHEN (OLD.* IS DISTINCT FROM NEW.*)
+	EXECUTE PROCEDURE example.touch();
+
+CREATE TRIGGER user_view_write INSTEAD OF INSERT ON example.user_view
+	FOR EACH ROW
+	EXECUTE PROCEDURE example.write();
+
+DROP FUNCTION example.obsolete(BIGINT);
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{}).GenerateMigration(schema(false), schema(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_index(t *testing.T) {
+	schema := func(expression string) *pqt.Schema {
+		user := pqt.NewTable("user").AddColumn(pqt.NewColumn("email", pqt.TypeText()))
+		user.AddConstraint(pqt.IndexOn(user, []*pqt.IndexElement{pqt.IndexExpression(expression)}, pqt.WithIndexMethod("hash"), pqt.WithConcurrently()))
+		return pqt.NewSchema("example").AddTable(user)
+	}
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+DROP INDEX CONCURRENTLY example."example.user_CWSTXh6z_idx";
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS "example.user_0Tx392l5_idx" ON example."user" USING hash ((upper(email)));
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).GenerateMigration(schema("lower(email)"), schema("upper(email)"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_partition(t *testing.T) {
+	schema := func(partitions ...*pqt.Partition) *pqt.Schema {
+		createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())
+		event := pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByRange, createdAt)).AddColumn(createdAt)
+		for _, p := range partitions {
+			event.AddPartition(p)
+		}
+		return pqt.NewSchema("example").AddTable(event)
+	}
+
+	before := schema(
+		pqt.PartitionRange("event_2020_01", "'2020-01-01'", "'2020-02-01'"),
+		pqt.PartitionRange("event_2020_02", "'2020-02-01'", "'2020-03-01'"),
+		pqt.PartitionDefault("event_default"),
+	)
+	after := schema(
+		pqt.PartitionRange("event_2020_01", "'2020-01-01'", "'2020-02-01'"),
+		pqt.PartitionRange("event_2020_02", "'2020-02-01'", "'2020-04-01'"),
+		pqt.PartitionRange("event_2020_04", "'2020-04-01'", "'2020-05-01'"),
+	)
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+ALTER TABLE example.event DETACH PARTITION example.event_2020_02;
+ALTER TABLE example.event ATTACH PARTITION example.event_2020_02 FOR VALUES FROM ('2020-02-01') TO ('2020-04-01');
+DROP TABLE example.event_default;
+CREATE TABLE example.event_2020_04 PARTITION OF example.event FOR VALUES FROM ('2020-04-01') TO ('2020-05-01');
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{Version: 11}).GenerateMigration(before, after)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_inherits(t *testing.T) {
+	schema := func(inherit bool) *pqt.Schema {
+		entry := pqt.NewTable("entry").
+			AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ(), pqt.WithNotNull())).
+			AddColumn(pqt.NewColumn("name", pqt.TypeText()))
+		tag := pqt.NewTable("tag").
+			AddColumn(pqt.NewColumn("label", pqt.TypeText()))
+		var opts []pqt.TableOption
+		if inherit {
+			opts = append(opts, pqt.WithInherits(entry))
+		} else {
+			opts = append(opts, pqt.WithInherits(tag))
+		}
+		audit := pqt.NewTable("audit", opts...).
+			AddColumn(pqt.NewColumn("action", pqt.TypeText()))
+		if !inherit {
+			audit.AddColumn(pqt.NewColumn("name", pqt.TypeText()))
+		}
+		return pqt.NewSchema("example").AddTable(entry).AddTable(tag).AddTable(audit)
+	}
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+ALTER TABLE example.audit NO INHERIT example.tag;
+ALTER TABLE example.audit DROP COLUMN label;
+ALTER TABLE example.audit ADD COLUMN created_at TIMESTAMPTZ NOT NULL;
+ALTER TABLE example.audit INHERIT example.entry;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).GenerateMigration(schema(false), schema(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_comment(t *testing.T) {
+	schema := func(comment string) *pqt.Schema {
+		title := pqt.NewColumn("title", pqt.TypeText(), pqt.WithComment(comment))
+		news := pqt.NewTable("news", pqt.WithTableComment(comment)).
+			AddColumn(title)
+		news.AddConstraint(pqt.IndexOn(news, []*pqt.IndexElement{pqt.IndexColumn(title)}, pqt.WithConstraintComment(comment)))
+		return pqt.NewSchema("example").
+			AddTable(news).
+			AddFunction(&pqt.Function{Name: "one", Type: pqt.TypeInteger(), Body: "SELECT 1", Comment: comment})
+	}
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+CREATE OR REPLACE FUNCTION example.one() RETURNS INTEGER
+	AS 'SELECT 1'
+	LANGUAGE SQL
+	VOLATILE;
+COMMENT ON FUNCTION example.one() IS NULL;
+
+COMMENT ON TABLE example.news IS NULL;
+COMMENT ON COLUMN example.news.title IS NULL;
+COMMENT ON INDEX example."example.news_title_idx" IS NULL;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).GenerateMigration(schema("news"), schema(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
+
+func TestGenerator_GenerateMigration_generated(t *testing.T) {
+	schema := func(identity pqt.Identity, expr string) *pqt.Schema {
+		return pqt.NewSchema("example").
+			AddTable(pqt.NewTable("user").
+				AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithIdentity(identity), pqt.WithPrimaryKey())).
+				AddColumn(pqt.NewColumn("number", pqt.TypeInteger(), pqt.WithNotNull())).
+				AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+				AddColumn(pqt.NewColumn("slug", pqt.TypeText(), pqt.WithGenerated(expr))))
+	}
+
+	cases := map[string]struct {
+		old, cur *pqt.Schema
+		expected string
+	}{
+		"change": {
+			old: schema(pqt.IdentityByDefault, "lower(name)"),
+			cur: schema(pqt.IdentityAlways, "upper(name)"),
+			expected: `ALTER TABLE example."user" ALTER COLUMN id SET GENERATED ALWAYS;
+ALTER TABLE example."user" DROP COLUMN slug;
+ALTER TABLE example."user" ADD COLUMN slug TEXT GENERATED ALWAYS AS (upper(name)) STORED;
+`,
+		},
+		"add-and-drop": {
+			old: schema(pqt.IdentityByDefault, "lower(name)"),
+			cur: pqt.NewSchema("example").
+				AddTable(pqt.NewTable("user").
+					AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithNotNull(), pqt.WithPrimaryKey())).
+					AddColumn(pqt.NewColumn("number", pqt.TypeInteger(), pqt.WithIdentity(pqt.IdentityAlways))).
+					AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+					AddColumn(pqt.NewColumn("slug", pqt.TypeText()))),
+			expected: `ALTER TABLE example."user" ALTER COLUMN id DROP IDENTITY;
+ALTER TABLE example."user" ALTER COLUMN number ADD GENERATED ALWAYS AS IDENTITY;
+ALTER TABLE example."user" ALTER COLUMN slug DROP EXPRESSION;
+`,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			got, err := (&pqtsql.Generator{Version: 12}).GenerateMigration(c.old, c.cur)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			expected := "-- sql migration beginning\n-- do not modify, generated by pqt\n\n" + c.expected + "\n-- sql migration end\n"
+			if string(got) != expected {
+				t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateMigration_security(t *testing.T) {
+	schema := func(using string, rls bool, privileges ...pqt.Privilege) *pqt.Schema {
+		var opts []pqt.TableOption
+		if rls {
+			opts = append(opts, pqt.WithRowLevelSecurity())
+		}
+		account := pqt.NewTable("account", opts...).
+			AddColumn(pqt.NewColumn("tenant", pqt.TypeText())).
+			AddPolicy(pqt.NewPolicy("account_tenant", pqt.WithUsing(using))).
+			AddPolicy(pqt.NewPolicy("account_select", pqt.WithPolicyCommand(pqt.PolicyCommandSelect), pqt.WithUsing("true"))).
+			AddGrant(pqt.NewGrant("app", privileges...))
+		return pqt.NewSchema("example").
+			AddGrant(pqt.NewGrant("app", pqt.PrivilegeUsage)).
+			AddTable(account)
+	}
+
+	expected := `-- sql migration beginning
+-- do not modify, generated by pqt
+
+DROP POLICY account_tenant ON example.account;
+
+ALTER TABLE example.account ENABLE ROW LEVEL SECURITY;
+CREATE POLICY account_tenant ON example.account USING (tenant = current_user);
+REVOKE SELECT ON TABLE example.account FROM app;
+GRANT SELECT, UPDATE ON TABLE example.account TO app;
+
+-- sql migration end
+`
+
+	got, err := (&pqtsql.Generator{Version: 9.5}).GenerateMigration(
+		schema("true", false, pqt.PrivilegeSelect),
+		schema("tenant = current_user", true, pqt.PrivilegeSelect, pqt.PrivilegeUpdate),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if string(got) != expected {
+		t.Errorf("wrong migration, expected:\n'%s'\nbut got:\n'%s'", expected, got)
+	}
+}
@@ -0,0 +1,44 @@
+package pqtsql
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func generateCreatePartition(buf *bytes.Buffer, p *pqt.Partition) {
+	buf.WriteString("CREATE TABLE ")
+	if p.Parent.IfNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+	fmt.Fprintf(buf, "%s PARTITION OF %s %s;\n", p.FullName(), p.Parent.FullName(), partitionBound(p))
+}
+
+func partitionBound(p *pqt.Partition) string {
+	if p.Default {
+		return "DEFAULT"
+	}
+	return "FOR VALUES " + p.Bound
+}
+
+func dropPartitionQuery(buf *bytes.Buffer, p *pqt.Partition) {
+	fmt.Fprintf(buf, "DROP TABLE %s;\n", p.FullName())
+}
+
+// alterPartitionQuery changes bound of the partition without losing its content.
+func alterPartitionQuery(buf *bytes.Buffer, old, p *pqt.Partition) {
+	fmt.Fprintf(buf, "ALTER TABLE %s DETACH PARTITION %s;\n", old.Parent.FullName(), old.FullName())
+	fmt.Fprintf(buf, "ALTER TABLE %s ATTACH PARTITION %s %s;\n", p.Parent.FullName(), p.FullName(), partitionBound(p))
+}
+
+// partitionsByName maps partitions of all tables to their qualified names.
+func partitionsByName(s *pqt.Schema) map[string]*pqt.Partition {
+	partitions := make(map[string]*pqt.Partition)
+	for _, t := range s.Tables {
+		for _, p := range t.Partitions {
+			partitions[p.QualifiedName()] = p
+		}
+	}
+	return partitions
+}
@@ -0,0 +1,166 @@
+package pqtsql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+// tableSecurityQuery writes statements that enable or disable row level security,
+// create policies and give privileges that the old table is missing.
+// Old table can be nil if the table is created.
+// Policies that changed are expected to be already dropped.
+// It returns true if anything was written.
+func tableSecurityQuery(buf *bytes.Buffer, old, cur *pqt.Table) bool {
+	if old == nil {
+		old = &pqt.Table{}
+	}
+	var dirty bool
+	if old.EnableRowLevelSecurity != cur.EnableRowLevelSecurity {
+		if cur.EnableRowLevelSecurity {
+			fmt.Fprintf(buf, "ALTER TABLE %s ENABLE ROW LEVEL SECURITY;\n", cur.FullName())
+		} else {
+			fmt.Fprintf(buf, "ALTER TABLE %s DISABLE ROW LEVEL SECURITY;\n", cur.FullName())
+		}
+		dirty = true
+	}
+	if old.ForceRowLevelSecurity != cur.ForceRowLevelSecurity {
+		if cur.ForceRowLevelSecurity {
+			fmt.Fprintf(buf, "ALTER TABLE %s FORCE ROW LEVEL SECURITY;\n", cur.FullName())
+		} else {
+			fmt.Fprintf(buf, "ALTER TABLE %s NO FORCE ROW LEVEL SECURITY;\n", cur.FullName())
+		}
+		dirty = true
+	}
+
+	oldPolicies := policiesByName(old)
+	for _, p := range cur.Policies {
+		if op, ok := oldPolicies[p.Name]; ok && policyDefinition(op) == policyDefinition(p) {
+			continue
+		}
+		createPolicyQuery(buf, p)
+		dirty = true
+	}
+
+	if grantsQuery(buf, "TABLE "+cur.FullName(), old.Grants, cur.Grants) {
+		dirty = true
+	}
+	return dirty
+}
+
+// dropPoliciesQuery drops policies of the old table that disappeared or changed.
+// It returns true if anything was written.
+func dropPoliciesQuery(buf *bytes.Buffer, old, cur *pqt.Table) bool {
+	var dirty bool
+	curPolicies := policiesByName(cur)
+	for _, p := range old.Policies {
+		if cp, ok := curPolicies[p.Name]; ok && policyDefinition(cp) == policyDefinition(p) {
+			continue
+		}
+		fmt.Fprintf(buf, "DROP POLICY %s ON %s;\n", old.Schema.Quote(p.Name), old.FullName())
+		dirty = true
+	}
+	return dirty
+}
+
+func policiesByName(t *pqt.Table) map[string]*pqt.Policy {
+	policies := make(map[string]*pqt.Policy, len(t.Policies))
+	for _, p := range t.Policies {
+		policies[p.Name] = p
+	}
+	return policies
+}
+
+func createPolicyQuery(buf *bytes.Buffer, p *pqt.Policy) {
+	fmt.Fprintf(buf, "CREATE POLICY %s ON %s", p.Table.Schema.Quote(p.Name), p.Table.FullName())
+	if p.Restrictive {
+		buf.WriteString(" AS RESTRICTIVE")
+	}
+	if p.Command != "" {
+		fmt.Fprintf(buf, " FOR %s", p.Command)
+	}
+	if len(p.Roles) > 0 {
+		fmt.Fprintf(buf, " TO %s", strings.Join(p.Roles, ", "))
+	}
+	if p.Using != "" {
+		fmt.Fprintf(buf, " USING (%s)", p.Using)
+	}
+	if p.WithCheck != "" {
+		fmt.Fprintf(buf, " WITH CHECK (%s)", p.WithCheck)
+	}
+	buf.WriteString(";\n")
+}
+
+// policyDefinition returns rendered CREATE POLICY statement.
+// It is used to detect policies that kept the name but changed.
+func policyDefinition(p *pqt.Policy) string {
+	buf := bytes.NewBuffer(nil)
+	createPolicyQuery(buf, p)
+	return buf.String()
+}
+
+// grantsQuery revokes privileges that disappeared and gives those that are new.
+// Object is a kind of the object followed by its name, e.g. TABLE example.news.
+// It returns true if anything was written.
+func grantsQuery(buf *bytes.Buffer, object string, old, cur []*pqt.Grant) bool {
+	var dirty bool
+	curGrants := make(map[string]bool, len(cur))
+	for _, g := range cur {
+		curGrants[grantDefinition(object, g)] = true
+	}
+	oldGrants := make(map[string]bool, len(old))
+	for _, g := range old {
+		def := grantDefinition(object, g)
+		oldGrants[def] = true
+		if curGrants[def] {
+			continue
+		}
+		fmt.Fprintf(buf, "REVOKE %s ON %s FROM %s;\n", privilegesList(g), object, g.Role)
+		dirty = true
+	}
+	for _, g := range cur {
+		def := grantDefinition(object, g)
+		if oldGrants[def] {
+			continue
+		}
+		buf.WriteString(def)
+		dirty = true
+	}
+	return dirty
+}
+
+// grantDefinition returns rendered GRANT statement.
+func grantDefinition(object string, g *pqt.Grant) string {
+	def := fmt.Sprintf("GRANT %s ON %s TO %s", privilegesList(g), object, g.Role)
+	if g.GrantOption {
+		def += " WITH GRANT OPTION"
+	}
+	return def + ";\n"
+}
+
+func privilegesList(g *pqt.Grant) string {
+	privileges := make([]string, 0, len(g.Privileges))
+	for _, p := range g.Privileges {
+		if len(g.Columns) > 0 {
+			privileges = append(privileges, fmt.Sprintf("%s (%s)", p, pqt.JoinColumns(g.Columns, ", ")))
+			continue
+		}
+		privileges = append(privileges, string(p))
+	}
+	return strings.Join(privileges, ", ")
+}
+
+// schemaObject returns schema as an object privileges can be given on.
+func schemaObject(s *pqt.Schema) string {
+	if s.Name == "" {
+		return "SCHEMA " + pqt.DefaultSchema
+	}
+	return "SCHEMA " + s.QuotedName()
+}
+
+// functionObject returns function as an object privileges can be given on.
+func functionObject(s *pqt.Schema, f *pqt.Function) string {
+	return "FUNCTION " + functionSignature(s, f)
+}
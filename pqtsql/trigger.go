@@ -0,0 +1,72 @@
+package pqtsql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func (g *Generator) generateCreateTriggers(buf *bytes.Buffer, triggers []*pqt.Trigger) {
+	for _, tr := range triggers {
+		g.generateCreateTrigger(buf, tr)
+	}
+}
+
+func (g *Generator) generateCreateTrigger(buf *bytes.Buffer, tr *pqt.Trigger) {
+	fmt.Fprintf(buf, "CREATE TRIGGER %s %s ", tr.QuotedName(), tr.Timing)
+	for i, e := range tr.Events {
+		if i != 0 {
+			buf.WriteString(" OR ")
+		}
+		buf.WriteString(string(e))
+		if e == pqt.EventUpdate && len(tr.UpdateOf) > 0 {
+			fmt.Fprintf(buf, " OF %s", pqt.JoinQuotedColumns(tr.UpdateOf, ", "))
+		}
+	}
+	fmt.Fprintf(buf, " ON %s\n", tr.RelationFullName())
+	if tr.ForEachRow {
+		buf.WriteString("\tFOR EACH ROW\n")
+	} else {
+		buf.WriteString("\tFOR EACH STATEMENT\n")
+	}
+	if tr.When != "" {
+		fmt.Fprintf(buf, "\tWHEN (%s)\n", tr.When)
+	}
+	// EXECUTE FUNCTION is available since 11, older versions understand only EXECUTE PROCEDURE.
+	if g.Version >= 11 {
+		buf.WriteString("\tEXECUTE FUNCTION ")
+	} else {
+		buf.WriteString("\tEXECUTE PROCEDURE ")
+	}
+	args := make([]string, 0, len(tr.Args))
+	for _, arg := range tr.Args {
+		args = append(args, literal(arg))
+	}
+	fmt.Fprintf(buf, "%s(%s);\n\n", functionName(triggerSchema(tr), tr.Function), strings.Join(args, ", "))
+}
+
+// triggerSchema returns schema of the relation that trigger is attached to.
+func triggerSchema(tr *pqt.Trigger) *pqt.Schema {
+	switch {
+	case tr.Table != nil:
+		return tr.Table.Schema
+	case tr.View != nil:
+		return tr.View.Schema
+	default:
+		return nil
+	}
+}
+
+func dropTriggerQuery(buf *bytes.Buffer, tr *pqt.Trigger) {
+	fmt.Fprintf(buf, "DROP TRIGGER %s ON %s;\n", tr.QuotedName(), tr.RelationFullName())
+}
+
+// triggerDefinition returns rendered CREATE TRIGGER statement.
+// It is used to detect triggers that kept the name but changed.
+func (g *Generator) triggerDefinition(tr *pqt.Trigger) string {
+	buf := bytes.NewBuffer(nil)
+	g.generateCreateTrigger(buf, tr)
+	return buf.String()
+}
@@ -0,0 +1,252 @@
+package pqtsql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+// generateCreateType writes CREATE TYPE statement for types that need to be created upfront.
+// Other types are ignored.
+func generateCreateType(buf *bytes.Buffer, s *pqt.Schema, t pqt.Type) {
+	switch tt := t.(type) {
+	case pqt.EnumeratedType:
+		fmt.Fprintf(buf, "CREATE TYPE %s AS ENUM (", typeName(s, tt))
+		for i, e := range tt.Enums {
+			if i != 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(literal(e))
+		}
+		buf.WriteString(");\n\n")
+	case pqt.CompositeType:
+		fmt.Fprintf(buf, "CREATE TYPE %s AS (\n", typeName(s, tt))
+		for i, a := range tt.Attributes {
+			buf.WriteString("\t")
+			generateAttribute(buf, s, a)
+			if i < len(tt.Attributes)-1 {
+				buf.WriteRune(',')
+			}
+			buf.WriteRune('\n')
+		}
+		buf.WriteString(");\n\n")
+	case pqt.DomainType:
+		fmt.Fprintf(buf, "CREATE DOMAIN %s AS %s", typeName(s, tt), typeName(s, tt.Base))
+		if tt.Default != "" {
+			fmt.Fprintf(buf, " DEFAULT %s", tt.Default)
+		}
+		if tt.NotNull {
+			buf.WriteString(" NOT NULL")
+		}
+		for _, c := range tt.Checks {
+			fmt.Fprintf(buf, " CHECK (%s)", c)
+		}
+		buf.WriteString(";\n\n")
+	}
+}
+
+func generateAttribute(buf *bytes.Buffer, s *pqt.Schema, a *pqt.Attribute) {
+	buf.WriteString(s.Quote(a.Name))
+	buf.WriteRune(' ')
+	buf.WriteString(typeName(s, a.Type))
+	if a.Collate != "" {
+		buf.WriteString(" COLLATE ")
+		buf.WriteString(a.Collate)
+	}
+}
+
+// alterTypeQuery writes ALTER TYPE statements that transforms old type into the new one.
+// It returns true if anything was written.
+func alterTypeQuery(buf *bytes.Buffer, s *pqt.Schema, old, cur pqt.Type) (bool, error) {
+	switch ot := old.(type) {
+	case pqt.EnumeratedType:
+		ct, ok := cur.(pqt.EnumeratedType)
+		if !ok {
+			return false, fmt.Errorf("type %s cannot be changed from enumerated type", cur.String())
+		}
+		return alterEnumeratedTypeQuery(buf, s, ot, ct)
+	case pqt.CompositeType:
+		ct, ok := cur.(pqt.CompositeType)
+		if !ok {
+			return false, fmt.Errorf("type %s cannot be changed from composite type", cur.String())
+		}
+		return alterCompositeTypeQuery(buf, s, ot, ct), nil
+	case pqt.DomainType:
+		ct, ok := cur.(pqt.DomainType)
+		if !ok {
+			return false, fmt.Errorf("type %s cannot be changed from domain type", cur.String())
+		}
+		return alterDomainTypeQuery(buf, s, ot, ct)
+	}
+	return false, nil
+}
+
+func alterEnumeratedTypeQuery(buf *bytes.Buffer, s *pqt.Schema, old, cur pqt.EnumeratedType) (bool, error) {
+	existing := make(map[string]bool, len(old.Enums))
+	for _, e := range old.Enums {
+		existing[e] = true
+	}
+	for _, e := range old.Enums {
+		if !contains(cur.Enums, e) {
+			return false, fmt.Errorf("value %s cannot be removed from enumerated type %s", e, cur.String())
+		}
+	}
+
+	var dirty bool
+	for i, e := range cur.Enums {
+		if existing[e] {
+			continue
+		}
+		fmt.Fprintf(buf, "ALTER TYPE %s ADD VALUE %s", typeName(s, cur), literal(e))
+		if i > 0 {
+			fmt.Fprintf(buf, " AFTER %s", literal(cur.Enums[i-1]))
+		}
+		buf.WriteString(";\n")
+		dirty = true
+	}
+	return dirty, nil
+}
+
+func alterCompositeTypeQuery(buf *bytes.Buffer, s *pqt.Schema, old, cur pqt.CompositeType) bool {
+	var dirty bool
+
+	oldAttributes := make(map[string]*pqt.Attribute, len(old.Attributes))
+	for _, a := range old.Attributes {
+		oldAttributes[a.Name] = a
+	}
+	for _, a := range cur.Attributes {
+		oa, ok := oldAttributes[a.Name]
+		if !ok {
+			fmt.Fprintf(buf, "ALTER TYPE %s ADD ATTRIBUTE ", typeName(s, cur))
+			generateAttribute(buf, s, a)
+			buf.WriteString(";\n")
+			dirty = true
+			continue
+		}
+		delete(oldAttributes, a.Name)
+
+		if oa.Type.String() != a.Type.String() || oa.Collate != a.Collate {
+			fmt.Fprintf(buf, "ALTER TYPE %s ALTER ATTRIBUTE %s TYPE %s", typeName(s, cur), s.Quote(a.Name), typeName(s, a.Type))
+			if a.Collate != "" {
+				fmt.Fprintf(buf, " COLLATE %s", a.Collate)
+			}
+			buf.WriteString(";\n")
+			dirty = true
+		}
+	}
+	for _, a := range old.Attributes {
+		if _, ok := oldAttributes[a.Name]; !ok {
+			continue
+		}
+		fmt.Fprintf(buf, "ALTER TYPE %s DROP ATTRIBUTE %s;\n", typeName(s, cur), s.Quote(a.Name))
+		dirty = true
+	}
+	return dirty
+}
+
+func alterDomainTypeQuery(buf *bytes.Buffer, s *pqt.Schema, old, cur pqt.DomainType) (bool, error) {
+	if old.Base.String() != cur.Base.String() {
+		return false, fmt.Errorf("base type of domain %s cannot be changed", cur.String())
+	}
+	for _, c := range old.Checks {
+		if !contains(cur.Checks, c) {
+			return false, fmt.Errorf("check %s cannot be removed from domain %s", c, cur.String())
+		}
+	}
+
+	var dirty bool
+	if old.Default != cur.Default {
+		if cur.Default == "" {
+			fmt.Fprintf(buf, "ALTER DOMAIN %s DROP DEFAULT;\n", typeName(s, cur))
+		} else {
+			fmt.Fprintf(buf, "ALTER DOMAIN %s SET DEFAULT %s;\n", typeName(s, cur), cur.Default)
+		}
+		dirty = true
+	}
+	if old.NotNull != cur.NotNull {
+		if cur.NotNull {
+			fmt.Fprintf(buf, "ALTER DOMAIN %s SET NOT NULL;\n", typeName(s, cur))
+		} else {
+			fmt.Fprintf(buf, "ALTER DOMAIN %s DROP NOT NULL;\n", typeName(s, cur))
+		}
+		dirty = true
+	}
+	for _, c := range cur.Checks {
+		if contains(old.Checks, c) {
+			continue
+		}
+		fmt.Fprintf(buf, "ALTER DOMAIN %s ADD CHECK (%s);\n", typeName(s, cur), c)
+		dirty = true
+	}
+	return dirty, nil
+}
+
+// typeName returns name of the type as it should be used in SQL.
+// User defined types belong to the schema, so the name is qualified with the schema name.
+func typeName(s *pqt.Schema, t pqt.Type) string {
+	if at, ok := t.(pqt.ArrayType); ok && at.Elem != nil {
+		return typeName(s, at.Elem) + strings.TrimPrefix(at.String(), at.Elem.String())
+	}
+	if !userDefined(t) || s == nil || strings.Contains(t.String(), ".") {
+		return t.String()
+	}
+	s = typeSchema(s, t)
+	if s.Name == "" {
+		return s.Quote(t.String())
+	}
+	return s.QuotedName() + "." + s.Quote(t.String())
+}
+
+// typeSchema returns schema that declares given type.
+// If the schema is a part of a database, the type can be declared by any other schema of the database.
+func typeSchema(s *pqt.Schema, t pqt.Type) *pqt.Schema {
+	if s.Database == nil {
+		return s
+	}
+	for _, st := range s.Types {
+		if st.String() == t.String() {
+			return s
+		}
+	}
+	for _, ds := range s.Database.Schemas {
+		for _, st := range ds.Types {
+			if st.String() == t.String() {
+				return ds
+			}
+		}
+	}
+	return s
+}
+
+// userDefined returns true if type needs to be created using CREATE TYPE statement.
+func userDefined(t pqt.Type) bool {
+	switch t.(type) {
+	case pqt.EnumeratedType, pqt.CompositeType, pqt.DomainType:
+		return true
+	default:
+		return false
+	}
+}
+
+// columnType returns name of the column type as it should be used in SQL.
+func columnType(c *pqt.Column) string {
+	if c.Table == nil {
+		return c.Type.String()
+	}
+	return typeName(c.Table.Schema, c.Type)
+}
+
+func literal(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+func contains(slice []string, s string) bool {
+	for _, e := range slice {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package pqtsql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func generateCreateView(buf *bytes.Buffer, v *pqt.View) {
+	buf.WriteString("CREATE ")
+	if v.Materialized {
+		buf.WriteString("MATERIALIZED ")
+	}
+	fmt.Fprintf(buf, "VIEW %s (%s) AS\n", v.FullName(), viewColumns(v))
+	buf.WriteString(viewQuery(v))
+	buf.WriteString(";\n\n")
+}
+
+func dropViewQuery(buf *bytes.Buffer, v *pqt.View) {
+	buf.WriteString("DROP ")
+	if v.Materialized {
+		buf.WriteString("MATERIALIZED ")
+	}
+	fmt.Fprintf(buf, "VIEW %s;\n", v.FullName())
+}
+
+// viewColumns returns comma separated names of the view columns.
+// Columns of a view do not belong to any table, so they are quoted according to quoting policy of the view schema.
+func viewColumns(v *pqt.View) string {
+	names := make([]string, 0, len(v.Columns))
+	for _, c := range v.Columns {
+		names = append(names, v.Schema.Quote(c.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+// viewQuery returns defining query without trailing semicolon, so it can be embedded into CREATE VIEW statement.
+func viewQuery(v *pqt.View) string {
+	return strings.TrimRight(strings.TrimSpace(v.Query), "; \t\n")
+}
+
+// viewDefinition returns rendered CREATE VIEW statement.
+// It is used to detect views that kept the name but changed.
+func viewDefinition(v *pqt.View) string {
+	buf := bytes.NewBuffer(nil)
+	generateCreateView(buf, v)
+	return buf.String()
+}
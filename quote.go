@@ -0,0 +1,149 @@
+package pqt
+
+import (
+	"strings"
+)
+
+const (
+	// QuoteWhenNeeded quotes only identifiers that Postgres would not read back as they are:
+	// reserved keywords and names that contain anything else than lower case letters, digits, underscores and dollar signs.
+	QuoteWhenNeeded QuotingPolicy = iota
+	// QuoteAlways quotes every identifier.
+	QuoteAlways
+)
+
+// QuotingPolicy decides which identifiers are double quoted in generated SQL and queries.
+type QuotingPolicy int
+
+// Quote returns identifier quoted according to the policy.
+// Double quotes that are a part of the identifier are escaped.
+func (p QuotingPolicy) Quote(name string) string {
+	if name == "" || (p == QuoteWhenNeeded && !needsQuotes(name)) {
+		return name
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func needsQuotes(name string) bool {
+	if reservedKeywords[name] {
+		return true
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r == '_':
+		case (r >= '0' && r <= '9') || r == '$':
+			if i == 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// reservedKeywords are Postgres keywords that cannot be used as column or table names without quotes.
+// They are the reserved keywords and those that can be a function or type name.
+var reservedKeywords = map[string]bool{
+	"all":               true,
+	"analyse":           true,
+	"analyze":           true,
+	"and":               true,
+	"any":               true,
+	"array":             true,
+	"as":                true,
+	"asc":               true,
+	"asymmetric":        true,
+	"authorization":     true,
+	"binary":            true,
+	"both":              true,
+	"case":              true,
+	"cast":              true,
+	"check":             true,
+	"collate":           true,
+	"collation":         true,
+	"column":            true,
+	"concurrently":      true,
+	"constraint":        true,
+	"create":            true,
+	"cross":             true,
+	"current_catalog":   true,
+	"current_date":      true,
+	"current_role":      true,
+	"current_schema":    true,
+	"current_time":      true,
+	"current_timestamp": true,
+	"current_user":      true,
+	"default":           true,
+	"deferrable":        true,
+	"desc":              true,
+	"distinct":          true,
+	"do":                true,
+	"else":              true,
+	"end":               true,
+	"except":            true,
+	"false":             true,
+	"fetch":             true,
+	"for":               true,
+	"foreign":           true,
+	"freeze":            true,
+	"from":              true,
+	"full":              true,
+	"grant":             true,
+	"group":             true,
+	"having":            true,
+	"ilike":             true,
+	"in":                true,
+	"initially":         true,
+	"inner":             true,
+	"intersect":         true,
+	"into":              true,
+	"is":                true,
+	"isnull":            true,
+	"join":              true,
+	"lateral":           true,
+	"leading":           true,
+	"left":              true,
+	"like":              true,
+	"limit":             true,
+	"localtime":         true,
+	"localtimestamp":    true,
+	"natural":           true,
+	"not":               true,
+	"notnull":           true,
+	"null":              true,
+	"offset":            true,
+	"on":                true,
+	"only":              true,
+	"or":                true,
+	"order":             true,
+	"outer":             true,
+	"overlaps":          true,
+	"placing":           true,
+	"primary":           true,
+	"references":        true,
+	"returning":         true,
+	"right":             true,
+	"select":            true,
+	"session_user":      true,
+	"similar":           true,
+	"some":              true,
+	"symmetric":         true,
+	"system_user":       true,
+	"table":             true,
+	"tablesample":       true,
+	"then":              true,
+	"to":                true,
+	"trailing":          true,
+	"true":              true,
+	"union":             true,
+	"unique":            true,
+	"user":              true,
+	"using":             true,
+	"variadic":          true,
+	"verbose":           true,
+	"when":              true,
+	"where":             true,
+	"window":            true,
+	"with":              true,
+}
@@ -0,0 +1,60 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestQuotingPolicy_Quote(t *testing.T) {
+	cases := map[string]struct {
+		policy   pqt.QuotingPolicy
+		given    string
+		expected string
+	}{
+		"plain":           {policy: pqt.QuoteWhenNeeded, given: "user_id", expected: "user_id"},
+		"dollar":          {policy: pqt.QuoteWhenNeeded, given: "price$", expected: "price$"},
+		"unreserved":      {policy: pqt.QuoteWhenNeeded, given: "continue", expected: "continue"},
+		"reserved":        {policy: pqt.QuoteWhenNeeded, given: "user", expected: `"user"`},
+		"type-or-func":    {policy: pqt.QuoteWhenNeeded, given: "left", expected: `"left"`},
+		"mixed-case":      {policy: pqt.QuoteWhenNeeded, given: "FirstName", expected: `"FirstName"`},
+		"leading-digit":   {policy: pqt.QuoteWhenNeeded, given: "1st", expected: `"1st"`},
+		"special":         {policy: pqt.QuoteWhenNeeded, given: "first-name", expected: `"first-name"`},
+		"dot":             {policy: pqt.QuoteWhenNeeded, given: "public.user_id_pkey", expected: `"public.user_id_pkey"`},
+		"embedded-quote":  {policy: pqt.QuoteWhenNeeded, given: `a"b`, expected: `"a""b"`},
+		"empty":           {policy: pqt.QuoteWhenNeeded, given: "", expected: ""},
+		"always":          {policy: pqt.QuoteAlways, given: "user_id", expected: `"user_id"`},
+		"always-reserved": {policy: pqt.QuoteAlways, given: "order", expected: `"order"`},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			if got := c.policy.Quote(c.given); got != c.expected {
+				t.Errorf("wrong output, expected %s but got %s", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithQuotingPolicy(t *testing.T) {
+	id := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+	tbl := pqt.NewTable("news").AddColumn(id)
+	pqt.NewSchema("example", pqt.WithQuotingPolicy(pqt.QuoteAlways)).AddTable(tbl)
+
+	if got := tbl.FullName(); got != `"example"."news"` {
+		t.Errorf("wrong full name: %s", got)
+	}
+	if got := tbl.QualifiedName(); got != "example.news" {
+		t.Errorf("wrong qualified name: %s", got)
+	}
+	if got := id.QuotedName(); got != `"id"` {
+		t.Errorf("wrong column name: %s", got)
+	}
+	pk := pqt.PrimaryKey(tbl, id)
+	if got := pk.QuotedName(); got != `"example.news_id_pkey"` {
+		t.Errorf("wrong constraint name: %s", got)
+	}
+	if got := pk.Name(); got != "example.news_id_pkey" {
+		t.Errorf("constraint name should not be quoted, got: %s", got)
+	}
+}
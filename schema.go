@@ -1,7 +1,7 @@
 package pqt
 
 // Schema describes database schema.
-// It is a collection of tables, functions and types.
+// It is a collection of tables, views, functions and types.
 type Schema struct {
 	// Name is a schema name.
 	Name string
@@ -10,10 +10,26 @@ type Schema struct {
 	IfNotExists bool
 	// Tables is a collection of tables that schema contains.
 	Tables []*Table
+	// Views is a collection of views that schema contains.
+	Views []*View
 	// Functions is a collection of functions that schema contains.
 	Functions []*Function
 	// Types is a collection of types that schema contains.
 	Types []Type
+	// Grants is a collection of privileges given to roles on the schema.
+	Grants []*Grant
+	// Extensions is a collection of extensions that schema depends on.
+	// They are installed before any object of the schema is created.
+	Extensions []*Extension
+	// NamingStrategy decides how constraints and indexes of the schema are named.
+	// DefaultNamingStrategy is used if nil.
+	NamingStrategy NamingStrategy
+	// QuotingPolicy decides which identifiers of the schema are quoted, QuoteWhenNeeded by default.
+	QuotingPolicy QuotingPolicy
+	// ColumnOrder is an order of columns of tables that do not set their own, alphabetical by default.
+	ColumnOrder ColumnOrder
+	// Database references parent database, it is nil if schema is used on its own.
+	Database *Database
 }
 
 // NewSchema initializes new instance of Schema for given name and options.
@@ -36,10 +52,22 @@ func (s *Schema) AddTable(t *Table) *Schema {
 	}
 
 	t.Schema = s
+	t.inheritColumnOrder(s.ColumnOrder)
 	s.Tables = append(s.Tables, t)
 	return s
 }
 
+// AddView adds view to schema.
+func (s *Schema) AddView(v *View) *Schema {
+	if s.Views == nil {
+		s.Views = make([]*View, 0, 1)
+	}
+
+	v.Schema = s
+	s.Views = append(s.Views, v)
+	return s
+}
+
 // AddFunction adds function to schema.
 func (s *Schema) AddFunction(f *Function) *Schema {
 	if s.Functions == nil {
@@ -50,6 +78,28 @@ func (s *Schema) AddFunction(f *Function) *Schema {
 	return s
 }
 
+// AddType adds type to schema.
+func (s *Schema) AddType(t Type) *Schema {
+	if s.Types == nil {
+		s.Types = make([]Type, 0, 1)
+	}
+	s.Types = append(s.Types, t)
+
+	return s
+}
+
+// AddGrant gives privileges on the schema to a role.
+func (s *Schema) AddGrant(g *Grant) *Schema {
+	s.Grants = append(s.Grants, g)
+	return s
+}
+
+// AddExtension adds extension the schema depends on.
+func (s *Schema) AddExtension(e *Extension) *Schema {
+	s.Extensions = append(s.Extensions, e)
+	return s
+}
+
 // SchemaOption configures how we set up a schema.
 type SchemaOption func(*Schema)
 
@@ -59,3 +109,92 @@ func WithSchemaIfNotExists() SchemaOption {
 		s.IfNotExists = true
 	}
 }
+
+// WithSchemaExtension is schema option that adds extension of given name.
+func WithSchemaExtension(name string, opts ...ExtensionOption) SchemaOption {
+	return func(s *Schema) {
+		s.AddExtension(NewExtension(name, opts...))
+	}
+}
+
+// WithNamingStrategy is schema option that sets naming strategy of constraints and indexes.
+func WithNamingStrategy(ns NamingStrategy) SchemaOption {
+	return func(s *Schema) {
+		s.NamingStrategy = ns
+	}
+}
+
+// WithQuotingPolicy is schema option that sets quoting policy of identifiers.
+func WithQuotingPolicy(p QuotingPolicy) SchemaOption {
+	return func(s *Schema) {
+		s.QuotingPolicy = p
+	}
+}
+
+// WithDefaultColumnOrder is schema option that sets order of columns of tables that do not set their own.
+func WithDefaultColumnOrder(o ColumnOrder) SchemaOption {
+	return func(s *Schema) {
+		s.ColumnOrder = o
+	}
+}
+
+// Quote returns identifier quoted according to quoting policy of the schema.
+// It is safe to call on nil schema, QuoteWhenNeeded is used then.
+func (s *Schema) Quote(name string) string {
+	if s == nil {
+		return QuoteWhenNeeded.Quote(name)
+	}
+	return s.QuotingPolicy.Quote(name)
+}
+
+// ConstraintType returns type of the constraint of given name.
+// Constraints declared in the schema are recognized by their type, explicitly named ones included.
+// Other names are interpreted by naming strategy of the schema.
+// It is safe to call on nil schema, DefaultNamingStrategy is used then.
+func (s *Schema) ConstraintType(name string) ConstraintType {
+	if s != nil {
+		for _, t := range s.Tables {
+			for _, c := range t.Constraints {
+				if c.Name() == name {
+					return c.Type
+				}
+			}
+		}
+	}
+	return namingStrategy(s).ConstraintType(name)
+}
+
+// IsForeignKey returns true if constraint of given name is a foreign key.
+func (s *Schema) IsForeignKey(name string) bool {
+	return s.ConstraintType(name) == ConstraintTypeForeignKey
+}
+
+// IsUnique returns true if constraint of given name is a unique constraint.
+func (s *Schema) IsUnique(name string) bool {
+	return s.ConstraintType(name) == ConstraintTypeUnique
+}
+
+// IsPrimaryKey returns true if constraint of given name is a primary key.
+func (s *Schema) IsPrimaryKey(name string) bool {
+	return s.ConstraintType(name) == ConstraintTypePrimaryKey
+}
+
+// IsCheck returns true if constraint of given name is a check constraint.
+func (s *Schema) IsCheck(name string) bool {
+	return s.ConstraintType(name) == ConstraintTypeCheck
+}
+
+// IsExclusion returns true if constraint of given name is an exclusion constraint.
+func (s *Schema) IsExclusion(name string) bool {
+	return s.ConstraintType(name) == ConstraintTypeExclusion
+}
+
+// IsIndex returns true if constraint of given name is an index.
+func (s *Schema) IsIndex(name string) bool {
+	return s.ConstraintType(name) == ConstraintTypeIndex
+}
+
+// QuotedName returns name of the schema quoted according to its quoting policy.
+func (s *Schema) QuotedName() string {
+	return s.Quote(s.Name)
+}
@@ -29,3 +29,52 @@ func TestSchema_AddFunction(t *testing.T) {
 		t.Errorf("wrong number of functions: %d", len(tbl.Functions))
 	}
 }
+
+func TestSchema_AddView(t *testing.T) {
+	v := pqt.NewView("view", "SELECT 1")
+	sch := pqt.NewSchema("schema").AddView(v)
+	if v.Schema != sch {
+		t.Error("wrong schema assigned to the view")
+	}
+	if v.FullName() != "schema.view" {
+		t.Errorf("wrong full name: %s", v.FullName())
+	}
+}
+
+func TestWithSchemaExtension(t *testing.T) {
+	sch := pqt.NewSchema("schema", pqt.WithSchemaExtension("citext")).AddExtension(pqt.NewExtension("pgcrypto"))
+	if len(sch.Extensions) != 2 {
+		t.Fatalf("wrong number of extensions: %d", len(sch.Extensions))
+	}
+	if sch.Extensions[0].Name != "citext" || sch.Extensions[1].Name != "pgcrypto" {
+		t.Errorf("wrong extensions: %v, %v", sch.Extensions[0], sch.Extensions[1])
+	}
+}
+
+func TestWithDefaultColumnOrder(t *testing.T) {
+	cases := map[string]struct {
+		opts     []pqt.TableOption
+		position int
+		expected string
+	}{
+		"inherited":          {expected: "name,created_at,id,updated_at"},
+		"inherited-position": {position: -1, expected: "id,name,created_at,updated_at"},
+		"own":                {opts: []pqt.TableOption{pqt.WithColumnOrder(pqt.ColumnOrderAlphabetical)}, expected: "created_at,id,name,updated_at"},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			tbl := pqt.NewTable("test", c.opts...).
+				AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+				AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ())).
+				AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey(), pqt.WithPosition(c.position)))
+			pqt.NewSchema("schema", pqt.WithDefaultColumnOrder(pqt.ColumnOrderDeclared)).AddTable(tbl)
+			// Columns added later are ordered the same way.
+			tbl.AddColumn(pqt.NewColumn("updated_at", pqt.TypeTimestampTZ()))
+
+			if got := tbl.Columns.String(); got != c.expected {
+				t.Errorf("wrong order, expected %s but got %s", c.expected, got)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package pqt
 
 import (
-	"fmt"
 	"sort"
 )
 
@@ -13,7 +12,13 @@ type Table struct {
 	// Schema references parent schema.
 	Schema *Schema
 	// Columns is a collection of columns that table contains.
+	// They are kept sorted according to ColumnOrder.
 	Columns Columns
+	// ColumnOrder decides how columns are ordered in generated SQL and code.
+	// It has to be set before columns are added, unless it is set using WithColumnOrder it is inherited from the schema.
+	ColumnOrder ColumnOrder
+	// columnOrder if true means that ColumnOrder is set explicitly and is not inherited from the schema.
+	columnOrder bool
 	// Constraints is a collection of constraints that table contains.
 	Constraints Constraints
 	// OwnedRelationships is a collection of relationships that table owns.
@@ -22,6 +27,24 @@ type Table struct {
 	InversedRelationships []*Relationship
 	// ManyToManyRelationships is a collection of relationships that table is inversed in.
 	ManyToManyRelationships []*Relationship
+	// Triggers is a collection of triggers attached to the table.
+	Triggers []*Trigger
+	// PartitionBy if not empty means that table is partitioned using given strategy and PartitionKey columns.
+	PartitionBy  PartitionStrategy
+	PartitionKey Columns
+	// Partitions is a collection of child partitions of the table.
+	Partitions []*Partition
+	// Inherits is a collection of tables from which the table automatically inherits all columns.
+	Inherits []*Table
+	// Comment describes the table, it is stored in the database and used as a documentation of generated code.
+	Comment string
+	// EnableRowLevelSecurity if true means that rows are filtered using Policies.
+	// ForceRowLevelSecurity if true means that policies apply to the table owner as well.
+	EnableRowLevelSecurity, ForceRowLevelSecurity bool
+	// Policies is a collection of row level security policies of the table.
+	Policies []*Policy
+	// Grants is a collection of privileges given to roles on the table or its columns.
+	Grants []*Grant
 }
 
 // NewTable allocates new table using given name and options.
@@ -51,7 +74,18 @@ func SelfReference() *Table {
 }
 
 // FullName if schema is defined returns name in format <schema>.<name> or just <name> if not set.
+// Both parts are quoted according to quoting policy of the schema, so the name can be used in a query.
 func (t *Table) FullName() string {
+	if t.Schema != nil && t.Schema.Name != "" {
+		return t.Schema.QuotedName() + "." + t.Schema.Quote(t.Name)
+	}
+
+	return t.Schema.Quote(t.Name)
+}
+
+// QualifiedName works like FullName, but name is never quoted.
+// It identifies the table regardless of quoting policy.
+func (t *Table) QualifiedName() string {
 	if t.Schema != nil && t.Schema.Name != "" {
 		return t.Schema.Name + "." + t.Name
 	}
@@ -80,12 +114,17 @@ func (t *Table) AddColumn(c *Column) *Table {
 			OnDelete:       c.OnDelete,
 			OnUpdate:       c.OnUpdate,
 			Match:          c.Match,
+
+			DeferrableInitiallyDeferred:  c.DeferrableInitiallyDeferred,
+			DeferrableInitiallyImmediate: c.DeferrableInitiallyImmediate,
 		})
 		// When constraint is created, redundant data from column needs to be removed.
 		c.Reference = nil
 		c.OnDelete = 0
 		c.OnUpdate = 0
 		c.Match = 0
+		c.DeferrableInitiallyDeferred = false
+		c.DeferrableInitiallyImmediate = false
 	}
 
 	return t.addColumn(c)
@@ -97,13 +136,26 @@ func (t *Table) addColumn(c *Column) *Table {
 	}
 
 	c.Table = t
+	c.ordinal = len(t.Columns)
 	t.Columns = append(t.Columns, c)
 
 	t.Constraints = append(t.Constraints, c.Constraints()...)
-	sort.Sort(&t.Columns)
+	t.Columns.Sort(t.ColumnOrder)
 	return t
 }
 
+// inheritColumnOrder sets column order of the schema, unless the table has its own, and sorts columns again.
+func (t *Table) inheritColumnOrder(o ColumnOrder) {
+	if t.columnOrder || t.ColumnOrder == o {
+		return
+	}
+	t.ColumnOrder = o
+	sort.SliceStable(t.Columns, func(i, j int) bool {
+		return t.Columns[i].ordinal < t.Columns[j].ordinal
+	})
+	t.Columns.Sort(o)
+}
+
 // AddRelationship adds relationship to the table.
 func (t *Table) AddRelationship(r *Relationship, opts ...ColumnOption) *Table {
 	if r == nil {
@@ -135,6 +187,12 @@ func (t *Table) addRelationship(r *Relationship, opts ...ColumnOption) *Table {
 		r.InversedTable.InversedRelationships = append(r.InversedTable.InversedRelationships, r)
 	}
 
+	pks := r.InversedTable.PrimaryKeyColumns()
+	if len(pks) > 1 {
+		r.OwnerColumns = r.OwnerTable.addCompositeReference(columnPrefix(r.ColumnName, r.InversedTable), pks, opts)
+		r.InversedColumns = pks
+		return t
+	}
 	pk, ok := r.InversedTable.PrimaryKey()
 	if !ok {
 		return t
@@ -155,6 +213,31 @@ func (t *Table) addRelationship(r *Relationship, opts ...ColumnOption) *Table {
 	return t
 }
 
+// addCompositeReference adds columns that reference multi column primary key, together with the foreign key constraint.
+// Column names are made of the prefix and names of referenced columns.
+// On delete, on update, match and deferrable settings are taken from given options.
+func (t *Table) addCompositeReference(prefix string, pks Columns, opts []ColumnOption) Columns {
+	columns := make(Columns, 0, len(pks))
+	for _, pk := range pks {
+		col := NewColumn(prefix+"_"+pk.Name, fkType(pk.Type), opts...)
+		t.addColumn(col)
+		columns = append(columns, col)
+	}
+
+	fk := ForeignKey(columns, pks)
+	fk.OnDelete, fk.OnUpdate, fk.Match = columns[0].OnDelete, columns[0].OnUpdate, columns[0].Match
+	fk.DeferrableInitiallyDeferred = columns[0].DeferrableInitiallyDeferred
+	fk.DeferrableInitiallyImmediate = columns[0].DeferrableInitiallyImmediate
+	for _, col := range columns {
+		col.OnDelete, col.OnUpdate, col.Match = 0, 0, 0
+		col.DeferrableInitiallyDeferred, col.DeferrableInitiallyImmediate = false, false
+	}
+	t.AddConstraint(fk)
+	return columns
+}
+
+// addRelationshipManyToMany skips side of the relationship that has no primary key,
+// such schema is reported as invalid by Schema.Validate.
 func (t *Table) addRelationshipManyToMany(r *Relationship, opts ...ColumnOption) *Table {
 	r.ThroughTable = t
 	r.ThroughTable.OwnedRelationships = append(r.ThroughTable.OwnedRelationships, r)
@@ -174,12 +257,9 @@ func (t *Table) addRelationshipManyToMany(r *Relationship, opts ...ColumnOption)
 			r.ThroughTable.AddColumn(oc)
 			ownerColumns = append(ownerColumns, oc)
 		}
-	} else {
-		pk, ok := r.OwnerTable.PrimaryKey()
-		if !ok {
-			panic(fmt.Sprintf("missing owner table (%s) primary key for many to many relationship", r.OwnerTable.Name))
-		}
-
+	} else if pks := r.OwnerTable.PrimaryKeyColumns(); len(pks) > 1 {
+		ownerColumns = append(ownerColumns, r.ThroughTable.addCompositeReference(columnPrefix(r.ColumnName, r.OwnerTable), pks, opts)...)
+	} else if pk, ok := r.OwnerTable.PrimaryKey(); ok {
 		name := r.ColumnName
 		if name == "" {
 			name = r.OwnerTable.Name + "_" + pk.Name
@@ -199,11 +279,9 @@ func (t *Table) addRelationshipManyToMany(r *Relationship, opts ...ColumnOption)
 			r.ThroughTable.AddColumn(ic)
 			ownerColumns = append(ownerColumns, ic)
 		}
-	} else {
-		pk, ok := r.InversedTable.PrimaryKey()
-		if !ok {
-			panic(fmt.Sprintf("missing inversed table (%s) primary key for many to many relationship", r.InversedTable.Name))
-		}
+	} else if pks := r.InversedTable.PrimaryKeyColumns(); len(pks) > 1 {
+		inversedColumns = append(inversedColumns, r.ThroughTable.addCompositeReference(columnPrefix(r.ColumnName, r.InversedTable), pks, opts)...)
+	} else if pk, ok := r.InversedTable.PrimaryKey(); ok {
 		name := r.ColumnName
 		if name == "" {
 			name = r.InversedTable.Name + "_" + pk.Name
@@ -251,6 +329,33 @@ func (t *Table) AddUniqueIndex(methodSuffix, where string, columns ...*Column) *
 	return t.AddConstraint(UniqueIndex(t, methodSuffix, where, columns...))
 }
 
+// AddTrigger attaches trigger to the table.
+func (t *Table) AddTrigger(tr *Trigger) *Table {
+	tr.Table = t
+	t.Triggers = append(t.Triggers, tr)
+	return t
+}
+
+// AddPolicy adds row level security policy to the table.
+func (t *Table) AddPolicy(p *Policy) *Table {
+	p.Table = t
+	t.Policies = append(t.Policies, p)
+	return t
+}
+
+// AddGrant gives privileges on the table to a role.
+func (t *Table) AddGrant(g *Grant) *Table {
+	t.Grants = append(t.Grants, g)
+	return t
+}
+
+// AddExclusion is a shorthand for adding exclusion constraint with optional where clause.
+func (t *Table) AddExclusion(method, where string, elements ...*ExclusionElement) *Table {
+	c := Exclusion(t, method, elements...)
+	c.Where = where
+	return t.AddConstraint(c)
+}
+
 // SetIfNotExists sets IfNotExists flag.
 func (t *Table) SetIfNotExists(ine bool) *Table {
 	t.IfNotExists = ine
@@ -265,16 +370,79 @@ func (t *Table) SetSchema(s *Schema) *Table {
 }
 
 // PrimaryKey returns column that is primary key, or false if none.
+// It returns false as well if primary key consists of multiple columns, PrimaryKeyColumns covers such keys.
 func (t *Table) PrimaryKey() (*Column, bool) {
+	pks := t.PrimaryKeyColumns()
+	if len(pks) != 1 {
+		return nil, false
+	}
+
+	return pks[0], true
+}
+
+// PrimaryKeyColumns returns columns that primary key of the table consists of,
+// no matter if it is declared using column option or a constraint.
+// It returns nil if table has no primary key.
+func (t *Table) PrimaryKeyColumns() Columns {
+	for _, c := range t.Constraints {
+		if c.Type == ConstraintTypePrimaryKey && len(c.PrimaryColumns) > 0 {
+			return c.PrimaryColumns
+		}
+	}
 	for _, c := range t.Columns {
 		if c.PrimaryKey {
-			return c, true
+			return Columns{c}
 		}
 	}
 
-	return nil, false
+	return nil
 }
 
+// InheritedColumns returns columns of parent tables, including columns they inherit themselves.
+// Columns that the table redefines are skipped and columns inherited more than once are returned only once.
+func (t *Table) InheritedColumns() Columns {
+	names := make(map[string]bool, len(t.Columns))
+	for _, c := range t.Columns {
+		names[c.Name] = true
+	}
+	return t.inheritedColumns(names, map[*Table]bool{t: true})
+}
+
+// inheritedColumns walks up the inheritance tree, visited tables are skipped to not loop forever.
+func (t *Table) inheritedColumns(names map[string]bool, visited map[*Table]bool) Columns {
+	var columns Columns
+	for _, p := range t.Inherits {
+		if p == nil || visited[p] {
+			continue
+		}
+		visited[p] = true
+
+		// Parent definition takes precedence over the one it inherits.
+		var own Columns
+		for _, c := range p.Columns {
+			if names[c.Name] {
+				continue
+			}
+			names[c.Name] = true
+			own = append(own, c)
+		}
+		columns = append(columns, p.inheritedColumns(names, visited)...)
+		columns = append(columns, own...)
+	}
+
+	return columns
+}
+
+// ColumnOrder describes how columns of a table are ordered.
+type ColumnOrder int
+
+const (
+	// ColumnOrderAlphabetical orders columns by name.
+	ColumnOrderAlphabetical ColumnOrder = iota
+	// ColumnOrderDeclared keeps columns in order they were added to the table.
+	ColumnOrderDeclared
+)
+
 // TableOption configures how we set up the table.
 type TableOption func(*Table)
 
@@ -303,6 +471,44 @@ func WithTableSpace(s string) TableOption {
 	}
 }
 
+// WithInherits pass tables from which the new table automatically inherits all columns.
+// Check constraints that are not marked as NoInherit are inherited as well.
+func WithInherits(parents ...*Table) TableOption {
+	return func(t *Table) {
+		t.Inherits = append(t.Inherits, parents...)
+	}
+}
+
+// WithTableComment pass the comment that describes the table.
+func WithTableComment(s string) TableOption {
+	return func(t *Table) {
+		t.Comment = s
+	}
+}
+
+// WithRowLevelSecurity enables row level security, rows are filtered using policies of the table.
+func WithRowLevelSecurity() TableOption {
+	return func(t *Table) {
+		t.EnableRowLevelSecurity = true
+	}
+}
+
+// WithForceRowLevelSecurity enables row level security and applies it to the table owner as well.
+func WithForceRowLevelSecurity() TableOption {
+	return func(t *Table) {
+		t.EnableRowLevelSecurity = true
+		t.ForceRowLevelSecurity = true
+	}
+}
+
+// WithColumnOrder pass the order in which columns of the table are kept.
+func WithColumnOrder(o ColumnOrder) TableOption {
+	return func(t *Table) {
+		t.ColumnOrder = o
+		t.columnOrder = true
+	}
+}
+
 // WithTableShortName pass the short name of the table.
 func WithTableShortName(s string) TableOption {
 	return func(t *Table) {
@@ -310,6 +516,15 @@ func WithTableShortName(s string) TableOption {
 	}
 }
 
+// columnPrefix returns prefix of columns that reference multi column primary key of given table.
+// Column name of the relationship takes precedence over the table name.
+func columnPrefix(name string, t *Table) string {
+	if name != "" {
+		return name
+	}
+	return t.Name
+}
+
 func fkType(t Type) Type {
 	switch t {
 	case TypeSerial():
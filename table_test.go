@@ -83,6 +83,32 @@ func TestTable_AddColumn(t *testing.T) {
 	}
 }
 
+func TestTable_AddColumn_columnOrder(t *testing.T) {
+	cases := map[string]struct {
+		opts     []pqt.TableOption
+		position int
+		expected string
+	}{
+		"alphabetical":          {expected: "created_at,id,name"},
+		"alphabetical-position": {position: -1, expected: "id,created_at,name"},
+		"declared":              {opts: []pqt.TableOption{pqt.WithColumnOrder(pqt.ColumnOrderDeclared)}, expected: "name,created_at,id"},
+		"declared-position":     {opts: []pqt.TableOption{pqt.WithColumnOrder(pqt.ColumnOrderDeclared)}, position: -1, expected: "id,name,created_at"},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			tbl := pqt.NewTable("test", c.opts...).
+				AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+				AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ())).
+				AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey(), pqt.WithPosition(c.position)))
+
+			if got := tbl.Columns.String(); got != c.expected {
+				t.Errorf("wrong order, expected %s but got %s", c.expected, got)
+			}
+		})
+	}
+}
+
 func TestTable_AddConstraint(t *testing.T) {
 	tbl := pqt.Table{}
 	idx := pqt.Constraint{
@@ -282,14 +308,66 @@ func TestTable_AddRelationship_manyToMany(t *testing.T) {
 	}
 }
 
+func TestTable_AddRelationship_manyToOneComposite(t *testing.T) {
+	userID := pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithNotNull())
+	roleID := pqt.NewColumn("role_id", pqt.TypeIntegerBig(), pqt.WithNotNull())
+	membership := pqt.NewTable("membership").AddColumn(userID).AddColumn(roleID)
+	membership.AddConstraint(pqt.PrimaryKey(membership, userID, roleID))
+
+	grant := pqt.NewTable("grant").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())).
+		AddRelationship(pqt.ManyToOne(membership), pqt.WithOnDelete(pqt.Cascade))
+
+	r := grant.OwnedRelationships[0]
+	if len(r.OwnerColumns) != 2 {
+		t.Fatalf("relationship should have 2 owner columns, but has %d", len(r.OwnerColumns))
+	}
+	for i, name := range []string{"membership_user_id", "membership_role_id"} {
+		if r.OwnerColumns[i].Name != name {
+			t.Errorf("%d: wrong owner column name, expected %s but got %s", i, name, r.OwnerColumns[i].Name)
+		}
+		if r.OwnerColumns[i].Reference != nil {
+			t.Errorf("%d: owner column should not reference single column", i)
+		}
+	}
+
+	var fk *pqt.Constraint
+	for _, c := range grant.Constraints {
+		if c.Type == pqt.ConstraintTypeForeignKey {
+			fk = c
+		}
+	}
+	if fk == nil {
+		t.Fatal("foreign key constraint expected")
+	}
+	if len(fk.PrimaryColumns) != 2 || len(fk.Columns) != 2 {
+		t.Errorf("foreign key should consist of 2 columns")
+	}
+	if fk.OnDelete != pqt.Cascade {
+		t.Errorf("foreign key should cascade on delete")
+	}
+	if r.OwnerColumns[0].OnDelete != 0 {
+		t.Errorf("on delete action should be moved from column to the constraint")
+	}
+}
+
 func TestTable_FullName(t *testing.T) {
 	tbl := pqt.NewTable("table")
-	if tbl.FullName() != "table" {
+	if tbl.FullName() != `"table"` {
 		t.Errorf("wrong full name: %s", tbl.FullName())
 	}
 
-	pqt.NewSchema("schema").AddTable(tbl)
-	if tbl.FullName() != "schema.table" {
+	s := pqt.NewSchema("schema")
+	s.AddTable(tbl)
+	if tbl.FullName() != `schema."table"` {
+		t.Errorf("wrong full name: %s", tbl.FullName())
+	}
+	if tbl.QualifiedName() != "schema.table" {
+		t.Errorf("wrong qualified name: %s", tbl.QualifiedName())
+	}
+
+	s.QuotingPolicy = pqt.QuoteAlways
+	if tbl.FullName() != `"schema"."table"` {
 		t.Errorf("wrong full name: %s", tbl.FullName())
 	}
 }
@@ -338,3 +416,58 @@ func TestTable_AddIndex(t *testing.T) {
 		t.Errorf("wrong number of index constraints: %d", got)
 	}
 }
+
+func TestTable_InheritedColumns(t *testing.T) {
+	base := pqt.NewTable("base").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig())).
+		AddColumn(pqt.NewColumn("name", pqt.TypeText()))
+	entry := pqt.NewTable("entry", pqt.WithInherits(base)).
+		AddColumn(pqt.NewColumn("name", pqt.TypeText(), pqt.WithNotNull())).
+		AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ()))
+	tag := pqt.NewTable("tag").
+		AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig())).
+		AddColumn(pqt.NewColumn("label", pqt.TypeText()))
+	audit := pqt.NewTable("audit", pqt.WithInherits(entry, tag)).
+		AddColumn(pqt.NewColumn("created_at", pqt.TypeTimestampTZ()))
+
+	got := audit.InheritedColumns()
+	expected := []string{"base.id", "entry.name", "tag.label"}
+	if len(got) != len(expected) {
+		t.Fatalf("wrong number of columns, expected %d but got %d", len(expected), len(got))
+	}
+	for i, c := range got {
+		if name := c.Table.Name + "." + c.Name; name != expected[i] {
+			t.Errorf("%d: wrong column, expected %s but got %s", i, expected[i], name)
+		}
+	}
+
+	base.Inherits = append(base.Inherits, audit)
+	if len(base.InheritedColumns()) != 2 {
+		t.Errorf("inheritance cycle should not loop forever")
+	}
+}
+
+func TestTable_PrimaryKeyColumns(t *testing.T) {
+	single := pqt.NewTable("single").AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey()))
+	if got := single.PrimaryKeyColumns(); len(got) != 1 || got[0].Name != "id" {
+		t.Errorf("wrong primary key columns: %v", got)
+	}
+	if _, ok := single.PrimaryKey(); !ok {
+		t.Errorf("single column primary key expected")
+	}
+
+	a := pqt.NewColumn("a", pqt.TypeInteger())
+	b := pqt.NewColumn("b", pqt.TypeInteger())
+	composite := pqt.NewTable("composite").AddColumn(a).AddColumn(b)
+	composite.AddConstraint(pqt.PrimaryKey(composite, a, b))
+	if got := composite.PrimaryKeyColumns(); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("wrong primary key columns: %v", got)
+	}
+	if _, ok := composite.PrimaryKey(); ok {
+		t.Errorf("multi column primary key should not be returned as a single column")
+	}
+
+	if got := pqt.NewTable("none").PrimaryKeyColumns(); got != nil {
+		t.Errorf("table without primary key should return nil, got: %v", got)
+	}
+}
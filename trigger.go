@@ -0,0 +1,137 @@
+package pqt
+
+const (
+	// TriggerTimingBefore fires trigger before the operation is attempted.
+	TriggerTimingBefore TriggerTiming = iota
+	// TriggerTimingAfter fires trigger after the operation has completed.
+	TriggerTimingAfter
+	// TriggerTimingInsteadOf fires trigger instead of the operation. It can be used only by views.
+	TriggerTimingInsteadOf
+)
+
+// TriggerTiming determines when trigger is fired in relation to the event.
+type TriggerTiming int
+
+// String implements Stringer interface.
+func (tt TriggerTiming) String() string {
+	switch tt {
+	case TriggerTimingBefore:
+		return "BEFORE"
+	case TriggerTimingAfter:
+		return "AFTER"
+	case TriggerTimingInsteadOf:
+		return "INSTEAD OF"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Trigger describes database trigger that executes a function when certain event occurs on a table or a view.
+type Trigger struct {
+	Name   string
+	Timing TriggerTiming
+	// Events is a collection of events trigger fires on, e.g. EventInsert or EventDelete.
+	Events []Event
+	// UpdateOf restricts EventUpdate to updates of given columns.
+	UpdateOf Columns
+	// ForEachRow if true means that trigger is fired once for every modified row, otherwise once per statement.
+	ForEachRow bool
+	// When is a condition that determines whether the trigger function will actually be executed.
+	When string
+	// Function is a function that trigger executes, it has to return TypeTrigger.
+	Function *Function
+	// Args are literal arguments passed to the function.
+	Args []string
+	// Table or View references relation that trigger is attached to.
+	Table *Table
+	View  *View
+}
+
+// NewTrigger allocates new trigger that executes given function.
+func NewTrigger(name string, timing TriggerTiming, f *Function, opts ...TriggerOption) *Trigger {
+	tr := &Trigger{
+		Name:     name,
+		Timing:   timing,
+		Function: f,
+	}
+
+	for _, opt := range opts {
+		opt(tr)
+	}
+
+	return tr
+}
+
+// RelationName returns qualified name of the table or view that trigger is attached to.
+func (tr *Trigger) RelationName() string {
+	switch {
+	case tr.Table != nil:
+		return tr.Table.QualifiedName()
+	case tr.View != nil:
+		return tr.View.QualifiedName()
+	default:
+		return ""
+	}
+}
+
+// RelationFullName works like RelationName, but name is quoted according to quoting policy of the schema.
+func (tr *Trigger) RelationFullName() string {
+	switch {
+	case tr.Table != nil:
+		return tr.Table.FullName()
+	case tr.View != nil:
+		return tr.View.FullName()
+	default:
+		return ""
+	}
+}
+
+// QuotedName returns name of the trigger quoted according to quoting policy of the schema.
+func (tr *Trigger) QuotedName() string {
+	switch {
+	case tr.Table != nil:
+		return tr.Table.Schema.Quote(tr.Name)
+	case tr.View != nil:
+		return tr.View.Schema.Quote(tr.Name)
+	default:
+		return QuoteWhenNeeded.Quote(tr.Name)
+	}
+}
+
+// TriggerOption configures how we set up a trigger.
+type TriggerOption func(*Trigger)
+
+// WithTriggerEvents is trigger option that sets events trigger fires on.
+func WithTriggerEvents(events ...Event) TriggerOption {
+	return func(tr *Trigger) {
+		tr.Events = append(tr.Events, events...)
+	}
+}
+
+// WithUpdateOf is trigger option that restricts update event to given columns.
+func WithUpdateOf(columns ...*Column) TriggerOption {
+	return func(tr *Trigger) {
+		tr.UpdateOf = append(tr.UpdateOf, columns...)
+	}
+}
+
+// WithForEachRow is trigger option that makes trigger fire for every modified row.
+func WithForEachRow() TriggerOption {
+	return func(tr *Trigger) {
+		tr.ForEachRow = true
+	}
+}
+
+// WithWhen is trigger option that sets condition trigger is executed on.
+func WithWhen(condition string) TriggerOption {
+	return func(tr *Trigger) {
+		tr.When = condition
+	}
+}
+
+// WithTriggerArgs is trigger option that sets arguments passed to the trigger function.
+func WithTriggerArgs(args ...string) TriggerOption {
+	return func(tr *Trigger) {
+		tr.Args = append(tr.Args, args...)
+	}
+}
@@ -0,0 +1,69 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestNewTrigger(t *testing.T) {
+	f := &pqt.Function{Name: "touch", Type: pqt.TypeTrigger()}
+	name := pqt.NewColumn("name", pqt.TypeText())
+	tr := pqt.NewTrigger("touch", pqt.TriggerTimingBefore, f,
+		pqt.WithTriggerEvents(pqt.EventInsert, pqt.EventUpdate),
+		pqt.WithUpdateOf(name),
+		pqt.WithForEachRow(),
+		pqt.WithWhen("NEW.name IS NOT NULL"),
+		pqt.WithTriggerArgs("a", "b"),
+	)
+
+	if len(tr.Events) != 2 {
+		t.Errorf("wrong number of events: %d", len(tr.Events))
+	}
+	if len(tr.UpdateOf) != 1 || tr.UpdateOf[0] != name {
+		t.Errorf("wrong update columns: %s", tr.UpdateOf.String())
+	}
+	if !tr.ForEachRow {
+		t.Error("trigger should have field for each row set to true")
+	}
+	if tr.When != "NEW.name IS NOT NULL" {
+		t.Errorf("wrong condition: %s", tr.When)
+	}
+	if len(tr.Args) != 2 {
+		t.Errorf("wrong number of arguments: %d", len(tr.Args))
+	}
+}
+
+func TestTrigger_RelationName(t *testing.T) {
+	f := &pqt.Function{Name: "touch", Type: pqt.TypeTrigger()}
+	s := pqt.NewSchema("example")
+	tbl := pqt.NewTable("user")
+	view := pqt.NewView("user_view", "SELECT 1")
+	s.AddTable(tbl).AddView(view)
+
+	tr1 := pqt.NewTrigger("t1", pqt.TriggerTimingBefore, f)
+	tr2 := pqt.NewTrigger("t2", pqt.TriggerTimingInsteadOf, f)
+	tbl.AddTrigger(tr1)
+	view.AddTrigger(tr2)
+
+	if tr1.RelationName() != "example.user" {
+		t.Errorf("wrong relation name: %s", tr1.RelationName())
+	}
+	if tr2.RelationName() != "example.user_view" {
+		t.Errorf("wrong relation name: %s", tr2.RelationName())
+	}
+}
+
+func TestTriggerTiming_String(t *testing.T) {
+	cases := map[pqt.TriggerTiming]string{
+		pqt.TriggerTimingBefore:    "BEFORE",
+		pqt.TriggerTimingAfter:     "AFTER",
+		pqt.TriggerTimingInsteadOf: "INSTEAD OF",
+		pqt.TriggerTiming(100):     "UNKNOWN",
+	}
+	for tt, expected := range cases {
+		if tt.String() != expected {
+			t.Errorf("wrong string, expected %s but got %s", expected, tt.String())
+		}
+	}
+}
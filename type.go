@@ -1,6 +1,9 @@
 package pqt
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Type is a common interface that needs to be implemented so a type can be considered the Type in PQT sense.
 type Type interface {
@@ -24,6 +27,12 @@ func (bt BaseType) Fingerprint() string {
 	return fmt.Sprintf("base: %s", bt.name)
 }
 
+// TypeBase allocates BaseType with given name.
+// It allows to express types that are not covered by dedicated constructors.
+func TypeBase(name string) BaseType {
+	return BaseType{name: name}
+}
+
 // TypeDecimal ...
 func TypeDecimal(precision, scale int) BaseType {
 	switch {
@@ -208,11 +217,151 @@ func TypeJSONB() BaseType {
 	return BaseType{name: "JSONB"}
 }
 
+// TypeInterval is a time span, e.g. 1 day 02:00:00.
+func TypeInterval() BaseType {
+	return BaseType{name: "INTERVAL"}
+}
+
+// TypeTime is a time of day (no date, no time zone).
+func TypeTime() BaseType {
+	return BaseType{name: "TIME"}
+}
+
+// TypeTimeTZ is a time of day, including time zone.
+func TypeTimeTZ() BaseType {
+	return BaseType{name: "TIMETZ"}
+}
+
+// TypeInet holds an IPv4 or IPv6 host address, and optionally its subnet.
+func TypeInet() BaseType {
+	return BaseType{name: "INET"}
+}
+
+// TypeCIDR holds an IPv4 or IPv6 network specification.
+// In compare to TypeInet it does not accept values that have nonzero bits to the right of the netmask.
+func TypeCIDR() BaseType {
+	return BaseType{name: "CIDR"}
+}
+
+// TypeMacAddr stores MAC addresses, known for example from Ethernet card hardware addresses.
+func TypeMacAddr() BaseType {
+	return BaseType{name: "MACADDR"}
+}
+
+// TypeTSVector represents a document in a form optimized for text search.
+func TypeTSVector() BaseType {
+	return BaseType{name: "TSVECTOR"}
+}
+
+// TypeMoney stores a currency amount with a fixed fractional precision.
+// Output format depends on the lc_monetary setting of the database.
+func TypeMoney() BaseType {
+	return BaseType{name: "MONEY"}
+}
+
+// TypeXML stores XML data.
+func TypeXML() BaseType {
+	return BaseType{name: "XML"}
+}
+
+// TypeBit is a fixed-length bit string.
+func TypeBit(l int) BaseType {
+	if l == 0 {
+		return BaseType{name: "BIT"}
+	}
+	return BaseType{name: fmt.Sprintf("BIT(%d)", l)}
+}
+
+// TypeVarbit is a variable-length bit string with optional maximum length.
+func TypeVarbit(l int) BaseType {
+	if l == 0 {
+		return BaseType{name: "VARBIT"}
+	}
+	return BaseType{name: fmt.Sprintf("VARBIT(%d)", l)}
+}
+
+// TypeCIText is case-insensitive character string.
+// It is provided by citext extension.
+func TypeCIText() BaseType {
+	return BaseType{name: "CITEXT"}
+}
+
+// TypeHstore stores sets of key/value pairs within a single value.
+// It is provided by hstore extension.
+func TypeHstore() BaseType {
+	return BaseType{name: "HSTORE"}
+}
+
+// TypeInt4Range is a range of integer.
+func TypeInt4Range() BaseType {
+	return BaseType{name: "INT4RANGE"}
+}
+
+// TypeInt8Range is a range of bigint.
+func TypeInt8Range() BaseType {
+	return BaseType{name: "INT8RANGE"}
+}
+
+// TypeNumRange is a range of numeric.
+func TypeNumRange() BaseType {
+	return BaseType{name: "NUMRANGE"}
+}
+
+// TypeTSRange is a range of timestamp without time zone.
+func TypeTSRange() BaseType {
+	return BaseType{name: "TSRANGE"}
+}
+
+// TypeTSTZRange is a range of timestamp with time zone.
+func TypeTSTZRange() BaseType {
+	return BaseType{name: "TSTZRANGE"}
+}
+
+// TypeDateRange is a range of date.
+func TypeDateRange() BaseType {
+	return BaseType{name: "DATERANGE"}
+}
+
+// TypePoint is a point on a plane.
+func TypePoint() BaseType {
+	return BaseType{name: "POINT"}
+}
+
+// TypeLine is an infinite line.
+func TypeLine() BaseType {
+	return BaseType{name: "LINE"}
+}
+
+// TypeLineSegment is a finite line segment.
+func TypeLineSegment() BaseType {
+	return BaseType{name: "LSEG"}
+}
+
+// TypeBox is a rectangular box.
+func TypeBox() BaseType {
+	return BaseType{name: "BOX"}
+}
+
+// TypePath is a closed or open path made of points.
+func TypePath() BaseType {
+	return BaseType{name: "PATH"}
+}
+
+// TypePolygon is a polygon, similar to closed path.
+func TypePolygon() BaseType {
+	return BaseType{name: "POLYGON"}
+}
+
+// TypeCircle is a circle.
+func TypeCircle() BaseType {
+	return BaseType{name: "CIRCLE"}
+}
+
 // CompositeType represents the structure of a row or record.
 // It is essentially just a list of field names and their data types.
 // PostgreSQL allows composite types to be used in many of the same ways that simple types can be used.
 // For example, a column of a table can be declared to be of a composite type.
-// EXPERIMENTAL
+// To be created, it needs to be added to the schema using Schema.AddType.
 type CompositeType struct {
 	name       string
 	Attributes []*Attribute
@@ -220,12 +369,25 @@ type CompositeType struct {
 
 // String implements Stringer interface.
 func (ct CompositeType) String() string {
-	return "" // TODO: ?
+	return ct.name
+}
+
+// Name returns name of the type.
+func (ct CompositeType) Name() string {
+	return ct.name
 }
 
 // Fingerprint implements Type interface.
 func (ct CompositeType) Fingerprint() string {
-	return fmt.Sprintf("composite: %v", ct)
+	tmp := make([]string, 0, len(ct.Attributes))
+	for _, a := range ct.Attributes {
+		if a.Type == nil {
+			tmp = append(tmp, a.Name)
+			continue
+		}
+		tmp = append(tmp, a.Name+" "+a.Type.Fingerprint())
+	}
+	return fmt.Sprintf("composite: %s(%s)", ct.name, strings.Join(tmp, ", "))
 }
 
 // TypeComposite allocates CompositeType with given name and attributes.
@@ -237,7 +399,7 @@ func TypeComposite(name string, attributes ...*Attribute) CompositeType {
 }
 
 // EnumeratedType is a data type consisting of a set of named values called elements, members, enumeral, or enumerators of the type.
-// EXPERIMENTAL
+// To be created, it needs to be added to the schema using Schema.AddType.
 type EnumeratedType struct {
 	name  string
 	Enums []string
@@ -261,6 +423,68 @@ func TypeEnumerated(name string, enums ...string) EnumeratedType {
 	}
 }
 
+// DomainType is a user defined type that is based on another, underlying type.
+// It can have constraints that restrict valid values to a subset of what the underlying type would allow.
+// To be created, it needs to be added to the schema using Schema.AddType.
+type DomainType struct {
+	name string
+	Base Type
+	// Default is an expression used by columns of the domain type that do not have a default value on their own.
+	Default string
+	NotNull bool
+	// Checks are expressions that each value of the domain has to satisfy.
+	// The value being checked is referred to as VALUE.
+	Checks []string
+}
+
+// String implements Stringer interface.
+func (dt DomainType) String() string {
+	return dt.name
+}
+
+// Fingerprint implements Type interface.
+func (dt DomainType) Fingerprint() string {
+	return fmt.Sprintf("domain: %s", dt.name)
+}
+
+// TypeDomain allocates DomainType with given name, underlying type and options.
+func TypeDomain(name string, base Type, opts ...DomainOption) DomainType {
+	dt := DomainType{
+		name: name,
+		Base: base,
+	}
+
+	for _, opt := range opts {
+		opt(&dt)
+	}
+
+	return dt
+}
+
+// DomainOption configures how we set up a domain type.
+type DomainOption func(*DomainType)
+
+// WithDomainDefault is domain option that sets default value.
+func WithDomainDefault(d string) DomainOption {
+	return func(dt *DomainType) {
+		dt.Default = d
+	}
+}
+
+// WithDomainNotNull is domain option that prevents the domain from accepting NULL.
+func WithDomainNotNull() DomainOption {
+	return func(dt *DomainType) {
+		dt.NotNull = true
+	}
+}
+
+// WithDomainCheck is domain option that adds check expression, e.g. VALUE > 0.
+func WithDomainCheck(check string) DomainOption {
+	return func(dt *DomainType) {
+		dt.Checks = append(dt.Checks, check)
+	}
+}
+
 // PseudoType ...
 // EXPERIMENTAL
 type PseudoType struct {
@@ -285,6 +509,11 @@ func TypePseudo(name string) PseudoType {
 	}
 }
 
+// TypeTrigger returns pseudo type returned by trigger functions.
+func TypeTrigger() PseudoType {
+	return TypePseudo("TRIGGER")
+}
+
 // MappableType is a type that can be mapped to other types.
 // It allows
 type MappableType struct {
@@ -309,3 +538,83 @@ func TypeMappable(from Type, mapping ...Type) MappableType {
 		Mapping: mapping,
 	}
 }
+
+// MaxArrayDimensions is the maximum number of dimensions of an array supported by PostgreSQL.
+const MaxArrayDimensions = 6
+
+// ArrayType is a variable-length, possibly multidimensional array of elements of the given type.
+// Unlike dedicated array constructors like TypeIntegerArray, it keeps the element type,
+// so any type, including user defined ones, can be used as an element.
+type ArrayType struct {
+	Elem Type
+	// Dims is a number of dimensions, zero means a single dimension.
+	Dims int
+	// Size holds declared size of each dimension, zero means that the size is not specified.
+	Size [MaxArrayDimensions]int
+}
+
+// String implements Stringer interface.
+func (at ArrayType) String() string {
+	if at.Elem == nil {
+		return ""
+	}
+	return at.Elem.String() + at.suffix()
+}
+
+// Fingerprint implements Type interface.
+func (at ArrayType) Fingerprint() string {
+	if at.Elem == nil {
+		return fmt.Sprintf("array: %s", at.suffix())
+	}
+	return fmt.Sprintf("array: %s%s", at.Elem.Fingerprint(), at.suffix())
+}
+
+// Dimensions returns number of dimensions of the array.
+func (at ArrayType) Dimensions() int {
+	if at.Dims == 0 {
+		return 1
+	}
+	return at.Dims
+}
+
+// Sizes returns declared size of each dimension, zero means that the size is not specified.
+func (at ArrayType) Sizes() []int {
+	n := at.Dimensions()
+	if n > MaxArrayDimensions {
+		n = MaxArrayDimensions
+	}
+	sizes := make([]int, n)
+	copy(sizes, at.Size[:n])
+	return sizes
+}
+
+func (at ArrayType) suffix() string {
+	var b strings.Builder
+	for _, d := range at.Sizes() {
+		if d == 0 {
+			b.WriteString("[]")
+			continue
+		}
+		fmt.Fprintf(&b, "[%d]", d)
+	}
+	return b.String()
+}
+
+// TypeArray allocates ArrayType of given element type.
+// Each of the dims declares a dimension of given size, zero means that the size is not specified.
+// If no dims are given, a single dimension array is created.
+// If element is an array itself, dimensions are appended to the dimensions of the element, so the result is never nested.
+func TypeArray(elem Type, dims ...int) ArrayType {
+	if len(dims) == 0 {
+		dims = []int{0}
+	}
+	if inner, ok := elem.(ArrayType); ok {
+		elem, dims = inner.Elem, append(inner.Sizes(), dims...)
+	}
+	at := ArrayType{
+		Elem: elem,
+		Dims: len(dims),
+	}
+	copy(at.Size[:], dims)
+	return at
+}
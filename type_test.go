@@ -182,6 +182,48 @@ func TestTypeVarchar(t *testing.T) {
 	assertType(t, expected, got)
 }
 
+func TestTypeBit(t *testing.T) {
+	assertType(t, "BIT", pqt.TypeBit(0))
+	assertType(t, "BIT(8)", pqt.TypeBit(8))
+}
+
+func TestTypeVarbit(t *testing.T) {
+	assertType(t, "VARBIT", pqt.TypeVarbit(0))
+	assertType(t, "VARBIT(64)", pqt.TypeVarbit(64))
+}
+
+func TestType_catalogue(t *testing.T) {
+	cases := map[string]pqt.Type{
+		"INTERVAL":  pqt.TypeInterval(),
+		"TIME":      pqt.TypeTime(),
+		"TIMETZ":    pqt.TypeTimeTZ(),
+		"INET":      pqt.TypeInet(),
+		"CIDR":      pqt.TypeCIDR(),
+		"MACADDR":   pqt.TypeMacAddr(),
+		"TSVECTOR":  pqt.TypeTSVector(),
+		"MONEY":     pqt.TypeMoney(),
+		"XML":       pqt.TypeXML(),
+		"CITEXT":    pqt.TypeCIText(),
+		"HSTORE":    pqt.TypeHstore(),
+		"INT4RANGE": pqt.TypeInt4Range(),
+		"INT8RANGE": pqt.TypeInt8Range(),
+		"NUMRANGE":  pqt.TypeNumRange(),
+		"TSRANGE":   pqt.TypeTSRange(),
+		"TSTZRANGE": pqt.TypeTSTZRange(),
+		"DATERANGE": pqt.TypeDateRange(),
+		"POINT":     pqt.TypePoint(),
+		"LINE":      pqt.TypeLine(),
+		"LSEG":      pqt.TypeLineSegment(),
+		"BOX":       pqt.TypeBox(),
+		"PATH":      pqt.TypePath(),
+		"POLYGON":   pqt.TypePolygon(),
+		"CIRCLE":    pqt.TypeCircle(),
+	}
+	for expected, got := range cases {
+		assertType(t, expected, got)
+	}
+}
+
 func assertType(t *testing.T, expected string, got pqt.Type) {
 	if got.String() != expected {
 		t.Errorf("unexpected sql representation, expected %s got %s", expected, got.String())
@@ -212,3 +254,90 @@ func TestTypeMappable(t *testing.T) {
 		t.Errorf("wrong fingerprint: %s", given.Fingerprint())
 	}
 }
+
+func TestTypeComposite(t *testing.T) {
+	given := pqt.TypeComposite("address",
+		&pqt.Attribute{Name: "street", Type: pqt.TypeText()},
+		&pqt.Attribute{Name: "number", Type: pqt.TypeInteger()},
+	)
+	assertType(t, "address", given)
+	if given.Fingerprint() != "composite: address(street base: TEXT, number base: INTEGER)" {
+		t.Errorf("wrong fingerprint: %s", given.Fingerprint())
+	}
+}
+
+func TestTypeDomain(t *testing.T) {
+	given := pqt.TypeDomain("amount", pqt.TypeNumeric(10, 2),
+		pqt.WithDomainNotNull(),
+		pqt.WithDomainDefault("0"),
+		pqt.WithDomainCheck("VALUE >= 0"),
+		pqt.WithDomainCheck("VALUE < 1000000"),
+	)
+	assertType(t, "amount", given)
+	if given.Fingerprint() != "domain: amount" {
+		t.Errorf("wrong fingerprint: %s", given.Fingerprint())
+	}
+	if given.Base != pqt.TypeNumeric(10, 2) || !given.NotNull || given.Default != "0" {
+		t.Errorf("wrong domain: %#v", given)
+	}
+	if !reflect.DeepEqual(given.Checks, []string{"VALUE >= 0", "VALUE < 1000000"}) {
+		t.Errorf("wrong set of checks: %v", given.Checks)
+	}
+}
+
+func TestTypeArray(t *testing.T) {
+	cases := map[string]struct {
+		given       pqt.ArrayType
+		expected    string
+		fingerprint string
+		dimensions  int
+	}{
+		"default": {
+			given:       pqt.TypeArray(pqt.TypeUUID()),
+			expected:    "UUID[]",
+			fingerprint: "array: base: UUID[]",
+			dimensions:  1,
+		},
+		"fixed-size": {
+			given:       pqt.TypeArray(pqt.TypeTimestampTZ(), 3),
+			expected:    "TIMESTAMPTZ[3]",
+			fingerprint: "array: base: TIMESTAMPTZ[3]",
+			dimensions:  1,
+		},
+		"multidimensional": {
+			given:       pqt.TypeArray(pqt.TypeNumeric(10, 2), 0, 0),
+			expected:    "NUMERIC(10,2)[][]",
+			fingerprint: "array: base: NUMERIC(10,2)[][]",
+			dimensions:  2,
+		},
+		"enumerated": {
+			given:       pqt.TypeArray(pqt.TypeEnumerated("pets", "cat", "dog")),
+			expected:    "pets[]",
+			fingerprint: "array: enumarated: pets[]",
+			dimensions:  1,
+		},
+
+		"nested": {
+			given:       pqt.TypeArray(pqt.TypeArray(pqt.TypeInteger(), 3), 4),
+			expected:    "INTEGER[3][4]",
+			fingerprint: "array: base: INTEGER[3][4]",
+			dimensions:  2,
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			assertType(t, c.expected, c.given)
+			if c.given.Fingerprint() != c.fingerprint {
+				t.Errorf("wrong fingerprint: %s", c.given.Fingerprint())
+			}
+			if c.given.Dimensions() != c.dimensions {
+				t.Errorf("wrong number of dimensions: %d", c.given.Dimensions())
+			}
+		})
+	}
+
+	if pqt.TypeArray(pqt.TypeArray(pqt.TypeInteger())) != pqt.TypeArray(pqt.TypeInteger(), 0, 0) {
+		t.Error("nested array should be equal to multidimensional one")
+	}
+}
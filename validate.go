@@ -0,0 +1,804 @@
+package pqt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxIdentifierLength is a maximum length of an identifier in bytes.
+// Postgres truncates longer identifiers silently.
+const MaxIdentifierLength = 63
+
+// ValidationError describes single problem found in a schema.
+type ValidationError struct {
+	// Path points to the problematic element, e.g. <schema>.<table>.<column>.
+	Path   string
+	Reason string
+}
+
+// Error implements error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Reason)
+}
+
+// ValidationErrors is a collection of problems found in a schema.
+type ValidationErrors []*ValidationError
+
+// Error implements error interface.
+func (e ValidationErrors) Error() string {
+	tmp := make([]string, 0, len(e))
+	for _, err := range e {
+		tmp = append(tmp, err.Error())
+	}
+	return strings.Join(tmp, "\n")
+}
+
+// Validate checks if schema is consistent and can be turned into code.
+// It returns ValidationErrors that contains every problem found or nil if there is none.
+func (s *Schema) Validate() error {
+	v := &validator{
+		tables:      make(map[*Table]bool, len(s.Tables)),
+		types:       make(map[string]bool, len(s.Types)),
+		functions:   make(map[*Function]bool, len(s.Functions)),
+		constraints: make(map[string]bool),
+	}
+	for _, t := range s.Tables {
+		v.tables[t] = true
+	}
+	for _, f := range s.Functions {
+		v.functions[f] = true
+	}
+	// Schema of a database can reference objects of other schemas of the same database.
+	if s.Database != nil {
+		v.external = make(map[string]bool)
+		for _, ds := range s.Database.Schemas {
+			if ds == s {
+				continue
+			}
+			for _, t := range ds.Tables {
+				v.tables[t] = true
+			}
+			for _, f := range ds.Functions {
+				v.functions[f] = true
+			}
+			for _, t := range ds.Types {
+				v.external[t.String()] = true
+			}
+		}
+	}
+	for _, t := range s.Types {
+		switch {
+		case t.String() == "":
+			v.add(s.Name, "type name is missing")
+		case v.types[t.String()]:
+			v.add(t.String(), "duplicate type name")
+		}
+		v.types[t.String()] = true
+		v.identifier(t.String(), t.String())
+
+		switch tt := t.(type) {
+		case EnumeratedType:
+			v.enumeratedType(tt)
+		case CompositeType:
+			v.compositeType(tt)
+		case DomainType:
+			v.domainType(tt)
+		}
+	}
+	v.extensions(s.Extensions)
+
+	v.identifier(s.Name, s.Name)
+	if len(s.Grants) > 0 {
+		path := s.Name
+		if path == "" {
+			path = DefaultSchema
+		}
+		v.grants(path, s.Grants, nil, PrivilegeUsage, PrivilegeCreate)
+	}
+
+	names := make(map[string]bool, len(s.Tables))
+	for _, t := range s.Tables {
+		switch {
+		case t.Name == "":
+			v.add(t.QualifiedName(), "table name is missing")
+		case names[t.QualifiedName()]:
+			v.add(t.QualifiedName(), "duplicate table name")
+		}
+		names[t.QualifiedName()] = true
+		v.identifier(t.QualifiedName(), t.Name)
+	}
+	declared := make(map[*Table]bool, len(s.Tables))
+	for _, t := range s.Tables {
+		v.table(t)
+		v.triggers(t.QualifiedName(), t.Triggers, false)
+		v.partitions(t, names)
+		v.inherits(t, declared)
+		v.policies(t)
+		v.grants(t.QualifiedName(), t.Grants, t, tablePrivileges...)
+		declared[t] = true
+	}
+	for _, vw := range s.Views {
+		// Views and tables share the same namespace.
+		switch {
+		case vw.Name == "":
+			v.add(vw.QualifiedName(), "view name is missing")
+		case names[vw.QualifiedName()]:
+			v.add(vw.QualifiedName(), "duplicate view name")
+		}
+		names[vw.QualifiedName()] = true
+		v.identifier(vw.QualifiedName(), vw.Name)
+		v.view(vw)
+		v.triggers(vw.QualifiedName(), vw.Triggers, true)
+	}
+	for i, f := range s.Functions {
+		if f.Name == "" {
+			v.add(fmt.Sprintf("functions[%d]", i), "function name is missing")
+			continue
+		}
+		v.identifier(f.Name, f.Name)
+		v.function(f)
+		v.grants(f.Name, f.Grants, nil, PrivilegeExecute)
+	}
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// Validate checks if every schema of the database is consistent and can be turned into code.
+// Schemas can reference tables and types of each other.
+// It returns ValidationErrors that contains every problem found or nil if there is none.
+func (d *Database) Validate() error {
+	var errs ValidationErrors
+
+	schemas := make(map[string]bool, len(d.Schemas))
+	for _, s := range d.Schemas {
+		name := s.Name
+		if name == "" {
+			name = DefaultSchema
+		}
+		if schemas[name] {
+			errs = append(errs, &ValidationError{Path: name, Reason: "duplicate schema name"})
+		}
+		schemas[name] = true
+		if s.Database != d {
+			errs = append(errs, &ValidationError{Path: name, Reason: "schema is not assigned to the database"})
+		}
+		if err := s.Validate(); err != nil {
+			errs = append(errs, err.(ValidationErrors)...)
+		}
+	}
+
+	v := &validator{}
+	v.extensions(d.Extensions)
+	errs = append(errs, v.errs...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+type validator struct {
+	tables    map[*Table]bool
+	types     map[string]bool
+	functions map[*Function]bool
+	// constraints are names of constraints already seen.
+	constraints map[string]bool
+	// external are types declared by other schemas of the database.
+	external map[string]bool
+	errs     ValidationErrors
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:   path,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) extensions(extensions []*Extension) {
+	names := make(map[string]bool, len(extensions))
+	for i, e := range extensions {
+		switch {
+		case e.Name == "":
+			v.add(fmt.Sprintf("extensions[%d]", i), "extension name is missing")
+		case names[e.Name]:
+			v.add(e.Name, "duplicate extension")
+		}
+		names[e.Name] = true
+	}
+}
+
+func (v *validator) identifier(path, name string) {
+	if len(name) > MaxIdentifierLength {
+		v.add(path, "identifier %s is longer than %d bytes", name, MaxIdentifierLength)
+	}
+}
+
+// outside returns true if table is not a part of the schema.
+func (v *validator) outside(t *Table) bool {
+	return t != nil && !t.self && !v.tables[t]
+}
+
+func (v *validator) table(t *Table) {
+	names := make(map[string]bool, len(t.Columns))
+	for _, c := range t.Columns {
+		path := t.QualifiedName() + "." + c.Name
+		switch {
+		case c.Name == "":
+			v.add(path, "column name is missing")
+		case names[c.Name]:
+			v.add(path, "duplicate column name")
+		}
+		names[c.Name] = true
+		v.identifier(path, c.Name)
+
+		if c.Reference != nil && v.outside(c.Reference.Table) {
+			v.add(path, "referenced table %s is not a part of the schema", c.Reference.Table.QualifiedName())
+		}
+		if c.IsDynamic {
+			v.dynamicColumn(path, c)
+		}
+		v.generatedColumn(path, c)
+		v.typeDeclared(path, c.Type)
+	}
+	for _, c := range t.Constraints {
+		v.constraint(c)
+	}
+	for _, r := range t.OwnedRelationships {
+		v.relationship(t, r)
+	}
+}
+
+// inherits validates parents of the table, they have to be created before the table that inherits from them.
+func (v *validator) inherits(t *Table, declared map[*Table]bool) {
+	if len(t.Inherits) == 0 {
+		return
+	}
+	if t.PartitionBy != "" {
+		v.add(t.QualifiedName(), "partitioned table cannot inherit from other tables")
+	}
+	for _, p := range t.Inherits {
+		switch {
+		case p == nil:
+			v.add(t.QualifiedName(), "inherited table is missing")
+		case v.outside(p):
+			v.add(t.QualifiedName(), "inherited table %s is not a part of the schema", p.QualifiedName())
+		case !declared[p]:
+			v.add(t.QualifiedName(), "inherited table %s has to be declared before the table", p.QualifiedName())
+		}
+	}
+
+	inherited := make(map[string]*Column)
+	for _, p := range t.Inherits {
+		if p == nil {
+			continue
+		}
+		for _, c := range append(p.InheritedColumns(), p.Columns...) {
+			inherited[c.Name] = c
+		}
+	}
+	for _, c := range t.Columns {
+		if ic, ok := inherited[c.Name]; ok && ic.Type != nil && c.Type != nil && ic.Type.String() != c.Type.String() {
+			v.add(t.QualifiedName()+"."+c.Name, "column type %s conflicts with inherited type %s", c.Type, ic.Type)
+		}
+	}
+}
+
+// partitions validates partitioning of the table, partitions share namespace with tables.
+func (v *validator) partitions(t *Table, names map[string]bool) {
+	path := t.QualifiedName()
+	if t.PartitionBy == "" {
+		if len(t.Partitions) > 0 {
+			v.add(path, "table has partitions, but it is not partitioned")
+		}
+		return
+	}
+	switch t.PartitionBy {
+	case PartitionByRange, PartitionByList, PartitionByHash:
+	default:
+		v.add(path, "unknown partition strategy %s", t.PartitionBy)
+	}
+	if len(t.PartitionKey) == 0 {
+		v.add(path, "partition key is missing")
+	}
+	if t.PartitionBy == PartitionByList && len(t.PartitionKey) > 1 {
+		v.add(path, "list partition key has to be a single column")
+	}
+	for _, c := range t.PartitionKey {
+		if c.Table != t {
+			v.add(path, "partition key column %s does not belong to the table", c.Name)
+		}
+	}
+	for _, c := range t.Constraints {
+		if c.Type != ConstraintTypePrimaryKey && c.Type != ConstraintTypeUnique && c.Type != ConstraintTypeUniqueIndex {
+			continue
+		}
+	KeyLoop:
+		for _, k := range t.PartitionKey {
+			for _, col := range c.PrimaryColumns {
+				if col == k {
+					continue KeyLoop
+				}
+			}
+			v.add(c.Name(), "constraint of partitioned table has to include partition key column %s", k.Name)
+		}
+	}
+
+	var defaults int
+	for _, p := range t.Partitions {
+		path := p.QualifiedName()
+		switch {
+		case p.Name == "":
+			v.add(path, "partition name is missing")
+		case names[path]:
+			v.add(path, "duplicate partition name")
+		}
+		names[path] = true
+		v.identifier(path, p.Name)
+
+		switch {
+		case p.Default && p.Bound != "":
+			v.add(path, "default partition cannot have bound")
+		case p.Default && t.PartitionBy == PartitionByHash:
+			v.add(path, "hash partitioned table cannot have default partition")
+		case !p.Default && p.Bound == "":
+			v.add(path, "partition bound is missing")
+		}
+		if p.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		v.add(path, "table can have only one default partition")
+	}
+}
+
+func (v *validator) function(f *Function) {
+	if f.BuiltIn {
+		return
+	}
+	switch {
+	case len(f.ReturnsTable) > 0 && f.SetOf:
+		v.add(f.Name, "function cannot return both set of values and table")
+	case len(f.ReturnsTable) == 0 && f.Type == nil:
+		v.add(f.Name, "function return type is missing")
+	}
+	for i, arg := range f.ReturnsTable {
+		if arg.Name == "" {
+			v.add(f.Name, "name of returned column %d is missing", i)
+		}
+		if arg.Type == nil {
+			v.add(f.Name, "type of returned column %d is missing", i)
+		}
+	}
+}
+
+func (v *validator) triggers(path string, triggers []*Trigger, view bool) {
+	names := make(map[string]bool, len(triggers))
+	for _, tr := range triggers {
+		path := path + "." + tr.Name
+		switch {
+		case tr.Name == "":
+			v.add(path, "trigger name is missing")
+		case names[tr.Name]:
+			v.add(path, "duplicate trigger name")
+		}
+		names[tr.Name] = true
+		v.identifier(path, tr.Name)
+
+		switch {
+		case tr.Function == nil:
+			v.add(path, "trigger function is missing")
+		case tr.Function.Type == nil || tr.Function.Type.Fingerprint() != TypeTrigger().Fingerprint():
+			v.add(path, "function %s does not return trigger", tr.Function.Name)
+		case !tr.Function.BuiltIn && !v.functions[tr.Function]:
+			v.add(path, "function %s is not a part of the schema", tr.Function.Name)
+		}
+
+		if len(tr.Events) == 0 {
+			v.add(path, "trigger without events")
+		}
+		var update, truncate bool
+		for _, e := range tr.Events {
+			switch e {
+			case EventUpdate:
+				update = true
+			case EventTruncate:
+				truncate = true
+			case EventInsert, EventDelete:
+			default:
+				v.add(path, "unknown trigger event %s", e)
+			}
+		}
+		if len(tr.UpdateOf) > 0 && !update {
+			v.add(path, "update columns given, but trigger does not fire on update")
+		}
+		for _, c := range tr.UpdateOf {
+			if view || c.Table != tr.Table {
+				v.add(path, "column %s does not belong to %s", c.Name, tr.RelationName())
+			}
+		}
+		if truncate && (tr.ForEachRow || view) {
+			v.add(path, "truncate trigger has to be a statement level trigger of a table")
+		}
+
+		switch {
+		case tr.Timing == TriggerTimingInsteadOf && !view:
+			v.add(path, "instead of trigger can be attached only to a view")
+		case tr.Timing == TriggerTimingInsteadOf && (!tr.ForEachRow || tr.When != "" || len(tr.UpdateOf) > 0):
+			v.add(path, "instead of trigger has to be a row level trigger without condition and update columns")
+		case tr.Timing != TriggerTimingInsteadOf && view && tr.ForEachRow:
+			v.add(path, "before and after triggers of a view have to be statement level triggers")
+		}
+	}
+}
+
+func (v *validator) policies(t *Table) {
+	names := make(map[string]bool, len(t.Policies))
+	for _, p := range t.Policies {
+		path := t.QualifiedName() + "." + p.Name
+		switch {
+		case p.Name == "":
+			v.add(path, "policy name is missing")
+		case names[p.Name]:
+			v.add(path, "duplicate policy name")
+		}
+		names[p.Name] = true
+		v.identifier(path, p.Name)
+
+		switch p.Command {
+		case "", PolicyCommandAll, PolicyCommandUpdate:
+		case PolicyCommandSelect, PolicyCommandDelete:
+			if p.WithCheck != "" {
+				v.add(path, "%s policy cannot have WITH CHECK expression", p.Command)
+			}
+		case PolicyCommandInsert:
+			if p.Using != "" {
+				v.add(path, "%s policy cannot have USING expression", p.Command)
+			}
+		default:
+			v.add(path, "unknown policy command %s", p.Command)
+		}
+		for _, r := range p.Roles {
+			if r == "" {
+				v.add(path, "policy role is missing")
+			}
+		}
+	}
+}
+
+var (
+	tablePrivileges = []Privilege{
+		PrivilegeSelect, PrivilegeInsert, PrivilegeUpdate, PrivilegeDelete,
+		PrivilegeTruncate, PrivilegeReferences, PrivilegeTrigger,
+	}
+	columnPrivileges = []Privilege{
+		PrivilegeSelect, PrivilegeInsert, PrivilegeUpdate, PrivilegeReferences,
+	}
+)
+
+// grants validates privileges given on an object, t is not nil only for privileges of a table.
+func (v *validator) grants(path string, grants []*Grant, t *Table, allowed ...Privilege) {
+	for _, g := range grants {
+		if g.Role == "" {
+			v.add(path, "grant role is missing")
+		}
+		if len(g.Privileges) == 0 {
+			v.add(path, "grant without privileges")
+		}
+		privileges := allowed
+		if len(g.Columns) > 0 {
+			privileges = columnPrivileges
+		}
+	PrivilegesLoop:
+		for _, p := range g.Privileges {
+			if p == PrivilegeAll {
+				continue
+			}
+			for _, a := range privileges {
+				if p == a {
+					continue PrivilegesLoop
+				}
+			}
+			v.add(path, "privilege %s cannot be granted to %s", p, g.Role)
+		}
+		for _, c := range g.Columns {
+			if t == nil || c.Table != t {
+				v.add(path, "column %s does not belong to %s", c.Name, path)
+			}
+		}
+	}
+}
+
+func (v *validator) view(vw *View) {
+	if strings.TrimSpace(vw.Query) == "" {
+		v.add(vw.QualifiedName(), "view query is missing")
+	}
+	if len(vw.Columns) == 0 {
+		v.add(vw.QualifiedName(), "view has no columns")
+	}
+	names := make(map[string]bool, len(vw.Columns))
+	for _, c := range vw.Columns {
+		path := vw.QualifiedName() + "." + c.Name
+		switch {
+		case c.Name == "":
+			v.add(path, "column name is missing")
+		case names[c.Name]:
+			v.add(path, "duplicate column name")
+		}
+		names[c.Name] = true
+		v.identifier(path, c.Name)
+
+		if c.IsDynamic || len(c.Default) > 0 || len(c.Constraints()) > 0 {
+			v.add(path, "columns of view cannot be dynamic, have default value or constraints")
+		}
+		if c.Type == nil {
+			v.add(path, "column type is missing")
+		} else {
+			v.typeDeclared(path, c.Type)
+		}
+	}
+}
+
+// typeDeclared checks if user defined type is declared in the schema.
+func (v *validator) typeDeclared(path string, t Type) {
+	var kind string
+	switch tt := t.(type) {
+	case ArrayType:
+		if tt.Elem == nil {
+			v.add(path, "array element type is missing")
+			return
+		}
+		if _, ok := tt.Elem.(ArrayType); ok {
+			v.add(path, "array element type cannot be an array, dimensions should be given to TypeArray instead")
+			return
+		}
+		if tt.Dimensions() > MaxArrayDimensions {
+			v.add(path, "array cannot have more than %d dimensions", MaxArrayDimensions)
+			return
+		}
+		v.typeDeclared(path, tt.Elem)
+		return
+	case EnumeratedType:
+		kind = "enumerated"
+	case CompositeType:
+		kind = "composite"
+	case DomainType:
+		kind = "domain"
+	default:
+		return
+	}
+	if !v.types[t.String()] && !v.external[t.String()] {
+		v.add(path, "%s type %s is not a part of the schema", kind, t.String())
+	}
+}
+
+func (v *validator) domainType(dt DomainType) {
+	if dt.Base == nil {
+		v.add(dt.String(), "domain base type is missing")
+	} else {
+		v.typeDeclared(dt.String(), dt.Base)
+	}
+	for _, c := range dt.Checks {
+		if strings.TrimSpace(c) == "" {
+			v.add(dt.String(), "domain check is empty")
+		}
+	}
+}
+
+func (v *validator) compositeType(ct CompositeType) {
+	if len(ct.Attributes) == 0 {
+		v.add(ct.String(), "composite type without attributes")
+	}
+	names := make(map[string]bool, len(ct.Attributes))
+	for _, a := range ct.Attributes {
+		path := ct.String() + "." + a.Name
+		switch {
+		case a.Name == "":
+			v.add(path, "attribute name is missing")
+		case names[a.Name]:
+			v.add(path, "duplicate attribute name")
+		}
+		names[a.Name] = true
+		v.identifier(path, a.Name)
+
+		if a.Type == nil {
+			v.add(path, "attribute type is missing")
+		} else {
+			v.typeDeclared(path, a.Type)
+		}
+		if a.Default != "" || a.Check != "" || a.Unique || a.PrimaryKey {
+			v.add(path, "attributes of composite type cannot have default value or constraints")
+		}
+	}
+}
+
+func (v *validator) enumeratedType(et EnumeratedType) {
+	if len(et.Enums) == 0 {
+		v.add(et.String(), "enumerated type without values")
+	}
+	values := make(map[string]bool, len(et.Enums))
+	for _, e := range et.Enums {
+		if values[e] {
+			v.add(et.String(), "duplicate value %s", e)
+		}
+		values[e] = true
+	}
+}
+
+func (v *validator) dynamicColumn(path string, c *Column) {
+	if c.Func == nil {
+		v.add(path, "dynamic column without function")
+		return
+	}
+	if c.Func.Name == "" {
+		v.add(path, "function name is missing")
+	}
+	if len(c.Func.Args) != len(c.Columns) {
+		v.add(path, "function %s expects %d arguments, but %d columns given", c.Func.Name, len(c.Func.Args), len(c.Columns))
+	}
+	for i, arg := range c.Func.Args {
+		if i >= len(c.Columns) {
+			break
+		}
+		if arg.Type == nil || c.Columns[i].Type == nil {
+			continue
+		}
+		// Serial types are integers that have a default value, so they can be passed as such.
+		if arg.Type.Fingerprint() != fkType(c.Columns[i].Type).Fingerprint() && arg.Type.Fingerprint() != c.Columns[i].Type.Fingerprint() {
+			v.add(path, "function %s argument %d expects type %s, but column %s is of type %s", c.Func.Name, i, arg.Type, c.Columns[i].Name, c.Columns[i].Type)
+		}
+	}
+}
+
+// generatedColumn validates identity and generated columns, their values are computed by the database.
+func (v *validator) generatedColumn(path string, c *Column) {
+	if c.Identity == "" && c.Generated == "" {
+		return
+	}
+	switch {
+	case c.IsDynamic:
+		v.add(path, "dynamic column cannot be identity or generated column")
+	case c.Identity != "" && c.Generated != "":
+		v.add(path, "column cannot be both identity and generated column")
+	case len(c.Default) > 0:
+		v.add(path, "identity or generated column cannot have default value")
+	}
+	switch c.Identity {
+	case "", IdentityAlways, IdentityByDefault:
+	default:
+		v.add(path, "unknown identity %s", c.Identity)
+	}
+	if c.Identity != "" && c.Type != nil {
+		switch c.Type.String() {
+		case "SMALLINT", "INTEGER", "BIGINT":
+		default:
+			v.add(path, "identity column has to be of type SMALLINT, INTEGER or BIGINT, got %s", c.Type)
+		}
+	}
+}
+
+func (v *validator) constraint(c *Constraint) {
+	path := c.Name()
+	v.identifier(path, path)
+
+	if c.PrimaryTable == nil {
+		v.add(path, "constraint is not assigned to any table")
+		return
+	}
+	if v.constraints[path] {
+		v.add(path, "duplicate constraint name")
+	}
+	v.constraints[path] = true
+	for _, col := range c.PrimaryColumns {
+		if col.Table != c.PrimaryTable {
+			v.add(path, "column %s does not belong to table %s", col.Name, c.PrimaryTable.QualifiedName())
+		}
+	}
+	if c.DeferrableInitiallyDeferred || c.DeferrableInitiallyImmediate {
+		switch c.Type {
+		case ConstraintTypePrimaryKey, ConstraintTypeUnique, ConstraintTypeForeignKey, ConstraintTypeExclusion:
+		default:
+			v.add(path, "constraint of type %s cannot be deferrable", c.Type)
+		}
+	}
+	if c.DeferrableInitiallyDeferred && c.DeferrableInitiallyImmediate {
+		v.add(path, "constraint cannot be initially deferred and initially immediate at the same time")
+	}
+	if c.Match != MatchDefault && c.Type != ConstraintTypeForeignKey {
+		v.add(path, "match type can be set only for foreign key")
+	}
+	if c.Type == ConstraintTypeExclusion {
+		v.exclusion(path, c)
+		return
+	}
+	if c.Type == ConstraintTypeIndex || c.Type == ConstraintTypeUniqueIndex {
+		v.index(path, c)
+		return
+	}
+	if c.Type != ConstraintTypeForeignKey {
+		return
+	}
+	if len(c.Columns) == 0 {
+		v.add(path, "foreign key expects at least one reference column")
+		return
+	}
+	if len(c.Columns) != len(c.PrimaryColumns) {
+		v.add(path, "foreign key has %d columns, but references %d", len(c.PrimaryColumns), len(c.Columns))
+	}
+	if c.Table == nil {
+		v.add(path, "referenced table is missing")
+		return
+	}
+	if v.outside(c.Table) {
+		v.add(path, "referenced table %s is not a part of the schema", c.Table.QualifiedName())
+	}
+	for _, col := range c.Columns {
+		if col.Table != c.Table {
+			v.add(path, "reference column %s does not belong to table %s", col.Name, c.Table.QualifiedName())
+		}
+	}
+}
+
+func (v *validator) exclusion(path string, c *Constraint) {
+	if len(c.Exclude) == 0 {
+		v.add(path, "exclusion constraint expects at least one element")
+	}
+	for _, e := range c.Exclude {
+		if e.Column == nil && e.Expression == "" {
+			v.add(path, "exclusion element has neither column nor expression")
+		}
+		if e.Operator == "" {
+			v.add(path, "exclusion element operator is missing")
+		}
+	}
+}
+
+func (v *validator) index(path string, c *Constraint) {
+	if len(c.PrimaryColumns) == 0 && len(c.Elements) == 0 {
+		v.add(path, "index expects at least one column or expression")
+	}
+	for _, e := range c.Elements {
+		if e.Column == nil && e.Expression == "" {
+			v.add(path, "index element has neither column nor expression")
+		}
+	}
+	for _, col := range c.Include {
+		if col.Table != c.PrimaryTable {
+			v.add(path, "included column %s does not belong to table %s", col.Name, c.PrimaryTable.QualifiedName())
+		}
+	}
+}
+
+func (v *validator) relationship(t *Table, r *Relationship) {
+	path := t.QualifiedName()
+	for _, rt := range []*Table{r.OwnerTable, r.InversedTable, r.ThroughTable} {
+		if v.outside(rt) {
+			v.add(path, "related table %s is not a part of the schema", rt.QualifiedName())
+		}
+	}
+
+	if r.OwnerTable == nil || r.InversedTable == nil {
+		v.add(path, "relationship is missing owner or inversed table")
+		return
+	}
+	if r.Type == RelationshipTypeManyToMany {
+		if len(r.OwnerTable.PrimaryKeyColumns()) == 0 && r.OwnerForeignKey == nil {
+			v.add(path, "missing owner table (%s) primary key for many to many relationship", r.OwnerTable.QualifiedName())
+		}
+		if len(r.InversedTable.PrimaryKeyColumns()) == 0 && r.InversedForeignKey == nil {
+			v.add(path, "missing inversed table (%s) primary key for many to many relationship", r.InversedTable.QualifiedName())
+		}
+		return
+	}
+	if len(r.OwnerColumns) == 0 {
+		v.add(path, "missing inversed table (%s) primary key for relationship", r.InversedTable.QualifiedName())
+		return
+	}
+	if len(r.OwnerColumns) != len(r.InversedColumns) {
+		v.add(path, "number of owner (%d) and inversed (%d) relationship columns is not equal", len(r.OwnerColumns), len(r.InversedColumns))
+	}
+}
@@ -0,0 +1,418 @@
+package pqt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	cases := map[string]struct {
+		schema   func() *pqt.Schema
+		expected []string
+	}{
+		"valid": {
+			schema: func() *pqt.Schema {
+				userID := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+				user := pqt.NewTable("user").AddColumn(userID)
+				comment := pqt.NewTable("comment").
+					AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+					AddColumn(pqt.NewColumn("user_id", pqt.TypeIntegerBig(), pqt.WithReference(userID)))
+				return pqt.NewSchema("example").AddTable(user).AddTable(comment)
+			},
+		},
+		"duplicates": {
+			schema: func() *pqt.Schema {
+				return pqt.NewSchema("example").
+					AddTable(pqt.NewTable("user").
+						AddColumn(pqt.NewColumn("name", pqt.TypeText())).
+						AddColumn(pqt.NewColumn("name", pqt.TypeText()))).
+					AddTable(pqt.NewTable("user"))
+			},
+			expected: []string{
+				"example.user: duplicate table name",
+				"example.user.name: duplicate column name",
+			},
+		},
+		"outside-schema": {
+			schema: func() *pqt.Schema {
+				groupID := pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())
+				_ = pqt.NewTable("group").AddColumn(groupID)
+				user := pqt.NewTable("user").
+					AddColumn(pqt.NewColumn("group_id", pqt.TypeInteger(), pqt.WithReference(groupID)))
+				return pqt.NewSchema("example").AddTable(user)
+			},
+			expected: []string{
+				"example.user_group_id_fkey: referenced table group is not a part of the schema",
+				"example.user: related table group is not a part of the schema",
+			},
+		},
+		"missing-primary-key": {
+			schema: func() *pqt.Schema {
+				user := pqt.NewTable("user").AddColumn(pqt.NewColumn("name", pqt.TypeText()))
+				group := pqt.NewTable("group").AddColumn(pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey()))
+				comment := pqt.NewTable("comment").AddRelationship(pqt.ManyToOne(user))
+				userGroups := pqt.NewTable("user_groups").AddRelationship(pqt.ManyToMany(user, group))
+				return pqt.NewSchema("example").AddTable(user).AddTable(group).AddTable(comment).AddTable(userGroups)
+			},
+			expected: []string{
+				"example.comment: missing inversed table (example.user) primary key for relationship",
+				"example.user_groups: missing owner table (example.user) primary key for many to many relationship",
+			},
+		},
+		"foreign-key": {
+			schema: func() *pqt.Schema {
+				id := pqt.NewColumn("id", pqt.TypeSerial(), pqt.WithPrimaryKey())
+				name := pqt.NewColumn("name", pqt.TypeText())
+				other := pqt.NewColumn("other", pqt.TypeText())
+				user := pqt.NewTable("user").AddColumn(id).AddColumn(name)
+				group := pqt.NewTable("group").AddColumn(other)
+				user.AddConstraint(pqt.ForeignKey(pqt.Columns{id, other}, pqt.Columns{name}))
+				return pqt.NewSchema("example").AddTable(user).AddTable(group)
+			},
+			expected: []string{
+				"example.user_id_other_fkey: column other does not belong to table example.user",
+				"example.user_id_other_fkey: foreign key has 2 columns, but references 1",
+			},
+		},
+		"deferrable": {
+			schema: func() *pqt.Schema {
+				name := pqt.NewColumn("name", pqt.TypeText())
+				user := pqt.NewTable("user").AddColumn(name)
+				check := pqt.Check(user, "name <> ''", name)
+				pqt.WithDeferrable()(check)
+				pqt.WithMatch(pqt.MatchFull)(check)
+				unique := pqt.UniqueOn(user, pqt.Columns{name}, pqt.WithInitiallyDeferred())
+				unique.DeferrableInitiallyImmediate = true
+				user.AddConstraint(check).AddConstraint(unique)
+				return pqt.NewSchema("example").AddTable(user)
+			},
+			expected: []string{
+				"example.user_name_check: constraint of type check cannot be deferrable",
+				"example.user_name_check: match type can be set only for foreign key",
+				"example.user_name_key: constraint cannot be initially deferred and initially immediate at the same time",
+			},
+		},
+		"exclusion": {
+			schema: func() *pqt.Schema {
+				room := pqt.NewColumn("room", pqt.TypeInteger())
+				reservation := pqt.NewTable("reservation").AddColumn(room)
+				reservation.AddConstraint(pqt.Exclusion(reservation, "gist"))
+				reservation.AddExclusion("gist", "", pqt.ExcludeColumn(room, ""), pqt.ExcludeExpression("", "&&"))
+				return pqt.NewSchema("example").AddTable(reservation)
+			},
+			expected: []string{
+				"example.reservation_excl: exclusion constraint expects at least one element",
+				"example.reservation_room_excl: exclusion element operator is missing",
+				"example.reservation_room_excl: exclusion element has neither column nor expression",
+			},
+		},
+		"index": {
+			schema: func() *pqt.Schema {
+				name := pqt.NewColumn("name", pqt.TypeText())
+				other := pqt.NewColumn("other", pqt.TypeText())
+				_ = pqt.NewTable("other").AddColumn(other)
+				user := pqt.NewTable("user").AddColumn(name)
+				user.AddConstraint(pqt.IndexOn(user, nil))
+				user.AddConstraint(pqt.IndexOn(user, []*pqt.IndexElement{pqt.IndexColumn(name), pqt.IndexExpression("")}, pqt.WithInclude(other)))
+				return pqt.NewSchema("example").AddTable(user)
+			},
+			expected: []string{
+				"example.user_idx: index expects at least one column or expression",
+				"example.user_name_1B2M2Y8A_idx: index element has neither column nor expression",
+				"example.user_name_1B2M2Y8A_idx: included column other does not belong to table example.user",
+			},
+		},
+		"partition": {
+			schema: func() *pqt.Schema {
+				id := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+				createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ())
+				other := pqt.NewColumn("other", pqt.TypeText())
+				event := pqt.NewTable("event", pqt.WithPartitionBy(pqt.PartitionByHash, createdAt, other)).
+					AddColumn(id).
+					AddColumn(createdAt).
+					AddPartition(pqt.PartitionHash("user", 2, 0)).
+					AddPartition(pqt.NewPartition("event_1", "")).
+					AddPartition(pqt.PartitionDefault("event_default"))
+				user := pqt.NewTable("user").
+					AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig())).
+					AddPartition(pqt.PartitionDefault("user_default"))
+				return pqt.NewSchema("example").AddTable(user).AddTable(event)
+			},
+			expected: []string{
+				"example.user: table has partitions, but it is not partitioned",
+				"example.event: partition key column other does not belong to the table",
+				"example.event_id_pkey: constraint of partitioned table has to include partition key column created_at",
+				"example.event_id_pkey: constraint of partitioned table has to include partition key column other",
+				"example.user: duplicate partition name",
+				"example.event_1: partition bound is missing",
+				"example.event_default: hash partitioned table cannot have default partition",
+			},
+		},
+		"inherits": {
+			schema: func() *pqt.Schema {
+				createdAt := pqt.NewColumn("created_at", pqt.TypeTimestampTZ())
+				base := pqt.NewTable("base").
+					AddColumn(pqt.NewColumn("name", pqt.TypeText()))
+				entry := pqt.NewTable("entry", pqt.WithInherits(base)).
+					AddColumn(pqt.NewColumn("name", pqt.TypeInteger()))
+				audit := pqt.NewTable("audit", pqt.WithInherits(entry, pqt.NewTable("other")), pqt.WithPartitionBy(pqt.PartitionByRange, createdAt)).
+					AddColumn(createdAt)
+				return pqt.NewSchema("example").AddTable(audit).AddTable(base).AddTable(entry)
+			},
+			expected: []string{
+				"example.audit: partitioned table cannot inherit from other tables",
+				"example.audit: inherited table example.entry has to be declared before the table",
+				"example.audit: inherited table other is not a part of the schema",
+				"example.entry.name: column type INTEGER conflicts with inherited type TEXT",
+			},
+		},
+		"generated": {
+			schema: func() *pqt.Schema {
+				return pqt.NewSchema("example").AddTable(pqt.NewTable("item").
+					AddColumn(pqt.NewColumn("a", pqt.TypeIntegerBig(), pqt.WithIdentity(pqt.IdentityAlways), pqt.WithGenerated("1"))).
+					AddColumn(pqt.NewColumn("b", pqt.TypeText(), pqt.WithIdentity(pqt.IdentityByDefault))).
+					AddColumn(pqt.NewColumn("c", pqt.TypeInteger(), pqt.WithGenerated("a + 1"), pqt.WithDefault("0"))).
+					AddColumn(pqt.NewColumn("d", pqt.TypeInteger(), pqt.WithIdentity("SOMETIMES"))).
+					AddColumn(pqt.NewColumn("e", pqt.TypeIntegerSmall(), pqt.WithIdentity(pqt.IdentityAlways))).
+					AddColumn(pqt.NewColumn("f", pqt.TypeText(), pqt.WithGenerated("lower(b)"))))
+			},
+			expected: []string{
+				"example.item.a: column cannot be both identity and generated column",
+				"example.item.b: identity column has to be of type SMALLINT, INTEGER or BIGINT, got TEXT",
+				"example.item.c: identity or generated column cannot have default value",
+				"example.item.d: unknown identity SOMETIMES",
+			},
+		},
+		"security": {
+			schema: func() *pqt.Schema {
+				other := pqt.NewColumn("other", pqt.TypeText())
+				tenant := pqt.NewColumn("tenant", pqt.TypeText())
+				account := pqt.NewTable("account", pqt.WithRowLevelSecurity()).
+					AddColumn(tenant).
+					AddPolicy(pqt.NewPolicy("tenant", pqt.WithUsing("tenant = current_user"))).
+					AddPolicy(pqt.NewPolicy("tenant", pqt.WithPolicyCommand(pqt.PolicyCommandInsert), pqt.WithUsing("true"))).
+					AddPolicy(pqt.NewPolicy("reader", pqt.WithPolicyCommand(pqt.PolicyCommandSelect), pqt.WithPolicyCheck("true"))).
+					AddPolicy(pqt.NewPolicy("merge", pqt.WithPolicyCommand("MERGE"))).
+					AddGrant(pqt.NewGrant("app", pqt.PrivilegeSelect, pqt.PrivilegeExecute)).
+					AddGrant(pqt.NewGrant("app", pqt.PrivilegeDelete).OnColumns(tenant, other)).
+					AddGrant(pqt.NewGrant(""))
+				return pqt.NewSchema("example").
+					AddGrant(pqt.NewGrant("app", pqt.PrivilegeUsage, pqt.PrivilegeSelect)).
+					AddTable(account).
+					AddFunction(&pqt.Function{
+						Name:   "one",
+						Type:   pqt.TypeInteger(),
+						Grants: []*pqt.Grant{pqt.NewGrant("app", pqt.PrivilegeExecute, pqt.PrivilegeAll)},
+					})
+			},
+			expected: []string{
+				"example: privilege SELECT cannot be granted to app",
+				"example.account.tenant: duplicate policy name",
+				"example.account.tenant: INSERT policy cannot have USING expression",
+				"example.account.reader: SELECT policy cannot have WITH CHECK expression",
+				"example.account.merge: unknown policy command MERGE",
+				"example.account: privilege EXECUTE cannot be granted to app",
+				"example.account: privilege DELETE cannot be granted to app",
+				"example.account: column other does not belong to example.account",
+				"example.account: grant role is missing",
+				"example.account: grant without privileges",
+			},
+		},
+		"function-arguments": {
+			schema: func() *pqt.Schema {
+				id := pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())
+				name := pqt.NewColumn("name", pqt.TypeText())
+				fn := &pqt.Function{
+					Name: "multiply",
+					Type: pqt.TypeIntegerBig(),
+					Args: []*pqt.FunctionArg{
+						{Name: "x", Type: pqt.TypeIntegerBig()},
+						{Name: "y", Type: pqt.TypeIntegerBig()},
+					},
+				}
+				user := pqt.NewTable("user").
+					AddColumn(id).
+					AddColumn(name).
+					AddColumn(pqt.NewDynamicColumn("id_squared", fn, id, id)).
+					AddColumn(pqt.NewDynamicColumn("id_name", fn, id, name)).
+					AddColumn(pqt.NewDynamicColumn("id_only", fn, id))
+				return pqt.NewSchema("example").AddTable(user).AddFunction(fn)
+			},
+			expected: []string{
+				"example.user.id_name: function multiply argument 1 expects type BIGINT, but column name is of type TEXT",
+				"example.user.id_only: function multiply expects 2 arguments, but 1 columns given",
+			},
+		},
+		"enumerated-type": {
+			schema: func() *pqt.Schema {
+				mood := pqt.TypeEnumerated("mood", "happy", "happy")
+				return pqt.NewSchema("example").
+					AddType(mood).
+					AddType(pqt.TypeEnumerated("mood", "sad")).
+					AddTable(pqt.NewTable("user").
+						AddColumn(pqt.NewColumn("mood", mood)).
+						AddColumn(pqt.NewColumn("color", pqt.TypeEnumerated("color", "red"))))
+			},
+			expected: []string{
+				"mood: duplicate value happy",
+				"mood: duplicate type name",
+				"example.user.color: enumerated type color is not a part of the schema",
+			},
+		},
+		"composite-type": {
+			schema: func() *pqt.Schema {
+				address := pqt.TypeComposite("address",
+					&pqt.Attribute{Name: "street", Type: pqt.TypeText(), Default: "''"},
+					&pqt.Attribute{Name: "street", Type: pqt.TypeText()},
+					&pqt.Attribute{Name: "mood", Type: pqt.TypeEnumerated("mood", "happy")},
+				)
+				return pqt.NewSchema("example").
+					AddType(address).
+					AddTable(pqt.NewTable("user").
+						AddColumn(pqt.NewColumn("address", address)).
+						AddColumn(pqt.NewColumn("point", pqt.TypeComposite("point", &pqt.Attribute{Name: "x", Type: pqt.TypeInteger()}))))
+			},
+			expected: []string{
+				"address.street: attributes of composite type cannot have default value or constraints",
+				"address.street: duplicate attribute name",
+				"address.mood: enumerated type mood is not a part of the schema",
+				"example.user.point: composite type point is not a part of the schema",
+			},
+		},
+		"domain-type": {
+			schema: func() *pqt.Schema {
+				email := pqt.TypeDomain("email", pqt.TypeCIText(), pqt.WithDomainCheck(" "))
+				return pqt.NewSchema("example", pqt.WithSchemaExtension("citext"), pqt.WithSchemaExtension("citext")).
+					AddType(email).
+					AddType(pqt.TypeDomain("nothing", nil)).
+					AddType(pqt.TypeDomain("moods", pqt.TypeEnumerated("mood", "happy"))).
+					AddTable(pqt.NewTable("user").
+						AddColumn(pqt.NewColumn("email", email)).
+						AddColumn(pqt.NewColumn("slug", pqt.TypeDomain("slug", pqt.TypeText()))))
+			},
+			expected: []string{
+				"email: domain check is empty",
+				"nothing: domain base type is missing",
+				"moods: enumerated type mood is not a part of the schema",
+				"citext: duplicate extension",
+				"example.user.slug: domain type slug is not a part of the schema",
+			},
+		},
+		"constraint-name": {
+			schema: func() *pqt.Schema {
+				tbl := pqt.NewTable("user").
+					AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+					AddColumn(pqt.NewColumn("email", pqt.TypeText()))
+				tbl.AddConstraint(pqt.UniqueOn(tbl, pqt.Columns{tbl.Columns[0]}, pqt.WithConstraintName("user_key")))
+				tbl.AddConstraint(pqt.UniqueOn(tbl, pqt.Columns{tbl.Columns[1]}, pqt.WithConstraintName("user_key")))
+				tbl.AddConstraint(pqt.UniqueOn(tbl, pqt.Columns{tbl.Columns[1]}, pqt.WithConstraintName(strings.Repeat("x", 64))))
+				return pqt.NewSchema("example").AddTable(tbl)
+			},
+			expected: []string{
+				"user_key: duplicate constraint name",
+				strings.Repeat("x", 64) + ": identifier " + strings.Repeat("x", 64) + " is longer than 63 bytes",
+			},
+		},
+		"array-type": {
+			schema: func() *pqt.Schema {
+				return pqt.NewSchema("example").
+					AddTable(pqt.NewTable("user").
+						AddColumn(pqt.NewColumn("tags", pqt.TypeArray(pqt.TypeText()))).
+						AddColumn(pqt.NewColumn("moods", pqt.TypeArray(pqt.TypeEnumerated("mood", "happy")))).
+						AddColumn(pqt.NewColumn("empty", pqt.ArrayType{})).
+						AddColumn(pqt.NewColumn("nested", pqt.ArrayType{Elem: pqt.TypeArray(pqt.TypeInteger())})).
+						AddColumn(pqt.NewColumn("deep", pqt.TypeArray(pqt.TypeInteger(), 0, 0, 0, 0, 0, 0, 0))))
+			},
+			expected: []string{
+				"example.user.deep: array cannot have more than 6 dimensions",
+				"example.user.empty: array element type is missing",
+				"example.user.moods: enumerated type mood is not a part of the schema",
+				"example.user.nested: array element type cannot be an array, dimensions should be given to TypeArray instead",
+			},
+		},
+		"view": {
+			schema: func() *pqt.Schema {
+				return pqt.NewSchema("example").
+					AddTable(pqt.NewTable("user").AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey()))).
+					AddView(pqt.NewView("user", "SELECT id FROM example.user").
+						AddColumn(pqt.NewColumn("id", pqt.TypeIntegerBig(), pqt.WithPrimaryKey()))).
+					AddView(pqt.NewView("empty", " "))
+			},
+			expected: []string{
+				"example.user: duplicate view name",
+				"example.user.id: columns of view cannot be dynamic, have default value or constraints",
+				"example.empty: view query is missing",
+				"example.empty: view has no columns",
+			},
+		},
+		"trigger": {
+			schema: func() *pqt.Schema {
+				touch := &pqt.Function{Name: "touch", Type: pqt.TypeTrigger(), Language: pqt.FunctionLanguagePLpgSQL, Body: "BEGIN RETURN NEW; END;"}
+				other := &pqt.Function{Name: "other", Type: pqt.TypeTrigger(), Language: pqt.FunctionLanguagePLpgSQL, Body: "BEGIN RETURN NEW; END;"}
+				now := pqt.FunctionNow()
+				name := pqt.NewColumn("name", pqt.TypeText())
+				user := pqt.NewTable("user").
+					AddColumn(pqt.NewColumn("id", pqt.TypeSerialBig(), pqt.WithPrimaryKey())).
+					AddTrigger(pqt.NewTrigger("t1", pqt.TriggerTimingBefore, touch, pqt.WithTriggerEvents(pqt.EventUpdate), pqt.WithForEachRow())).
+					AddTrigger(pqt.NewTrigger("t1", pqt.TriggerTimingBefore, other, pqt.WithTriggerEvents(pqt.EventInsert))).
+					AddTrigger(pqt.NewTrigger("t2", pqt.TriggerTimingInsteadOf, now, pqt.WithTriggerEvents(pqt.EventTruncate), pqt.WithUpdateOf(name)))
+				view := pqt.NewView("user_view", "SELECT 1").
+					AddColumn(pqt.NewColumn("one", pqt.TypeInteger())).
+					AddTrigger(pqt.NewTrigger("t3", pqt.TriggerTimingAfter, touch, pqt.WithForEachRow()))
+				return pqt.NewSchema("example").
+					AddFunction(touch).
+					AddFunction(&pqt.Function{Name: "both", Type: pqt.TypeText(), SetOf: true, ReturnsTable: []*pqt.FunctionArg{{Name: "a", Type: pqt.TypeText()}}}).
+					AddTable(user).
+					AddView(view)
+			},
+			expected: []string{
+				"example.user.t1: duplicate trigger name",
+				"example.user.t1: function other is not a part of the schema",
+				"example.user.t2: function now does not return trigger",
+				"example.user.t2: update columns given, but trigger does not fire on update",
+				"example.user.t2: column name does not belong to example.user",
+				"example.user.t2: instead of trigger can be attached only to a view",
+				"example.user_view.t3: trigger without events",
+				"example.user_view.t3: before and after triggers of a view have to be statement level triggers",
+				"both: function cannot return both set of values and table",
+			},
+		},
+		"identifier-length": {
+			schema: func() *pqt.Schema {
+				return pqt.NewSchema("example").
+					AddTable(pqt.NewTable("user").
+						AddColumn(pqt.NewColumn(strings.Repeat("a", 64), pqt.TypeText())).
+						AddColumn(pqt.NewColumn(strings.Repeat("b", 63), pqt.TypeText())))
+			},
+			expected: []string{
+				"example.user." + strings.Repeat("a", 64) + ": identifier " + strings.Repeat("a", 64) + " is longer than 63 bytes",
+			},
+		},
+	}
+
+	for hint, c := range cases {
+		t.Run(hint, func(t *testing.T) {
+			err := c.schema().Validate()
+			if len(c.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				return
+			}
+			errs, ok := err.(pqt.ValidationErrors)
+			if !ok {
+				t.Fatalf("expected validation errors, got: %v", err)
+			}
+			if len(errs) != len(c.expected) {
+				t.Fatalf("wrong number of problems, expected %d but got %d:\n%s", len(c.expected), len(errs), errs.Error())
+			}
+			for i, exp := range c.expected {
+				if errs[i].Error() != exp {
+					t.Errorf("wrong problem #%d, expected:\n%s\nbut got:\n%s", i, exp, errs[i].Error())
+				}
+			}
+		})
+	}
+}
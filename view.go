@@ -0,0 +1,76 @@
+package pqt
+
+// View describes database view or materialized view.
+type View struct {
+	// Name is a view name.
+	Name string
+	// Query is a SELECT statement that defines the view.
+	Query string
+	// Materialized if true means that view stores its result and needs to be refreshed.
+	Materialized bool
+	// Schema references parent schema.
+	Schema *Schema
+	// Columns is a collection of output columns of the view.
+	// They are declared in the same order as query returns them.
+	Columns Columns
+	// Triggers is a collection of triggers attached to the view.
+	Triggers []*Trigger
+}
+
+// NewView allocates new view using given name, defining query and options.
+func NewView(name, query string, opts ...ViewOption) *View {
+	v := &View{
+		Name:    name,
+		Query:   query,
+		Columns: make(Columns, 0),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// FullName if schema is defined returns name in format <schema>.<name> or just <name> if not set.
+// Both parts are quoted according to quoting policy of the schema.
+func (v *View) FullName() string {
+	if v.Schema != nil && v.Schema.Name != "" {
+		return v.Schema.QuotedName() + "." + v.Schema.Quote(v.Name)
+	}
+
+	return v.Schema.Quote(v.Name)
+}
+
+// QualifiedName works like FullName, but name is never quoted.
+func (v *View) QualifiedName() string {
+	if v.Schema != nil && v.Schema.Name != "" {
+		return v.Schema.Name + "." + v.Name
+	}
+
+	return v.Name
+}
+
+// AddColumn adds output column to the view.
+// Unlike table columns, view columns keep order of declaration.
+func (v *View) AddColumn(c *Column) *View {
+	v.Columns = append(v.Columns, c)
+	return v
+}
+
+// AddTrigger attaches trigger to the view.
+func (v *View) AddTrigger(tr *Trigger) *View {
+	tr.View = v
+	v.Triggers = append(v.Triggers, tr)
+	return v
+}
+
+// ViewOption configures how we set up a view.
+type ViewOption func(*View)
+
+// WithMaterialized is view option that makes view materialized.
+func WithMaterialized() ViewOption {
+	return func(v *View) {
+		v.Materialized = true
+	}
+}
@@ -0,0 +1,28 @@
+package pqt_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqt"
+)
+
+func TestNewView(t *testing.T) {
+	v := pqt.NewView("report", "SELECT 1", pqt.WithMaterialized())
+
+	if !v.Materialized {
+		t.Errorf("view should have field materialized set to true")
+	}
+	if v.FullName() != "report" {
+		t.Errorf("wrong full name: %s", v.FullName())
+	}
+}
+
+func TestView_AddColumn(t *testing.T) {
+	v := pqt.NewView("report", "SELECT 'a', 'b'").
+		AddColumn(pqt.NewColumn("b", pqt.TypeText())).
+		AddColumn(pqt.NewColumn("a", pqt.TypeText()))
+
+	if v.Columns[0].Name != "b" || v.Columns[1].Name != "a" {
+		t.Errorf("columns are expected to keep declaration order, got: %s", v.Columns.String())
+	}
+}